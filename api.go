@@ -0,0 +1,241 @@
+package databricks
+
+import "context"
+
+// ClusterAPI is the interface of the Clusters API, implemented by
+// ClusterService.
+type ClusterAPI interface {
+	Create(ctx context.Context, createReq *ClusterCreateRequest) (string, error)
+	Edit(ctx context.Context, editReq *ClusterEditRequest) error
+	Start(ctx context.Context, clusterID string) error
+	Restart(ctx context.Context, clusterID string) error
+	ResizeWorkers(ctx context.Context, clusterID string, workers int) error
+	ResizeAutoscale(ctx context.Context, clusterID string, autoscale Autoscale) error
+	Terminate(ctx context.Context, clusterID string) error
+	Delete(ctx context.Context, clusterID string) error
+	Get(ctx context.Context, clusterID string) (*ClusterGetResponse, error)
+	Pin(ctx context.Context, clusterID string) error
+	Unpin(ctx context.Context, clusterID string) error
+	List(ctx context.Context) ([]ClusterInfo, error)
+	Zones(ctx context.Context) (*ClusterZoneResponse, error)
+	NodeTypes(ctx context.Context) ([]NodeType, error)
+	SparkVersions(ctx context.Context) ([]SparkNodeAwsAttributes, error)
+	Events(ctx context.Context, eventReq *ClusterEventRequest) (*ClusterEventResponse, error)
+	EventsIter(ctx context.Context, eventReq *ClusterEventRequest, opts ...IterOpt) *Iterator[ClusterEvent]
+	WatchEvents(ctx context.Context, clusterID string, types ...ClusterEventType) (<-chan ClusterEvent, <-chan error)
+	WaitForClusterState(ctx context.Context, clusterID string, target ClusterState, opts ...WaitOpt) (*ClusterGetResponse, error)
+	StartAndWait(ctx context.Context, clusterID string, opts ...WaitOpt) (*ClusterGetResponse, error)
+	CreateAndWait(ctx context.Context, createReq *ClusterCreateRequest, opts ...WaitOpt) (string, *ClusterGetResponse, error)
+	TerminateAndWait(ctx context.Context, clusterID string, opts ...WaitOpt) (*ClusterGetResponse, error)
+	Ensure(ctx context.Context, spec *ClusterSpec, opts ...EnsureOpt) (*EnsureResult, error)
+}
+
+// ClusterPoliciesAPI is the interface of the Cluster Policies API,
+// implemented by ClusterPoliciesService.
+type ClusterPoliciesAPI interface {
+	Create(ctx context.Context, policy *Policy) (string, error)
+	Edit(ctx context.Context, policy *Policy) error
+	Get(ctx context.Context, policyID string) (*Policy, error)
+	List(ctx context.Context) ([]Policy, error)
+	Delete(ctx context.Context, policyID string) error
+}
+
+// DBFSAPI is the interface of the DBFS API, implemented by DBFSService.
+type DBFSAPI interface {
+	AddBlock(ctx context.Context, handle int64, data []byte) error
+	Close(ctx context.Context, handle int64) error
+	Create(ctx context.Context, path string, overwrite bool) (int64, error)
+	Delete(ctx context.Context, path string, recursive bool) error
+	GetStatus(ctx context.Context, path string) (bool, int64, error)
+	List(ctx context.Context, path string) ([]FileInfo, error)
+	Mkdirs(ctx context.Context, path string) error
+	Move(ctx context.Context, src, dest string) error
+	Put(ctx context.Context, path string, content []byte, overwrite bool) error
+	Read(ctx context.Context, path string, offset, length int64) (int64, []byte, error)
+}
+
+// GroupsAPI is the interface of the Groups API, implemented by
+// GroupsService.
+type GroupsAPI interface {
+	AddMember(ctx context.Context, userName, groupName, parentName string) error
+	Create(ctx context.Context, groupName string) error
+	Members(ctx context.Context, groupName string) ([]PrincipalName, error)
+	Groups(ctx context.Context) ([]string, error)
+	UserParents(ctx context.Context, userName string) ([]string, error)
+	GroupParents(ctx context.Context, groupName string) ([]string, error)
+	RemoveUser(ctx context.Context, userName string) error
+	RemoveGroup(ctx context.Context, groupName string) error
+	Delete(ctx context.Context, groupName string) error
+}
+
+// InstancePoolsAPI is the interface of the Instance Pools API, implemented
+// by InstancePoolsService.
+type InstancePoolsAPI interface {
+	Create(ctx context.Context, spec *InstancePoolSpec) (string, error)
+	Edit(ctx context.Context, editReq *InstancePoolEditRequest) error
+	Get(ctx context.Context, instancePoolID string) (*InstancePool, error)
+	List(ctx context.Context) ([]InstancePool, error)
+	Delete(ctx context.Context, instancePoolID string) error
+}
+
+// JobsAPI is the interface of the Jobs API, implemented by JobsService.
+type JobsAPI interface {
+	Create(ctx context.Context, createReq *JobCreateRequest) (int64, error)
+	List(ctx context.Context) ([]Job, error)
+	ListPage(ctx context.Context, offset, limit int) ([]Job, bool, error)
+	ListIter(ctx context.Context, opts ...IterOpt) *Iterator[Job]
+	Delete(ctx context.Context, jobID int64) error
+	Get(ctx context.Context, jobID int64) (*JobGetResponse, error)
+	Reset(ctx context.Context, jobID int64, settings JobSettings) error
+	RunNow(ctx context.Context, settings *JobRunNowSettings) (int64, int64, error)
+	RunSubmit(ctx context.Context, settings *JobSubmitSettings) (int64, error)
+	RunsList(ctx context.Context, runListReq *JobRunListRequest) ([]Run, bool, error)
+	RunsIter(ctx context.Context, runListReq *JobRunListRequest, opts ...IterOpt) *Iterator[Run]
+	RunsGet(ctx context.Context, runID int64) (*JobRunGetResponse, error)
+	RunsExport(ctx context.Context, runID int64, viewToExport string) ([]View, error)
+	RunsCancel(ctx context.Context, runID int64) error
+	RunsGetOutput(ctx context.Context, runID int64) (string, *Run, error)
+	RunsDelete(ctx context.Context, runID int64) error
+	WaitForRun(ctx context.Context, runID int64, opts ...WaitOpt) (*JobRunGetResponse, error)
+}
+
+// LibrariesAPI is the interface of the Libraries API, implemented by
+// LibrariesService.
+type LibrariesAPI interface {
+	AllClusterStatuses(ctx context.Context) ([]ClusterLibraryStatuses, error)
+	ClusterStatus(ctx context.Context, clusterID string) ([]LibraryFullStatus, error)
+	Install(ctx context.Context, clusterID string, libraries []Library) error
+	Uninstall(ctx context.Context, clusterID string, libraries []Library) error
+	WaitForInstall(ctx context.Context, clusterID string, opts ...WaitOpt) ([]LibraryFullStatus, error)
+}
+
+// PermissionsAPI is the interface of the Permissions API, implemented by
+// PermissionsService.
+type PermissionsAPI interface {
+	Get(ctx context.Context, objectType PermissionObjectType, objectID string) (*ObjectPermissions, error)
+	Set(ctx context.Context, objectType PermissionObjectType, objectID string, acl []AccessControlRequest) (*ObjectPermissions, error)
+	Update(ctx context.Context, objectType PermissionObjectType, objectID string, acl []AccessControlRequest) (*ObjectPermissions, error)
+	PermissionLevels(ctx context.Context, objectType PermissionObjectType, objectID string) ([]PermissionLevelDescription, error)
+}
+
+// ProfilesAPI is the interface of the Instance Profiles API, implemented by
+// ProfilesService.
+type ProfilesAPI interface {
+	Add(ctx context.Context, profileARN string, skipValidation bool) error
+	List(ctx context.Context) ([]string, error)
+	Remove(ctx context.Context, profileARN string) error
+}
+
+// SecretsAPI is the interface of the Secrets API, implemented by
+// SecretsService.
+type SecretsAPI interface {
+	CreateSecretScope(ctx context.Context, scope, initialManagePrincipal string) error
+	DeleteSecretScope(ctx context.Context, scope string) error
+	ListSecretScopes(ctx context.Context) ([]SecretScope, error)
+	PutSecret(ctx context.Context, scope, key, value string) error
+	DeleteSecret(ctx context.Context, scope, key string) error
+	ListSecrets(ctx context.Context) ([]SecretMetadata, error)
+	PutSecretACL(ctx context.Context, scope, principal string, permission ACLPermission) error
+	DeleteSecretACL(ctx context.Context, scope, principal string) error
+	GetSecretACL(ctx context.Context, scope, principal string) (ACLPermission, error)
+	ListSecretACLs(ctx context.Context, scope string) ([]ACLItem, error)
+}
+
+// TokenAPI is the interface of the Token API, implemented by TokenService.
+type TokenAPI interface {
+	Create(ctx context.Context, lifetimeSec uint) (string, *PublicTokenInfo, error)
+	List(ctx context.Context) ([]PublicTokenInfo, error)
+	Revoke(ctx context.Context, tokens []PublicTokenInfo) error
+}
+
+// WorkspaceAPI is the interface of the Workspace API, implemented by
+// WorkspaceService.
+type WorkspaceAPI interface {
+	Delete(ctx context.Context, path string, recursive bool) error
+	Export(ctx context.Context, path string) ([]byte, error)
+	GetStatus(ctx context.Context, path string) (Language, ObjectType, error)
+	Import(
+		ctx context.Context,
+		path string,
+		content []byte,
+		language Language,
+		overwrite bool,
+		format string,
+	) error
+	List(ctx context.Context, path string) ([]ObjectInfo, error)
+	Mkdirs(ctx context.Context, path string) error
+}
+
+var (
+	_ ClusterAPI         = (*ClusterService)(nil)
+	_ ClusterPoliciesAPI = (*ClusterPoliciesService)(nil)
+	_ DBFSAPI            = (*DBFSService)(nil)
+	_ GroupsAPI          = (*GroupsService)(nil)
+	_ InstancePoolsAPI   = (*InstancePoolsService)(nil)
+	_ JobsAPI            = (*JobsService)(nil)
+	_ LibrariesAPI       = (*LibrariesService)(nil)
+	_ PermissionsAPI     = (*PermissionsService)(nil)
+	_ ProfilesAPI        = (*ProfilesService)(nil)
+	_ SecretsAPI         = (*SecretsService)(nil)
+	_ TokenAPI           = (*TokenService)(nil)
+	_ WorkspaceAPI       = (*WorkspaceService)(nil)
+)
+
+// Services gives access to every service of a workspace through its
+// interface. Code that depends on Services instead of *Client can be tested
+// with the mocks of the databricksmock package:
+//
+//	func deleteJobs(ctx context.Context, services databricks.Services) error {
+//		jobs, err := services.Jobs().List(ctx)
+//		...
+//	}
+//
+//	err := deleteJobs(ctx, client.Services())
+type Services interface {
+	Cluster() ClusterAPI
+	ClusterPolicies() ClusterPoliciesAPI
+	DBFS() DBFSAPI
+	Groups() GroupsAPI
+	InstancePools() InstancePoolsAPI
+	Jobs() JobsAPI
+	Libraries() LibrariesAPI
+	Permissions() PermissionsAPI
+	Profiles() ProfilesAPI
+	Secrets() SecretsAPI
+	Token() TokenAPI
+	Workspace() WorkspaceAPI
+}
+
+// Services returns the services of the client behind their interfaces.
+func (c *Client) Services() Services {
+	return clientServices{c}
+}
+
+// clientServices implements Services with the services of a Client.
+type clientServices struct {
+	c *Client
+}
+
+func (s clientServices) Cluster() ClusterAPI { return s.c.Cluster() }
+
+func (s clientServices) ClusterPolicies() ClusterPoliciesAPI { return s.c.ClusterPolicies() }
+
+func (s clientServices) DBFS() DBFSAPI { return s.c.DBFS() }
+
+func (s clientServices) Groups() GroupsAPI { return s.c.Groups() }
+
+func (s clientServices) InstancePools() InstancePoolsAPI { return s.c.InstancePools() }
+
+func (s clientServices) Jobs() JobsAPI { return s.c.Jobs() }
+
+func (s clientServices) Libraries() LibrariesAPI { return s.c.Libraries() }
+
+func (s clientServices) Permissions() PermissionsAPI { return s.c.Permissions() }
+
+func (s clientServices) Profiles() ProfilesAPI { return s.c.Profiles() }
+
+func (s clientServices) Secrets() SecretsAPI { return s.c.Secrets() }
+
+func (s clientServices) Token() TokenAPI { return s.c.Token() }
+
+func (s clientServices) Workspace() WorkspaceAPI { return s.c.Workspace() }
@@ -0,0 +1,54 @@
+package databricks
+
+import (
+	"testing"
+)
+
+func Test_Client_Services(t *testing.T) {
+	t.Parallel()
+	client, err := NewClient("test-account")
+	if err != nil {
+		t.Fatal(err)
+	}
+	services := client.Services()
+	if _, ok := services.Cluster().(*ClusterService); !ok {
+		t.Fatalf("Cluster returned %T", services.Cluster())
+	}
+	if _, ok := services.ClusterPolicies().(*ClusterPoliciesService); !ok {
+		t.Fatalf("ClusterPolicies returned %T", services.ClusterPolicies())
+	}
+	if _, ok := services.DBFS().(*DBFSService); !ok {
+		t.Fatalf("DBFS returned %T", services.DBFS())
+	}
+	if _, ok := services.Groups().(*GroupsService); !ok {
+		t.Fatalf("Groups returned %T", services.Groups())
+	}
+	if _, ok := services.InstancePools().(*InstancePoolsService); !ok {
+		t.Fatalf("InstancePools returned %T", services.InstancePools())
+	}
+	if _, ok := services.Jobs().(*JobsService); !ok {
+		t.Fatalf("Jobs returned %T", services.Jobs())
+	}
+	if _, ok := services.Libraries().(*LibrariesService); !ok {
+		t.Fatalf("Libraries returned %T", services.Libraries())
+	}
+	if _, ok := services.Permissions().(*PermissionsService); !ok {
+		t.Fatalf("Permissions returned %T", services.Permissions())
+	}
+	if _, ok := services.Profiles().(*ProfilesService); !ok {
+		t.Fatalf("Profiles returned %T", services.Profiles())
+	}
+	if _, ok := services.Secrets().(*SecretsService); !ok {
+		t.Fatalf("Secrets returned %T", services.Secrets())
+	}
+	if _, ok := services.Token().(*TokenService); !ok {
+		t.Fatalf("Token returned %T", services.Token())
+	}
+	ws, ok := services.Workspace().(*WorkspaceService)
+	if !ok {
+		t.Fatalf("Workspace returned %T", services.Workspace())
+	}
+	if ws.client.host != client.host {
+		t.Fatalf("Workspace uses host %q, expected %q", ws.client.host, client.host)
+	}
+}
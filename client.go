@@ -3,6 +3,9 @@ package databricks
 import (
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 )
 
 // ListOrder is a listing order.
@@ -12,13 +15,14 @@ const (
 	// Desc is descending.
 	Desc ListOrder = "DESC"
 	// Asc is ascending.
-	Asc = "ASC"
+	Asc ListOrder = "ASC"
 )
 
 // ClientOpt is used for configuring a Client.
 type ClientOpt func(*Client) error
 
-// ClientHTTPClient configures the Client's HTTP client.
+// ClientHTTPClient configures the Client's HTTP client. Credentials
+// configured with ClientCredentials are added on top of its transport.
 func ClientHTTPClient(client *http.Client) ClientOpt {
 	return func(c *Client) error {
 		c.client = client
@@ -26,20 +30,62 @@ func ClientHTTPClient(client *http.Client) ClientOpt {
 	}
 }
 
+// ClientHost configures the Client to use a full workspace URL instead of
+// deriving it from the account name. This is required for Azure
+// (adb-*.azuredatabricks.net), GCP (*.gcp.databricks.com) and private link
+// deployments. A bare hostname is assumed to use https.
+func ClientHost(host string) ClientOpt {
+	return func(c *Client) error {
+		normalized, err := normalizeHost(host)
+		if err != nil {
+			return err
+		}
+		c.host = normalized
+		return nil
+	}
+}
+
+// ClientCloud overrides the cloud that is detected from the workspace host.
+// This is useful for private link hostnames that don't follow the standard
+// naming of a cloud.
+func ClientCloud(cloud Cloud) ClientOpt {
+	return func(c *Client) error {
+		switch cloud {
+		case CloudAWS, CloudAzure, CloudGCP:
+			c.cloud = cloud
+			return nil
+		}
+		return fmt.Errorf("Unknown cloud: %q", cloud)
+	}
+}
+
 // Client is a Databricks client.
 type Client struct {
 	client  *http.Client
 	account string
+	host    string
+	cloud   Cloud
 	url     string
+	retry   RetryPolicy
+	limiter *rateLimiter
+
+	eventPollInterval time.Duration
+
+	credentials CredentialsProvider
+	middleware  []Middleware
 }
 
-// NewClient returns a new Databricks client.
+// NewClient returns a new Databricks client. The account is used to derive
+// an AWS workspace host of the form https://<account>.cloud.databricks.com,
+// it may be left empty when the ClientHost option is used.
 func NewClient(account string, opts ...ClientOpt) (*Client, error) {
 	c := &Client{
 		account: account,
-		url:     fmt.Sprintf("https://%s.cloud.databricks.com/api/", account),
 		client:  http.DefaultClient,
 	}
+	if account != "" {
+		c.host = fmt.Sprintf("https://%s.cloud.databricks.com", account)
+	}
 
 	for _, opt := range opts {
 		if err := opt(c); err != nil {
@@ -47,9 +93,70 @@ func NewClient(account string, opts ...ClientOpt) (*Client, error) {
 		}
 	}
 
+	if c.host == "" {
+		return nil, fmt.Errorf("Must specify either an account or a host")
+	}
+	if c.cloud == "" {
+		c.cloud = detectCloud(c.host)
+	}
+	c.url = c.host + "/api/"
+	if c.credentials != nil {
+		client := *c.client
+		client.Transport = newCredentialsTransport(
+			c.credentials, client.Transport)
+		c.client = &client
+	}
+
 	return c, nil
 }
 
+// Host returns the workspace URL the client is configured for, for example
+// https://adb-1234567890123456.7.azuredatabricks.net.
+func (c *Client) Host() string {
+	return c.host
+}
+
+// Cloud returns the cloud the workspace is deployed on.
+func (c *Client) Cloud() Cloud {
+	return c.cloud
+}
+
+// normalizeHost validates a workspace host and returns it as a URL without a
+// trailing slash.
+func normalizeHost(host string) (string, error) {
+	host = strings.TrimSpace(host)
+	if host == "" {
+		return "", fmt.Errorf("Host must not be empty")
+	}
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return "", fmt.Errorf("Invalid host %q: %s", host, err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "", fmt.Errorf(
+			"Invalid host %q: scheme must be http or https", host)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("Invalid host %q: missing hostname", host)
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf(
+			"Invalid host %q: must not contain credentials, a query or a fragment",
+			host,
+		)
+	}
+	path := strings.TrimSuffix(u.Path, "/")
+	if path != "" {
+		return "", fmt.Errorf(
+			"Invalid host %q: must not contain a path", host)
+	}
+
+	return u.Scheme + "://" + u.Host, nil
+}
+
 // Do is a proxy to the http.Client's Do method.
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
 	return c.client.Do(req)
@@ -62,6 +169,14 @@ func (c *Client) Cluster() *ClusterService {
 	}
 }
 
+// ClusterPolicies returns a ClusterPoliciesService for the corresponding
+// client.
+func (c *Client) ClusterPolicies() *ClusterPoliciesService {
+	return &ClusterPoliciesService{
+		client: *c,
+	}
+}
+
 // DBFS returns a DBFSService for the corresponding client.
 func (c *Client) DBFS() *DBFSService {
 	return &DBFSService{
@@ -76,6 +191,14 @@ func (c *Client) Groups() *GroupsService {
 	}
 }
 
+// InstancePools returns an InstancePoolsService for the corresponding
+// client.
+func (c *Client) InstancePools() *InstancePoolsService {
+	return &InstancePoolsService{
+		client: *c,
+	}
+}
+
 // Jobs returns a JobsService for the corresponding client.
 func (c *Client) Jobs() *JobsService {
 	return &JobsService{
@@ -90,6 +213,13 @@ func (c *Client) Libraries() *LibrariesService {
 	}
 }
 
+// Permissions returns a PermissionsService for the corresponding client.
+func (c *Client) Permissions() *PermissionsService {
+	return &PermissionsService{
+		client: *c,
+	}
+}
+
 // Profiles returns a ProfilesService for the corresponding client.
 func (c *Client) Profiles() *ProfilesService {
 	return &ProfilesService{
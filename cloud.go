@@ -0,0 +1,63 @@
+package databricks
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Cloud is the cloud provider a Databricks workspace is deployed on.
+type Cloud string
+
+const (
+	// CloudAWS is Databricks on AWS.
+	CloudAWS Cloud = "AWS"
+	// CloudAzure is Azure Databricks.
+	CloudAzure Cloud = "AZURE"
+	// CloudGCP is Databricks on Google Cloud.
+	CloudGCP Cloud = "GCP"
+)
+
+var azureHostSuffixes = []string{
+	".azuredatabricks.net",
+	".databricks.azure.cn",
+	".databricks.azure.us",
+}
+
+var gcpHostSuffixes = []string{
+	".gcp.databricks.com",
+}
+
+// detectCloud guesses the cloud of a workspace from its host, defaulting to
+// AWS for hosts it doesn't recognise.
+func detectCloud(host string) Cloud {
+	if u, err := url.Parse(host); err == nil && u.Host != "" {
+		host = u.Hostname()
+	}
+	host = strings.ToLower(host)
+	for _, suffix := range azureHostSuffixes {
+		if strings.HasSuffix(host, suffix) {
+			return CloudAzure
+		}
+	}
+	for _, suffix := range gcpHostSuffixes {
+		if strings.HasSuffix(host, suffix) {
+			return CloudGCP
+		}
+	}
+	return CloudAWS
+}
+
+// IsAWS returns if the cloud is AWS.
+func (c Cloud) IsAWS() bool {
+	return c == CloudAWS
+}
+
+// IsAzure returns if the cloud is Azure.
+func (c Cloud) IsAzure() bool {
+	return c == CloudAzure
+}
+
+// IsGCP returns if the cloud is GCP.
+func (c Cloud) IsGCP() bool {
+	return c == CloudGCP
+}
@@ -1,79 +1,75 @@
 package databricks
 
+// ClusterSpec is the specification of a cluster, as sent when a cluster is
+// created or edited and as returned when it is described. It is embedded in
+// every request and response that carries one, including the new cluster of
+// a job.
+type ClusterSpec struct {
+	// NumWorkers is the number of workers of a fixed size cluster. Only one
+	// of NumWorkers and Autoscale is set.
+	NumWorkers *int32 `json:"num_workers,omitempty"`
+	// Autoscale are the bounds of an autoscaling cluster.
+	Autoscale   *Autoscale `json:"autoscale,omitempty"`
+	ClusterName string     `json:"cluster_name,omitempty"`
+	// SparkVersion is the key of a Databricks Runtime version, e.g.
+	// "13.3.x-scala2.12".
+	SparkVersion string `json:"spark_version"`
+	// SparkConf are Spark configuration properties, e.g.
+	// {"spark.speculation": "true"}.
+	SparkConf       map[string]string `json:"spark_conf,omitempty"`
+	AWSAttributes   *AWSAttributes    `json:"aws_attributes,omitempty"`
+	AzureAttributes *AzureAttributes  `json:"azure_attributes,omitempty"`
+	GCPAttributes   *GCPAttributes    `json:"gcp_attributes,omitempty"`
+	// NodeTypeID is the node type of the workers, and of the driver unless
+	// DriverNodeTypeID is set.
+	NodeTypeID       string   `json:"node_type_id,omitempty"`
+	DriverNodeTypeID string   `json:"driver_node_type_id,omitempty"`
+	SSHPublicKeys    []string `json:"ssh_public_keys,omitempty"`
+	// CustomTags are added to the cloud resources of the cluster, in
+	// addition to its DefaultTags.
+	CustomTags     map[string]string `json:"custom_tags,omitempty"`
+	ClusterLogConf *ClusterLogConf   `json:"cluster_log_conf,omitempty"`
+	InitScripts    []InitScriptInfo  `json:"init_scripts,omitempty"`
+	// SparkEnvVars are environment variables of the driver and workers,
+	// e.g. {"SPARK_WORKER_MEMORY": "28000m"}.
+	SparkEnvVars map[string]string `json:"spark_env_vars,omitempty"`
+	// AutoterminationMinutes is the number of idle minutes after which the
+	// cluster terminates. 0 disables automatic termination, and nil uses the
+	// default of the API.
+	AutoterminationMinutes    *int32 `json:"autotermination_minutes,omitempty"`
+	EnableElasticDisk         bool   `json:"enable_elastic_disk,omitempty"`
+	EnableLocalDiskEncryption bool   `json:"enable_local_disk_encryption,omitempty"`
+	DataSecurityMode          string `json:"data_security_mode,omitempty"`
+	SingleUserName            string `json:"single_user_name,omitempty"`
+	RuntimeEngine             string `json:"runtime_engine,omitempty"`
+	// InstancePoolID is the ID of the instance pool that the workers get
+	// their instances from, and the driver too unless DriverInstancePoolID
+	// is set. The node type of the pool is used, so NodeTypeID must not be
+	// set.
+	InstancePoolID       string `json:"instance_pool_id,omitempty"`
+	DriverInstancePoolID string `json:"driver_instance_pool_id,omitempty"`
+	// PolicyID is the ID of the cluster policy that the cluster is
+	// created with, and validated against.
+	PolicyID string `json:"policy_id,omitempty"`
+	// ApplyPolicyDefaultValues fills the attributes that are not set with
+	// the default values of the policy.
+	ApplyPolicyDefaultValues bool `json:"apply_policy_default_values,omitempty"`
+}
+
 // ClusterCreateRequest is a Create request for a Cluster.
 type ClusterCreateRequest struct {
-	NumWorkers             *int32            `json:"num_workers,omitempty"`
-	Autoscale              *Autoscale        `json:"autoscale,omitempty"`
-	ClusterID              string            `json:"cluster_id"`
-	ClusterName            string            `json:"cluster_name"`
-	SparkVersion           string            `json:"spark_version"`
-	SparkConf              *S3StorageInfo    `json:"spark_conf,omitempty"`
-	AWSAttributes          *AWSAttributes    `json:"aws_attributes,omitempty"`
-	NodeTypeID             string            `json:"node_type_id"`
-	DriverNodeTypeID       string            `json:"driver_node_type_id"`
-	SSHPublicKeys          []string          `json:"ssh_public_keys"`
-	CustomTags             []ClusterTag      `json:"custom_tags"`
-	ClusterLogConf         *ClusterLogConf   `json:"cluster_log_conf,omitempty"`
-	InitScripts            []InitScriptInfo  `json:"init_scripts"`
-	SparkEnvVars           map[string]string `json:"spark_env_vars,omitempty"`
-	AutoterminationMinutes int32             `json:"autotermination_minutes"`
-	EnableElasticDisk      bool              `json:"enable_elastic_disk"`
+	ClusterSpec
 }
 
-// ClusterEditRequest is a Edit request for a Cluster.
+// ClusterEditRequest is a Edit request for a Cluster. It replaces the whole
+// spec of the cluster.
 type ClusterEditRequest struct {
-	NumWorkers             *int32            `json:"num_workers,omitempty"`
-	Autoscale              *Autoscale        `json:"autoscale,omitempty"`
-	ClusterID              string            `json:"cluster_id"`
-	ClusterName            string            `json:"cluster_name"`
-	SparkVersion           string            `json:"spark_version"`
-	SparkConf              *S3StorageInfo    `json:"spark_conf,omitempty"`
-	AWSAttributes          *AWSAttributes    `json:"aws_attributes,omitempty"`
-	NodeTypeID             string            `json:"node_type_id"`
-	DriverNodeTypeID       string            `json:"driver_node_type_id"`
-	SSHPublicKeys          []string          `json:"ssh_public_keys,omitempty"`
-	CustomTags             []ClusterTag      `json:"custom_tags,omitempty"`
-	ClusterLogConf         ClusterLogConf    `json:"cluster_log_conf"`
-	InitScripts            []InitScriptInfo  `json:"init_scripts,omitempty"`
-	SparkEnvVars           map[string]string `json:"spark_env_vars,omitempty"`
-	AutoterminationMinutes int32             `json:"autotermination_minutes"`
-	EnableElasticDisk      bool              `json:"enable_elastic_disk"`
+	ClusterID string `json:"cluster_id"`
+	ClusterSpec
 }
 
 // ClusterGetResponse is a response for a Cluster Get request.
-type ClusterGetResponse struct {
-	NumWorkers             *int32            `json:"num_workers,omitempty"`
-	Autoscale              *Autoscale        `json:"autoscale,omitempty"`
-	ClusterID              string            `json:"cluster_id"`
-	CreatorUserName        string            `json:"creator_user_name"`
-	Driver                 *SparkNode        `json:"driver"`
-	Executors              []SparkNode       `json:"executors"`
-	SparkContextID         int64             `json:"spark_context_id"`
-	JDBCPort               int32             `json:"jdbc_port"`
-	ClusterName            string            `json:"cluster_name"`
-	SparkVersion           string            `json:"spark_version"`
-	SparkConf              map[string]string `json:"spark_conf"`
-	AWSAttributes          AWSAttributes     `json:"aws_attributes"`
-	NodeTypeID             string            `json:"node_type_id"`
-	DriverNodeTypeID       string            `json:"driver_node_type_id"`
-	SSHPublicKeys          []string          `json:"ssh_public_keys"`
-	CustomTags             map[string]string `json:"custom_tags"`
-	ClusterLogConf         *ClusterLogConf   `json:"cluster_log_conf"`
-	InitScripts            []InitScriptInfo  `json:"init_scripts"`
-	SparkEnvVars           map[string]string `json:"spark_env_vars"`
-	AutoterminationMinutes int32             `json:"autotermination_minutes"`
-	EnableElasticDisk      bool              `json:"enable_elastic_disk"`
-	ClusterSource          *AWSAvailability  `json:"cluster_source"`
-	State                  ClusterState      `json:"state"`
-	StateMessage           string            `json:"state_message"`
-	StartTime              int64             `json:"start_time"`
-	TerminatedTime         int64             `json:"terminated_time"`
-	LastStateLossTime      int64             `json:"last_state_loss_time"`
-	LastActivityTime       int64             `json:"last_activity_time"`
-	ClusterMemoryMB        int64             `json:"cluster_memory_mb"`
-	ClusterCores           float32           `json:"cluster_cores"`
-	DefaultTags            map[string]string `json:"default_tags"`
-}
+type ClusterGetResponse = ClusterInfo
 
 // Autoscale is used to set the bounds on autoscaling a Cluster.
 type Autoscale struct {
@@ -92,8 +88,8 @@ type AWSAvailability string
 
 const (
 	Spot             AWSAvailability = "SPOT"
-	OnDemand                         = "ON_DEMAND"
-	SpotWithFallBack                 = "SPOT_WITH_FALLBACK"
+	OnDemand         AWSAvailability = "ON_DEMAND"
+	SpotWithFallBack AWSAvailability = "SPOT_WITH_FALLBACK"
 )
 
 // EBSVolumeType EBS volume types that Databricks supports. See Amazon EBS
@@ -102,7 +98,7 @@ type EBSVolumeType string
 
 const (
 	SSD EBSVolumeType = "GENERAL_PURPOSE_SSD"
-	HDD               = "THROUGHPUT_OPTIMIZED_HDD"
+	HDD EBSVolumeType = "THROUGHPUT_OPTIMIZED_HDD"
 )
 
 // ClusterSource is the service that created the cluster.
@@ -110,8 +106,8 @@ type ClusterSource string
 
 const (
 	UI         ClusterSource = "UI"
-	ClusterJob               = "JOB"
-	API                      = "API"
+	ClusterJob ClusterSource = "JOB"
+	API        ClusterSource = "API"
 )
 
 // ClusterState is the state of a cluster. The current allowable state
@@ -130,29 +126,75 @@ const (
 type ClusterState string
 
 const (
-	Pending     ClusterState = "PENDING"
-	Running                  = "RUNNING"
-	Terminating              = "TERMINATING"
-	Resizing                 = "RESIZING"
-	Restarting               = "RESTARTING"
-	Terminated               = "TERMINATED"
+	Pending        ClusterState = "PENDING"
+	Running        ClusterState = "RUNNING"
+	Terminating    ClusterState = "TERMINATING"
+	Resizing       ClusterState = "RESIZING"
+	Restarting     ClusterState = "RESTARTING"
+	Terminated     ClusterState = "TERMINATED"
+	ClusterError   ClusterState = "ERROR"
+	ClusterUnknown ClusterState = "UNKNOWN"
+)
+
+// TerminationCode is the reason code of a cluster termination.
+type TerminationCode string
+
+const (
+	// TerminationUserRequest is a termination requested by a user.
+	TerminationUserRequest TerminationCode = "USER_REQUEST"
+	// TerminationJobFinished is the termination of a job cluster after its
+	// run finished.
+	TerminationJobFinished TerminationCode = "JOB_FINISHED"
+	// TerminationInactivity is an automatic termination after the cluster
+	// was idle for autotermination_minutes.
+	TerminationInactivity TerminationCode = "INACTIVITY"
+	// TerminationCloudProviderShutdown is a shutdown of the instance that
+	// hosted the driver by the cloud provider.
+	TerminationCloudProviderShutdown TerminationCode = "CLOUD_PROVIDER_SHUTDOWN"
+	// TerminationCommunicationLost is a loss of the connection to the
+	// driver instance.
+	TerminationCommunicationLost TerminationCode = "COMMUNICATION_LOST"
+	// TerminationCloudProviderLaunchFailure is a failure to acquire
+	// instances from the cloud provider.
+	TerminationCloudProviderLaunchFailure TerminationCode = "CLOUD_PROVIDER_LAUNCH_FAILURE"
+	// TerminationSparkStartupFailure is a failure to initialize Spark.
+	TerminationSparkStartupFailure TerminationCode = "SPARK_STARTUP_FAILURE"
+	// TerminationInvalidArgument is a launch with an invalid argument, such
+	// as an invalid instance profile.
+	TerminationInvalidArgument TerminationCode = "INVALID_ARGUMENT"
+	// TerminationUnexpectedLaunchFailure is an unexpected error while
+	// launching the cluster.
+	TerminationUnexpectedLaunchFailure TerminationCode = "UNEXPECTED_LAUNCH_FAILURE"
+	// TerminationInternalError is an internal error of Databricks.
+	TerminationInternalError TerminationCode = "INTERNAL_ERROR"
+	// TerminationInstanceUnreachable is a driver instance that Databricks
+	// could not reach.
+	TerminationInstanceUnreachable TerminationCode = "INSTANCE_UNREACHABLE"
+	// TerminationRequestRejected is a request rejected by Databricks
+	// because it is temporarily unavailable.
+	TerminationRequestRejected TerminationCode = "REQUEST_REJECTED"
+	// TerminationInitScriptFailure is a failing init script.
+	TerminationInitScriptFailure TerminationCode = "INIT_SCRIPT_FAILURE"
+	// TerminationTrialExpired is an expired Databricks trial.
+	TerminationTrialExpired TerminationCode = "TRIAL_EXPIRED"
 )
 
 // TerminationReason is the reason why a Cluster terminated.
 type TerminationReason struct {
-	Code       EBSVolumeType     `json:"code"`
-	Parameters map[string]string `json:"parameters"`
+	Code       TerminationCode   `json:"code"`
+	Type       string            `json:"type,omitempty"`
+	Parameters map[string]string `json:"parameters,omitempty"`
 }
 
 // S3StorageInfo is S3 storage information.
 type S3StorageInfo struct {
 	Destination      string `json:"destination"`
-	Region           string `json:"region"`
-	Endpoint         string `json:"endpoint"`
-	EnableEncryption bool   `json:"enable_encryption"`
-	EncryptionType   string `json:"encryption_type"`
-	KMSKey           string `json:"kms_key"`
-	CannedACL        string `json:"canned_acl"`
+	Region           string `json:"region,omitempty"`
+	Endpoint         string `json:"endpoint,omitempty"`
+	EnableEncryption bool   `json:"enable_encryption,omitempty"`
+	EncryptionType   string `json:"encryption_type,omitempty"`
+	KMSKey           string `json:"kms_key,omitempty"`
+	CannedACL        string `json:"canned_acl,omitempty"`
 }
 
 // DbfsStorageInfo is DBFS storage info.
@@ -160,24 +202,17 @@ type DbfsStorageInfo struct {
 	Destination string `json:"destination"`
 }
 
+// WorkspaceStorageInfo is the location of a workspace file.
+type WorkspaceStorageInfo struct {
+	Destination string `json:"destination"`
+}
+
 // SparkVersion represents a Databricks Spark version.
 type SparkVersion struct {
 	Key  string `json:"key"`
 	Name string `json:"name"`
 }
 
-// SparkConfPair are Spark configuration key-value pairs.
-type SparkConfPair struct {
-	Key   string `json:"key"`
-	Value string `json:"value"`
-}
-
-// SparkEnvPair are Spark environment variable key-value pairs.
-type SparkEnvPair struct {
-	Key   string `json:"key"`
-	Value string `json:"value"`
-}
-
 // SparkNodeAwsAttributes are attributes specific to AWS for a Spark node.
 type SparkNodeAwsAttributes struct {
 	IsSpot bool `json:"is_spot"`
@@ -189,7 +224,7 @@ type SparkNode struct {
 	PublicDNS         string                 `json:"public_dns"`
 	NodeID            string                 `json:"node_id"`
 	InstanceID        string                 `json:"instance_id"`
-	StartTimestamp    int64                  `json:"start_timestamp"`
+	StartTimestamp    EpochMillis            `json:"start_timestamp"`
 	NodeAWSAttributes SparkNodeAwsAttributes `json:"node_aws_attributes"`
 	HostPrivateIP     string                 `json:"host_private_ip"`
 }
@@ -206,9 +241,9 @@ type NodeType struct {
 
 // AWSAttributes is used to set AWS attributes.
 type AWSAttributes struct {
-	FirstOnDemand       int32           `json:"first_on_demand"`
-	Availability        AWSAvailability `json:"availability"`
-	ZoneID              string          `json:"zone_id"`
+	FirstOnDemand       int32           `json:"first_on_demand,omitempty"`
+	Availability        AWSAvailability `json:"availability,omitempty"`
+	ZoneID              string          `json:"zone_id,omitempty"`
 	InstanceProfileARN  *string         `json:"instance_profile_arn,omitempty"`
 	SpotBidPricePercent *int32          `json:"spot_bid_price_percent,omitempty"`
 	EBSVolumeType       *EBSVolumeType  `json:"ebs_volume_type,omitempty"`
@@ -216,111 +251,223 @@ type AWSAttributes struct {
 	EBSVolumeSize       *int32          `json:"ebs_volume_size,omitempty"`
 }
 
-// ClusterTag is a key value pair of cluster tags.
-type ClusterTag struct {
-	Key   string `json:"key"`
-	Value string `json:"value"`
+// AzureAvailability is used to configure Azure availability.
+type AzureAvailability string
+
+const (
+	SpotAzure             AzureAvailability = "SPOT_AZURE"
+	OnDemandAzure         AzureAvailability = "ON_DEMAND_AZURE"
+	SpotWithFallbackAzure AzureAvailability = "SPOT_WITH_FALLBACK_AZURE"
+)
+
+// AzureAttributes is used to set Azure attributes.
+type AzureAttributes struct {
+	FirstOnDemand   int32             `json:"first_on_demand,omitempty"`
+	Availability    AzureAvailability `json:"availability,omitempty"`
+	SpotBidMaxPrice *float64          `json:"spot_bid_max_price,omitempty"`
+}
+
+// GCPAvailability is used to configure GCP availability.
+type GCPAvailability string
+
+const (
+	PreemptibleGCP             GCPAvailability = "PREEMPTIBLE_GCP"
+	OnDemandGCP                GCPAvailability = "ON_DEMAND_GCP"
+	PreemptibleWithFallbackGCP GCPAvailability = "PREEMPTIBLE_WITH_FALLBACK_GCP"
+)
+
+// GCPAttributes is used to set GCP attributes.
+type GCPAttributes struct {
+	GoogleServiceAccount    string          `json:"google_service_account,omitempty"`
+	BootDiskSize            int32           `json:"boot_disk_size,omitempty"`
+	Availability            GCPAvailability `json:"availability,omitempty"`
+	LocalSSDCount           int32           `json:"local_ssd_count,omitempty"`
+	UsePreemptibleExecutors bool            `json:"use_preemptible_executors,omitempty"`
+	ZoneID                  string          `json:"zone_id,omitempty"`
 }
 
 // ClusterLogConf is used to configure Cluster logging.
 type ClusterLogConf struct {
-	DBFS *DbfsStorageInfo `json:"dbfs"`
-	S3   *S3StorageInfo   `json:"s3"`
+	DBFS *DbfsStorageInfo `json:"dbfs,omitempty"`
+	S3   *S3StorageInfo   `json:"s3,omitempty"`
 }
 
 // InitScriptInfo is info for an init script.
 type InitScriptInfo struct {
-	DBFS *DbfsStorageInfo `json:"dbfs"`
-	S3   *S3StorageInfo   `json:"s3"`
+	DBFS      *DbfsStorageInfo      `json:"dbfs,omitempty"`
+	S3        *S3StorageInfo        `json:"s3,omitempty"`
+	Workspace *WorkspaceStorageInfo `json:"workspace,omitempty"`
 }
 
 // LogSyncStatus is the statu of log synchronization
 type LogSyncStatus struct {
-	LastAttempted int64  `json:"last_attempted"`
-	LastException string `json:"last_exception"`
+	LastAttempted EpochMillis `json:"last_attempted"`
+	LastException string      `json:"last_exception,omitempty"`
 }
 
 // ClusterInfo describes all of the metadata about a single Spark cluster in
 // Databricks.
 type ClusterInfo struct {
-	NumWorkers             *int32            `json:"num_workers"`
-	Autoscale              *Autoscale        `json:"autoscale"`
-	ClusterID              string            `json:"cluster_id"`
-	CreatorUserName        string            `json:"creator_user_name"`
-	Driver                 SparkNode         `json:"driver"`
-	Executors              []SparkNode       `json:"executors"`
-	SparkContextID         int64             `json:"spark_context_id"`
-	JDBCPort               int32             `json:"jdbc_port"`
-	ClusterName            string            `json:"cluster_name"`
-	SparkVersion           string            `json:"spark_version"`
-	SparkConf              S3StorageInfo     `json:"spark_conf"` //Example Spark confs: {"spark.speculation": true, "spark.streaming.ui.retainedBatches": 5} or {"spark.driver.extraJavaOptions": "-verbose:gc -XX:+PrintGCDetails"}
-	AWSAttributes          AWSAttributes     `json:"aws_attributes"`
-	NodeTypeID             string            `json:"node_type_id"`
-	DriverNodeTypeID       string            `json:"driver_node_type_id"`
-	SSHPublicKeys          []string          `json:"ssh_public_keys"`
-	CustomTags             []ClusterTag      `json:"custom_tags"`
-	ClusterLogConf         ClusterLogConf    `json:"cluster_log_conf"`
-	InitScripts            []InitScriptInfo  `json:"init_scripts"`
-	SparkEnvVars           SparkEnvPair      `json:"spark_env_vars"`
-	AutoterminationMinutes int32             `json:"autotermination_minutes"`
-	EnableElasticDisk      bool              `json:"enable_elastic_disk"`
-	ClusterSource          AWSAvailability   `json:"cluster_source"`
-	State                  ClusterState      `json:"state"`
-	StateMessage           string            `json:"state_message"`
-	Start                  int64             `json:"start"`
-	TerminatedTime         int64             `json:"terminated_time"`
-	LastStateLossTime      int64             `json:"last_state_loss_time"`
-	LastActivityTime       int64             `json:"last_activity_time"`
-	ClusterMemoryMB        int64             `json:"cluster_memory_mb"`
-	ClusterCores           float32           `json:"cluster_cores"`
-	DefaultTags            map[string]string `json:"default_tags"`
-	ClusterLogStatus       LogSyncStatus     `json:"cluster_log_status"`
-	TerminationReason      TerminationReason `json:"termination_reason"`
+	ClusterSpec
+	ClusterID         string             `json:"cluster_id"`
+	CreatorUserName   string             `json:"creator_user_name,omitempty"`
+	Driver            *SparkNode         `json:"driver,omitempty"`
+	Executors         []SparkNode        `json:"executors,omitempty"`
+	SparkContextID    int64              `json:"spark_context_id,omitempty"`
+	JDBCPort          int32              `json:"jdbc_port,omitempty"`
+	ClusterSource     ClusterSource      `json:"cluster_source,omitempty"`
+	State             ClusterState       `json:"state,omitempty"`
+	StateMessage      string             `json:"state_message,omitempty"`
+	StartTime         EpochMillis        `json:"start_time,omitempty"`
+	TerminatedTime    EpochMillis        `json:"terminated_time,omitempty"`
+	LastStateLossTime EpochMillis        `json:"last_state_loss_time,omitempty"`
+	LastRestartedTime EpochMillis        `json:"last_restarted_time,omitempty"`
+	LastActivityTime  EpochMillis        `json:"last_activity_time,omitempty"`
+	ClusterMemoryMB   int64              `json:"cluster_memory_mb,omitempty"`
+	ClusterCores      float32            `json:"cluster_cores,omitempty"`
+	DefaultTags       map[string]string  `json:"default_tags,omitempty"`
+	ClusterLogStatus  *LogSyncStatus     `json:"cluster_log_status,omitempty"`
+	TerminationReason *TerminationReason `json:"termination_reason,omitempty"`
 }
 
-// ClusterAttributes are cluster attributes.
+// ClusterAttributes are the attributes of a cluster before and after it was
+// edited, as found in the details of an EDITED event.
 type ClusterAttributes struct {
-	ClusterName            string           `json:"cluster_name"`
-	SparkVersion           string           `json:"spark_version"`
-	SparkConf              S3StorageInfo    `json:"spark_conf"`
-	AWSAttributes          AWSAttributes    `json:"aws_attributes"`
-	NodeTypeID             string           `json:"node_type_id"`
-	DriverNodeTypeID       string           `json:"driver_node_type_id"`
-	SSHPublicKeys          []string         `json:"ssh_public_keys"`
-	CustomTags             []ClusterTag     `json:"custom_tags"`
-	ClusterLogConf         ClusterLogConf   `json:"cluster_log_conf"`
-	InitScripts            []InitScriptInfo `json:"init_scripts"`
-	SparkEnvVars           SparkEnvPair     `json:"spark_env_vars"`
-	AutoterminationMinutes int32            `json:"autotermination_minutes"`
-	EnableElasticDisk      bool             `json:"enable_elastic_disk"`
-	ClusterSource          AWSAvailability  `json:"cluster_source"`
+	ClusterSpec
+	ClusterSource ClusterSource `json:"cluster_source,omitempty"`
 }
 
 // ClusterSize is a Cluster's size.
 type ClusterSize struct {
-	NumWorkers *int32     `json:"num_workers"`
-	Autoscale  *Autoscale `json:"autoscale"`
+	NumWorkers *int32     `json:"num_workers,omitempty"`
+	Autoscale  *Autoscale `json:"autoscale,omitempty"`
 }
 
-// EventDetails is the details of an Event.
+// ResizeCause is the reason a cluster was resized.
+type ResizeCause string
+
+const (
+	// ResizeAutoscale is a resize by the autoscaler, based on load.
+	ResizeAutoscale ResizeCause = "AUTOSCALE"
+	// ResizeUserRequest is a resize requested by a user.
+	ResizeUserRequest ResizeCause = "USER_REQUEST"
+	// ResizeAutorecovery is a resize that replaces lost nodes.
+	ResizeAutorecovery ResizeCause = "AUTORECOVERY"
+)
+
+// EventDetails is the details of an Event. Which fields are set depends on
+// the type of the event.
 type EventDetails struct {
-	CurrentNumWorkers   int32             `json:"current_num_workers"`
-	TargetNumWorkers    int32             `json:"target_num_workers"`
-	PreviousAttributes  ClusterAttributes `json:"previous_attributes"`
-	Attributes          ClusterAttributes `json:"attributes"`
-	PreviousClusterSize ClusterSize       `json:"previous_cluster_size"`
-	ClusterSize         ClusterSize       `json:"cluster_size"`
-	Cause               string            `json:"cause"`
-	Reason              TerminationReason `json:"reason"`
-	User                string            `json:"user"`
+	// CurrentNumWorkers and TargetNumWorkers are the number of workers when
+	// the event occurred, and that the cluster is resizing to.
+	CurrentNumWorkers int32 `json:"current_num_workers,omitempty"`
+	TargetNumWorkers  int32 `json:"target_num_workers,omitempty"`
+	// PreviousAttributes and Attributes are the spec of a cluster before and
+	// after it was EDITED.
+	PreviousAttributes *ClusterAttributes `json:"previous_attributes,omitempty"`
+	Attributes         *ClusterAttributes `json:"attributes,omitempty"`
+	// PreviousClusterSize and ClusterSize are the size of a cluster before
+	// and after it was resized.
+	PreviousClusterSize *ClusterSize `json:"previous_cluster_size,omitempty"`
+	ClusterSize         *ClusterSize `json:"cluster_size,omitempty"`
+	Cause               ResizeCause  `json:"cause,omitempty"`
+	// Reason is the reason of a TERMINATING event, or of an unexpected
+	// event such as DRIVER_NOT_RESPONDING.
+	Reason *TerminationReason `json:"reason,omitempty"`
+	// User is the user who caused the event, if it was caused by a user.
+	User string `json:"user,omitempty"`
+	// InstanceID is the instance of a node that was lost or replaced.
+	InstanceID string `json:"instance_id,omitempty"`
+	// DriverStateMessage describes why the driver is unhealthy.
+	DriverStateMessage string `json:"driver_state_message,omitempty"`
+	// DidNotExpandReason describes why a disk was not expanded.
+	DidNotExpandReason string `json:"did_not_expand_reason,omitempty"`
+	// PreviousDiskSize, DiskSize and FreeSpace are sizes in bytes of a
+	// disk that was expanded or nearly full.
+	PreviousDiskSize int64 `json:"previous_disk_size,omitempty"`
+	DiskSize         int64 `json:"disk_size,omitempty"`
+	FreeSpace        int64 `json:"free_space,omitempty"`
+	// JobRunName is the name of the run that a job cluster was created for.
+	JobRunName string `json:"job_run_name,omitempty"`
 }
 
+// ClusterEventType is the type of a ClusterEvent.
+type ClusterEventType string
+
+const (
+	// ClusterEventCreating indicates that the cluster is being created.
+	ClusterEventCreating ClusterEventType = "CREATING"
+	// ClusterEventDidNotExpandDisk indicates that a disk is low on space,
+	// but adding disks would put it over the max capacity.
+	ClusterEventDidNotExpandDisk ClusterEventType = "DID_NOT_EXPAND_DISK"
+	// ClusterEventExpandedDisk indicates that a disk was low on space and
+	// the disks were expanded.
+	ClusterEventExpandedDisk ClusterEventType = "EXPANDED_DISK"
+	// ClusterEventFailedToExpandDisk indicates that a disk was low on space
+	// and disk space could not be expanded.
+	ClusterEventFailedToExpandDisk ClusterEventType = "FAILED_TO_EXPAND_DISK"
+	// ClusterEventInitScriptsStarting indicates that the cluster scoped init
+	// scripts have started.
+	ClusterEventInitScriptsStarting ClusterEventType = "INIT_SCRIPTS_STARTING"
+	// ClusterEventInitScriptsFinished indicates that the cluster scoped init
+	// scripts have finished.
+	ClusterEventInitScriptsFinished ClusterEventType = "INIT_SCRIPTS_FINISHED"
+	// ClusterEventStarting indicates that the cluster is being started.
+	ClusterEventStarting ClusterEventType = "STARTING"
+	// ClusterEventRestarting indicates that the cluster is being restarted.
+	ClusterEventRestarting ClusterEventType = "RESTARTING"
+	// ClusterEventTerminating indicates that the cluster is being
+	// terminated.
+	ClusterEventTerminating ClusterEventType = "TERMINATING"
+	// ClusterEventEdited indicates that the cluster has been edited.
+	ClusterEventEdited ClusterEventType = "EDITED"
+	// ClusterEventRunning indicates the cluster has finished being created,
+	// and includes the number of nodes and the reason it was started.
+	ClusterEventRunning ClusterEventType = "RUNNING"
+	// ClusterEventResizing indicates a change in the target size of the
+	// cluster.
+	ClusterEventResizing ClusterEventType = "RESIZING"
+	// ClusterEventUpsizeCompleted indicates that nodes finished being added
+	// to the cluster.
+	ClusterEventUpsizeCompleted ClusterEventType = "UPSIZE_COMPLETED"
+	// ClusterEventNodesLost indicates that some nodes were lost from the
+	// cluster.
+	ClusterEventNodesLost ClusterEventType = "NODES_LOST"
+	// ClusterEventDriverHealthy indicates that the driver is healthy and
+	// the cluster is ready for use.
+	ClusterEventDriverHealthy ClusterEventType = "DRIVER_HEALTHY"
+	// ClusterEventDriverUnavailable indicates that the driver is
+	// unavailable.
+	ClusterEventDriverUnavailable ClusterEventType = "DRIVER_UNAVAILABLE"
+	// ClusterEventSparkException indicates that a Spark exception was
+	// thrown from the driver.
+	ClusterEventSparkException ClusterEventType = "SPARK_EXCEPTION"
+	// ClusterEventDriverNotResponding indicates that the driver is up but
+	// is not responsive, likely due to GC.
+	ClusterEventDriverNotResponding ClusterEventType = "DRIVER_NOT_RESPONDING"
+	// ClusterEventDBFSDown indicates that the driver is up but DBFS is
+	// down.
+	ClusterEventDBFSDown ClusterEventType = "DBFS_DOWN"
+	// ClusterEventMetastoreDown indicates that the driver is up but the
+	// metastore is down.
+	ClusterEventMetastoreDown ClusterEventType = "METASTORE_DOWN"
+	// ClusterEventAutoscalingStatsReport reports autoscaling statistics.
+	ClusterEventAutoscalingStatsReport ClusterEventType = "AUTOSCALING_STATS_REPORT"
+	// ClusterEventNodeBlacklisted indicates that a node is not allowed by
+	// Spark.
+	ClusterEventNodeBlacklisted ClusterEventType = "NODE_BLACKLISTED"
+	// ClusterEventPinned indicates that the cluster was pinned.
+	ClusterEventPinned ClusterEventType = "PINNED"
+	// ClusterEventUnpinned indicates that the cluster was unpinned.
+	ClusterEventUnpinned ClusterEventType = "UNPINNED"
+)
+
 // ClusterEvent is an event that occured on a Cluster.
 type ClusterEvent struct {
-	ClusterID string       `json:"cluster_id"`
-	Timestamp int64        `json:"timestamp"`
-	Type      string       `json:"type"` // TODO(daniel): make this a type?
-	Details   EventDetails `json:"details"`
+	ClusterID string           `json:"cluster_id"`
+	Timestamp EpochMillis      `json:"timestamp"`
+	Type      ClusterEventType `json:"type"`
+	Details   EventDetails     `json:"details"`
 }
 
 // ClusterZoneResponse is a reponse for a Cluser zone request.
@@ -331,18 +478,18 @@ type ClusterZoneResponse struct {
 
 // ClusterEventRequest retrieves events pertaining to a specific cluster.
 type ClusterEventRequest struct {
-	ClusterID  string     `json:"cluster_id"`
-	StartTime  *int64     `json:"start_time"`
-	EndTime    *int64     `json:"end_time"`
-	Order      *ListOrder `json:"order"`
-	EventTypes []string   `json:"event_types"` // TODO(daniel): https://docs.databricks.com/api/latest/clusters.html#clustereventtype
-	Offset     int64      `json:"offset"`
-	Limit      int64      `json:"limit"`
+	ClusterID  string             `json:"cluster_id"`
+	StartTime  *EpochMillis       `json:"start_time,omitempty"`
+	EndTime    *EpochMillis       `json:"end_time,omitempty"`
+	Order      *ListOrder         `json:"order,omitempty"`
+	EventTypes []ClusterEventType `json:"event_types,omitempty"`
+	Offset     int64              `json:"offset,omitempty"`
+	Limit      int64              `json:"limit,omitempty"`
 }
 
 // ClusterEventResponse is a reponse for a ClusterEventRequest.
 type ClusterEventResponse struct {
-	Events     []string             `json:"events"`
+	Events     []ClusterEvent       `json:"events"`
 	NextPage   *ClusterEventRequest `json:"next_page"`
 	TotalCount int64                `json:"total_count"`
 }
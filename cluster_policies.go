@@ -0,0 +1,340 @@
+package databricks
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Policy is a cluster policy, which limits the clusters that its users can
+// create.
+type Policy struct {
+	PolicyID   string           `json:"policy_id,omitempty"`
+	Name       string           `json:"name"`
+	Definition PolicyDefinition `json:"definition,omitempty"`
+	// Description is a description of the policy, of at most 1000
+	// characters.
+	Description string `json:"description,omitempty"`
+	// MaxClustersPerUser is the maximum number of clusters that a user can
+	// create with the policy. 0 means no limit.
+	MaxClustersPerUser int64       `json:"max_clusters_per_user,omitempty"`
+	CreatorUserName    string      `json:"creator_user_name,omitempty"`
+	CreatedAtTimestamp EpochMillis `json:"created_at_timestamp,omitempty"`
+	IsDefault          bool        `json:"is_default,omitempty"`
+}
+
+// Evaluate predicts whether creating a cluster with the spec would be
+// rejected by the policy. See PolicyDefinition.Evaluate.
+func (p *Policy) Evaluate(spec *ClusterSpec) ([]PolicyViolation, error) {
+	return p.Definition.Evaluate(spec)
+}
+
+// PolicyRuleType is the type of a rule of a cluster policy.
+type PolicyRuleType string
+
+const (
+	// PolicyFixed fixes an attribute to Value.
+	PolicyFixed PolicyRuleType = "fixed"
+	// PolicyForbidden forbids setting an attribute.
+	PolicyForbidden PolicyRuleType = "forbidden"
+	// PolicyAllowlist limits an attribute to Values.
+	PolicyAllowlist PolicyRuleType = "allowlist"
+	// PolicyBlocklist forbids the Values for an attribute.
+	PolicyBlocklist PolicyRuleType = "blocklist"
+	// PolicyRegex limits an attribute to the values that match Pattern.
+	PolicyRegex PolicyRuleType = "regex"
+	// PolicyRange limits a numeric attribute to the range from MinValue
+	// to MaxValue.
+	PolicyRange PolicyRuleType = "range"
+	// PolicyUnlimited does not limit an attribute, and is used to set a
+	// default value or, with IsOptional set to false, to make it required.
+	PolicyUnlimited PolicyRuleType = "unlimited"
+)
+
+// PolicyRule is the rule of a cluster policy for an attribute.
+type PolicyRule struct {
+	Type PolicyRuleType `json:"type"`
+	// Value is the value of a fixed rule.
+	Value interface{} `json:"value,omitempty"`
+	// Values are the values of an allowlist or blocklist rule.
+	Values []interface{} `json:"values,omitempty"`
+	// Pattern is the regular expression of a regex rule, which must match
+	// the whole value.
+	Pattern string `json:"pattern,omitempty"`
+	// MinValue and MaxValue are the bounds of a range rule.
+	MinValue *float64 `json:"minValue,omitempty"`
+	MaxValue *float64 `json:"maxValue,omitempty"`
+	// DefaultValue is used when the attribute is not set.
+	DefaultValue interface{} `json:"defaultValue,omitempty"`
+	// IsOptional sets whether the attribute may be left unset. When it is
+	// nil, an attribute limited by an allowlist, blocklist, regex or range
+	// rule must be set and an unlimited attribute is optional. An attribute
+	// with a DefaultValue is never required.
+	IsOptional *bool `json:"isOptional,omitempty"`
+	// Hidden hides a fixed attribute in the UI.
+	Hidden bool `json:"hidden,omitempty"`
+}
+
+// PolicyDefinition maps the paths of cluster attributes to their rules.
+// Paths use the JSON names of a ClusterSpec, joined with dots, such as
+// "autoscale.max_workers", "custom_tags.team" or
+// "spark_conf.spark.speculation". Elements of a list are addressed by their
+// index or by *, as in "init_scripts.*.workspace.destination".
+//
+// The API sends a definition as a JSON document in a string, which
+// PolicyDefinition encodes and decodes.
+type PolicyDefinition map[string]PolicyRule
+
+// MarshalJSON implements the json.Marshaler interface.
+func (d PolicyDefinition) MarshalJSON() ([]byte, error) {
+	if d == nil {
+		return []byte(`""`), nil
+	}
+	raw, err := json.Marshal(map[string]PolicyRule(d))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(raw))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts a
+// definition in a string, as sent by the API, and a JSON object.
+func (d *PolicyDefinition) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		if raw == "" {
+			*d = nil
+			return nil
+		}
+		data = []byte(raw)
+	}
+	var rules map[string]PolicyRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return fmt.Errorf("Invalid policy definition: %w", err)
+	}
+	*d = rules
+	return nil
+}
+
+// virtualPolicyAttributes are policy attributes that are not part of a
+// cluster spec, and that Evaluate does not check.
+var virtualPolicyAttributes = map[string]bool{
+	"cluster_type":  true,
+	"dbus_per_hour": true,
+}
+
+// PolicyViolation is a cluster attribute that a policy rejects.
+type PolicyViolation struct {
+	// Path is the path of the attribute, with the index of a list element
+	// instead of *.
+	Path string
+	// Rule is the rule that the attribute violates.
+	Rule PolicyRule
+	// Message describes the violation, e.g. "must be one of [i3.xlarge]".
+	Message string
+}
+
+// String returns the path and message of the violation.
+func (v PolicyViolation) String() string {
+	return v.Path + " " + v.Message
+}
+
+// Evaluate predicts whether creating a cluster with the spec would be
+// rejected by the policy, without a round trip to the API. It returns the
+// attributes that violate the rules of the policy, ordered by path, and
+// returns an error if the policy itself is invalid.
+//
+// Attributes that are not set are filled in by the API from fixed rules
+// and default values, so only set attributes are checked against the rules,
+// and unset attributes only fail when they are required. Virtual attributes
+// such as cluster_type and dbus_per_hour are not checked.
+func (d PolicyDefinition) Evaluate(spec *ClusterSpec) ([]PolicyViolation, error) {
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+	attrs := map[string]interface{}{}
+	flattenAttributes("", decoded, attrs)
+
+	paths := make([]string, 0, len(d))
+	for path := range d {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var violations []PolicyViolation
+	for _, path := range paths {
+		rule := d[path]
+		if virtualPolicyAttributes[path] {
+			continue
+		}
+		matches, err := matchAttributes(path, attrs)
+		if err != nil {
+			return nil, err
+		}
+		violate := func(path, format string, args ...interface{}) {
+			violations = append(violations, PolicyViolation{
+				Path:    path,
+				Rule:    rule,
+				Message: fmt.Sprintf(format, args...),
+			})
+		}
+
+		if len(matches) == 0 {
+			if rule.isRequired() && !strings.Contains(path, "*") {
+				violate(path, "is required")
+			}
+			continue
+		}
+		var pattern *regexp.Regexp
+		if rule.Type == PolicyRegex {
+			pattern, err = regexp.Compile("^(?:" + rule.Pattern + ")$")
+			if err != nil {
+				return nil, fmt.Errorf(
+					"Invalid pattern of policy rule %s: %w", path, err)
+			}
+		}
+		for _, attr := range matches {
+			value := attrs[attr]
+			switch rule.Type {
+			case PolicyForbidden:
+				violate(attr, "is forbidden")
+			case PolicyFixed:
+				if !policyValueEqual(value, rule.Value) {
+					violate(attr, "must be %v", rule.Value)
+				}
+			case PolicyAllowlist:
+				if !policyValueIn(value, rule.Values) {
+					violate(attr, "must be one of %v", rule.Values)
+				}
+			case PolicyBlocklist:
+				if policyValueIn(value, rule.Values) {
+					violate(attr, "must not be one of %v", rule.Values)
+				}
+			case PolicyRegex:
+				if !pattern.MatchString(fmt.Sprint(value)) {
+					violate(attr, "must match %s", rule.Pattern)
+				}
+			case PolicyRange:
+				n, ok := policyNumber(value)
+				switch {
+				case !ok:
+					violate(attr, "must be a number")
+				case rule.MinValue != nil && n < *rule.MinValue:
+					violate(attr, "must be at least %v", *rule.MinValue)
+				case rule.MaxValue != nil && n > *rule.MaxValue:
+					violate(attr, "must be at most %v", *rule.MaxValue)
+				}
+			case PolicyUnlimited:
+			default:
+				return nil, fmt.Errorf(
+					"Unknown type of policy rule %s: %q", path, rule.Type)
+			}
+		}
+	}
+	return violations, nil
+}
+
+// isRequired returns whether an attribute that the rule applies to must be
+// set.
+func (r PolicyRule) isRequired() bool {
+	if r.DefaultValue != nil {
+		return false
+	}
+	if r.IsOptional != nil {
+		return !*r.IsOptional
+	}
+	switch r.Type {
+	case PolicyAllowlist, PolicyBlocklist, PolicyRegex, PolicyRange:
+		return true
+	}
+	return false
+}
+
+// flattenAttributes adds the leaves of a decoded JSON value to attrs, by
+// their dotted path.
+func flattenAttributes(prefix string, value interface{}, attrs map[string]interface{}) {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			flattenAttributes(join(key), field, attrs)
+		}
+	case []interface{}:
+		for i, item := range v {
+			flattenAttributes(join(strconv.Itoa(i)), item, attrs)
+		}
+	case nil:
+	default:
+		attrs[prefix] = v
+	}
+}
+
+// matchAttributes returns the sorted paths of the attributes that a policy
+// path matches. A * in the policy path matches any list index.
+func matchAttributes(path string, attrs map[string]interface{}) ([]string, error) {
+	if !strings.Contains(path, "*") {
+		if _, ok := attrs[path]; ok {
+			return []string{path}, nil
+		}
+		return nil, nil
+	}
+	pattern, err := regexp.Compile("^" + strings.Replace(
+		regexp.QuoteMeta(path), `\*`, `[0-9]+`, -1) + "$")
+	if err != nil {
+		return nil, err
+	}
+	var matches []string
+	for attr := range attrs {
+		if pattern.MatchString(attr) {
+			matches = append(matches, attr)
+		}
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// policyValueEqual compares an attribute with a value of a policy rule.
+// Numbers, booleans and their string forms are equal, as Spark
+// configuration values are strings but policies often use numbers or
+// booleans for them.
+func policyValueEqual(attr, value interface{}) bool {
+	if reflect.DeepEqual(attr, value) {
+		return true
+	}
+	return value != nil && fmt.Sprint(attr) == fmt.Sprint(value)
+}
+
+func policyValueIn(attr interface{}, values []interface{}) bool {
+	for _, value := range values {
+		if policyValueEqual(attr, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// policyNumber returns an attribute as a number, parsing strings.
+func policyNumber(attr interface{}) (float64, bool) {
+	switch v := attr.(type) {
+	case float64:
+		return v, true
+	case string:
+		n, err := strconv.ParseFloat(v, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,199 @@
+package databricks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// ClusterPoliciesService is a service for interacting with the cluster
+// policies.
+type ClusterPoliciesService struct {
+	client Client
+}
+
+// policyRequest is the body of a create or edit request for a policy.
+type policyRequest struct {
+	PolicyID           string           `json:"policy_id,omitempty"`
+	Name               string           `json:"name"`
+	Definition         PolicyDefinition `json:"definition,omitempty"`
+	Description        string           `json:"description,omitempty"`
+	MaxClustersPerUser int64            `json:"max_clusters_per_user,omitempty"`
+}
+
+// Create creates a cluster policy with the name, definition, description
+// and maximum number of clusters per user of the policy, and returns its ID.
+// This API is only available to admin users.
+func (s *ClusterPoliciesService) Create(
+	ctx context.Context,
+	policy *Policy,
+) (string, error) {
+	raw, err := json.Marshal(policyRequest{
+		Name:               policy.Name,
+		Definition:         policy.Definition,
+		Description:        policy.Description,
+		MaxClustersPerUser: policy.MaxClustersPerUser,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterPoliciesService.Create",
+		http.MethodPost,
+		"2.0/policies/clusters/create",
+		bytes.NewBuffer(raw),
+	)
+	if err != nil {
+		return "", err
+	}
+	res, err := s.client.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	decoder := json.NewDecoder(res.Body)
+
+	createRes := struct {
+		PolicyID string `json:"policy_id"`
+	}{}
+	err = decoder.Decode(&createRes)
+
+	return createRes.PolicyID, err
+}
+
+// Edit replaces the name, definition, description and maximum number of
+// clusters per user of the policy with the ID of the policy. Clusters that
+// were created with the policy are not changed, but can only be edited in
+// compliance with the new definition. This API is only available to admin
+// users.
+func (s *ClusterPoliciesService) Edit(
+	ctx context.Context,
+	policy *Policy,
+) error {
+	raw, err := json.Marshal(policyRequest{
+		PolicyID:           policy.PolicyID,
+		Name:               policy.Name,
+		Definition:         policy.Definition,
+		Description:        policy.Description,
+		MaxClustersPerUser: policy.MaxClustersPerUser,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterPoliciesService.Edit",
+		http.MethodPost,
+		"2.0/policies/clusters/edit",
+		bytes.NewBuffer(raw),
+	)
+	if err != nil {
+		return err
+	}
+	res, err := s.client.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return nil
+}
+
+// Get retrieves a cluster policy given its identifier.
+func (s *ClusterPoliciesService) Get(
+	ctx context.Context,
+	policyID string,
+) (*Policy, error) {
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterPoliciesService.Get",
+		http.MethodGet,
+		"2.0/policies/clusters/get",
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	q.Add("policy_id", policyID)
+	req.URL.RawQuery = q.Encode()
+	res, err := s.client.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	decoder := json.NewDecoder(res.Body)
+
+	var policy Policy
+	err = decoder.Decode(&policy)
+
+	return &policy, err
+}
+
+// List returns the cluster policies that the calling user can use.
+func (s *ClusterPoliciesService) List(
+	ctx context.Context,
+) ([]Policy, error) {
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterPoliciesService.List",
+		http.MethodGet,
+		"2.0/policies/clusters/list",
+		nil,
+	)
+	if err != nil {
+		return []Policy{}, err
+	}
+	res, err := s.client.do(req)
+	if err != nil {
+		return []Policy{}, err
+	}
+	defer res.Body.Close()
+	decoder := json.NewDecoder(res.Body)
+
+	listRes := struct {
+		Policies []Policy `json:"policies"`
+	}{[]Policy{}}
+	err = decoder.Decode(&listRes)
+
+	return listRes.Policies, err
+}
+
+// Delete deletes a cluster policy. Clusters that were created with it are
+// not deleted, but can no longer be edited. This API is only available to
+// admin users.
+func (s *ClusterPoliciesService) Delete(
+	ctx context.Context,
+	policyID string,
+) error {
+	raw, err := json.Marshal(struct {
+		PolicyID string `json:"policy_id"`
+	}{
+		policyID,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterPoliciesService.Delete",
+		http.MethodPost,
+		"2.0/policies/clusters/delete",
+		bytes.NewBuffer(raw),
+	)
+	if err != nil {
+		return err
+	}
+	res, err := s.client.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return nil
+}
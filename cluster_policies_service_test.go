@@ -0,0 +1,232 @@
+package databricks
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+)
+
+func badTransportClusterPoliciesHelper(t *testing.T) *ClusterPoliciesService {
+	badTransportClient, err := NewClient(
+		"test-account",
+		ClientHTTPClient(BadTransportHTTPClient),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if badTransportClient == nil {
+		t.Fatalf("NewClient returned nil")
+	}
+	policies := badTransportClient.ClusterPolicies()
+	if policies == nil {
+		t.Fatalf("ClusterPolicies returned nil")
+	}
+	return policies
+}
+
+func non200ClusterPoliciesHelper(t *testing.T) *ClusterPoliciesService {
+	non200Client, err := NewClient(
+		"test-account",
+		ClientHTTPClient(Non200HTTPClient),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if non200Client == nil {
+		t.Fatalf("NewClient returned nil")
+	}
+	policies := non200Client.ClusterPolicies()
+	if policies == nil {
+		t.Fatalf("ClusterPolicies returned nil")
+	}
+	return policies
+}
+
+func successClusterPoliciesHelper(
+	t *testing.T,
+	res []byte,
+	code int,
+) *ClusterPoliciesService {
+	successClient, err := NewClient(
+		"test-account",
+		ClientHTTPClient(injectedHTTPClient(
+			http.Response{
+				StatusCode: code,
+				Body: nopCloser{
+					bytes.NewBuffer(res),
+				},
+			},
+		)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if successClient == nil {
+		t.Fatalf("NewClient returned nil")
+	}
+	policies := successClient.ClusterPolicies()
+	if policies == nil {
+		t.Fatalf("ClusterPolicies returned nil")
+	}
+	return policies
+}
+
+func Test_ClusterPoliciesService_Create(t *testing.T) {
+	t.Parallel()
+	policies := successClusterPoliciesHelper(t,
+		[]byte(`{"policy_id": "ABC123"}`), http.StatusOK)
+
+	ctx := context.Background()
+	policy := &Policy{
+		Name: "small",
+		Definition: PolicyDefinition{
+			"autotermination_minutes": {Type: PolicyFixed, Value: 30},
+		},
+	}
+	policyID, err := policies.Create(ctx, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policyID != "ABC123" {
+		t.Fatalf("Expected policy ID ABC123, got %q", policyID)
+	}
+
+	// Non 200 test
+	policies = non200ClusterPoliciesHelper(t)
+
+	_, err = policies.Create(ctx, policy)
+	if err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+
+	// Transport error test
+	policies = badTransportClusterPoliciesHelper(t)
+
+	_, err = policies.Create(ctx, policy)
+	if err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+}
+
+func Test_ClusterPoliciesService_Edit(t *testing.T) {
+	t.Parallel()
+	policies := successClusterPoliciesHelper(t, []byte(`{}`), http.StatusOK)
+
+	ctx := context.Background()
+	policy := &Policy{PolicyID: "ABC123", Name: "small"}
+	if err := policies.Edit(ctx, policy); err != nil {
+		t.Fatal(err)
+	}
+
+	// Non 200 test
+	policies = non200ClusterPoliciesHelper(t)
+
+	if err := policies.Edit(ctx, policy); err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+
+	// Transport error test
+	policies = badTransportClusterPoliciesHelper(t)
+
+	if err := policies.Edit(ctx, policy); err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+}
+
+func Test_ClusterPoliciesService_Get(t *testing.T) {
+	t.Parallel()
+	policies := successClusterPoliciesHelper(t, []byte(`{
+		"policy_id": "ABC123",
+		"name": "small",
+		"definition": "{\"node_type_id\": {\"type\": \"allowlist\", \"values\": [\"i3.xlarge\"]}}",
+		"created_at_timestamp": 1618000000000
+	}`), http.StatusOK)
+
+	ctx := context.Background()
+	policy, err := policies.Get(ctx, "ABC123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule, ok := policy.Definition["node_type_id"]
+	if policy.PolicyID != "ABC123" || !ok || rule.Type != PolicyAllowlist ||
+		len(rule.Values) != 1 {
+		t.Fatalf("Unexpected policy: %+v", policy)
+	}
+
+	// Non 200 test
+	policies = non200ClusterPoliciesHelper(t)
+
+	_, err = policies.Get(ctx, "ABC123")
+	if err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+
+	// Transport error test
+	policies = badTransportClusterPoliciesHelper(t)
+
+	_, err = policies.Get(ctx, "ABC123")
+	if err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+}
+
+func Test_ClusterPoliciesService_List(t *testing.T) {
+	t.Parallel()
+	policies := successClusterPoliciesHelper(t, []byte(`{
+		"policies": [
+			{"policy_id": "ABC123", "name": "small", "definition": "{}"},
+			{"policy_id": "DEF456", "name": "large", "definition": "{}"}
+		],
+		"total_count": 2
+	}`), http.StatusOK)
+
+	ctx := context.Background()
+	list, err := policies.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[1].Name != "large" {
+		t.Fatalf("Unexpected policies: %+v", list)
+	}
+
+	// Non 200 test
+	policies = non200ClusterPoliciesHelper(t)
+
+	_, err = policies.List(ctx)
+	if err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+
+	// Transport error test
+	policies = badTransportClusterPoliciesHelper(t)
+
+	_, err = policies.List(ctx)
+	if err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+}
+
+func Test_ClusterPoliciesService_Delete(t *testing.T) {
+	t.Parallel()
+	policies := successClusterPoliciesHelper(t, []byte(`{}`), http.StatusOK)
+
+	ctx := context.Background()
+	if err := policies.Delete(ctx, "ABC123"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Non 200 test
+	policies = non200ClusterPoliciesHelper(t)
+
+	if err := policies.Delete(ctx, "ABC123"); err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+
+	// Transport error test
+	policies = badTransportClusterPoliciesHelper(t)
+
+	if err := policies.Delete(ctx, "ABC123"); err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+}
@@ -0,0 +1,131 @@
+package databricks
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func Test_PolicyDefinition_JSON(t *testing.T) {
+	t.Parallel()
+	var policy Policy
+	err := json.Unmarshal([]byte(`{
+		"name": "small",
+		"definition": "{\"autoscale.max_workers\": {\"type\": \"range\", \"maxValue\": 10, \"defaultValue\": 2}}"
+	}`), &policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := policy.Definition["autoscale.max_workers"]
+	if rule.Type != PolicyRange || rule.MaxValue == nil || *rule.MaxValue != 10 {
+		t.Fatalf("Unexpected definition: %+v", policy.Definition)
+	}
+
+	// The definition is sent as a string, and decodes to the same rules.
+	raw, err := json.Marshal(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded := struct {
+		Definition string `json:"definition"`
+	}{}
+	if err := json.Unmarshal(raw, &encoded); err != nil {
+		t.Fatal(err)
+	}
+	var definition PolicyDefinition
+	if err := json.Unmarshal([]byte(encoded.Definition), &definition); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(definition, policy.Definition) {
+		t.Fatalf("Expected %+v, got %+v", policy.Definition, definition)
+	}
+
+	if err := json.Unmarshal([]byte(`"{"`), &definition); err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+}
+
+func Test_Policy_Evaluate(t *testing.T) {
+	t.Parallel()
+	maxWorkers := 10.0
+	optional, required := true, false
+	autotermination := int32(30)
+	policy := &Policy{
+		Definition: PolicyDefinition{
+			"spark_version":           {Type: PolicyRegex, Pattern: `1[0-9]\..*`},
+			"node_type_id":            {Type: PolicyAllowlist, Values: []interface{}{"i3.xlarge", "i3.2xlarge"}},
+			"driver_node_type_id":     {Type: PolicyBlocklist, Values: []interface{}{"p3.16xlarge"}},
+			"autoscale.max_workers":   {Type: PolicyRange, MaxValue: &maxWorkers},
+			"autotermination_minutes": {Type: PolicyFixed, Value: 30},
+			"custom_tags.team":        {Type: PolicyUnlimited},
+			"custom_tags.owner":       {Type: PolicyUnlimited, IsOptional: &required},
+			"spark_conf.spark.speculation": {
+				Type: PolicyFixed, Value: true,
+			},
+			"ssh_public_keys.*":               {Type: PolicyForbidden},
+			"init_scripts.*.dbfs.destination": {Type: PolicyRegex, Pattern: `dbfs:/scripts/.*`},
+			"instance_pool_id":                {Type: PolicyAllowlist, Values: []interface{}{"pool"}, IsOptional: &optional},
+			"dbus_per_hour":                   {Type: PolicyRange, MaxValue: &maxWorkers},
+		},
+	}
+
+	compliant := &ClusterSpec{
+		SparkVersion:           "11.3.x-scala2.12",
+		NodeTypeID:             "i3.xlarge",
+		DriverNodeTypeID:       "i3.2xlarge",
+		Autoscale:              &Autoscale{Min: 1, Max: 8},
+		AutoterminationMinutes: &autotermination,
+		SparkConf:              map[string]string{"spark.speculation": "true"},
+		CustomTags:             map[string]string{"owner": "data"},
+		InitScripts: []InitScriptInfo{
+			{DBFS: &DbfsStorageInfo{Destination: "dbfs:/scripts/init.sh"}},
+		},
+	}
+	violations, err := policy.Evaluate(compliant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 0 {
+		t.Fatalf("Expected no violations, got %v", violations)
+	}
+
+	rejected := &ClusterSpec{
+		SparkVersion:     "7.3.x-scala2.12",
+		DriverNodeTypeID: "p3.16xlarge",
+		Autoscale:        &Autoscale{Min: 1, Max: 20},
+		SparkConf:        map[string]string{"spark.speculation": "false"},
+		SSHPublicKeys:    []string{"ssh-rsa AAAA"},
+		InitScripts: []InitScriptInfo{
+			{DBFS: &DbfsStorageInfo{Destination: "dbfs:/scripts/init.sh"}},
+			{DBFS: &DbfsStorageInfo{Destination: "dbfs:/tmp/init.sh"}},
+		},
+	}
+	violations, err = policy.Evaluate(rejected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, violation := range violations {
+		got = append(got, violation.String())
+	}
+	expected := []string{
+		"autoscale.max_workers must be at most 10",
+		"custom_tags.owner is required",
+		"driver_node_type_id must not be one of [p3.16xlarge]",
+		"init_scripts.1.dbfs.destination must match dbfs:/scripts/.*",
+		"node_type_id is required",
+		"spark_conf.spark.speculation must be true",
+		"spark_version must match 1[0-9]\\..*",
+		"ssh_public_keys.0 is forbidden",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Expected violations %q, got %q", expected, got)
+	}
+
+	invalid := &Policy{Definition: PolicyDefinition{
+		"spark_version": {Type: PolicyRegex, Pattern: "("},
+	}}
+	if _, err := invalid.Evaluate(compliant); err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+}
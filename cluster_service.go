@@ -4,8 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
-	"io/ioutil"
 	"net/http"
 )
 
@@ -24,28 +22,20 @@ func (s *ClusterService) Create(
 	if err != nil {
 		return "", err
 	}
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterService.Create",
 		http.MethodPost,
-		s.client.url+"2.0/clusters/create",
+		"2.0/clusters/create",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return "", err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return "", err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		body, err := ioutil.ReadAll(res.Body)
-		defer res.Body.Close()
-		if err != nil {
-			return "", err
-		}
-		return "", fmt.Errorf(
-			"Failed to return a 2XX response (%d): %s", res.StatusCode, body)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
@@ -66,23 +56,21 @@ func (s *ClusterService) Edit(
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterService.Edit",
 		http.MethodPost,
-		s.client.url+"2.0/clusters/edit",
+		"2.0/clusters/edit",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -105,23 +93,21 @@ func (s *ClusterService) Start(ctx context.Context, clusterID string) error {
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterService.Start",
 		http.MethodPost,
-		s.client.url+"2.0/clusters/start",
+		"2.0/clusters/start",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -137,23 +123,21 @@ func (s *ClusterService) Restart(ctx context.Context, clusterID string) error {
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterService.Restart",
 		http.MethodPost,
-		s.client.url+"2.0/clusters/restart",
+		"2.0/clusters/restart",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -175,20 +159,21 @@ func (s *ClusterService) ResizeWorkers(
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterService.ResizeWorkers",
 		http.MethodPost,
-		s.client.url+"2.0/clusters/resize",
+		"2.0/clusters/resize",
 		bytes.NewBuffer(raw),
 	)
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
+	res, err := s.client.do(req)
+	if err != nil {
+		return err
 	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -211,20 +196,21 @@ func (s *ClusterService) ResizeAutoscale(
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterService.ResizeAutoscale",
 		http.MethodPost,
-		s.client.url+"2.0/clusters/resize",
+		"2.0/clusters/resize",
 		bytes.NewBuffer(raw),
 	)
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
+	res, err := s.client.do(req)
+	if err != nil {
+		return err
 	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -242,23 +228,21 @@ func (s *ClusterService) Terminate(ctx context.Context, clusterID string) error
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterService.Terminate",
 		http.MethodPost,
-		s.client.url+"2.0/clusters/delete",
+		"2.0/clusters/delete",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -277,23 +261,21 @@ func (s *ClusterService) Delete(ctx context.Context, clusterID string) error {
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterService.Delete",
 		http.MethodPost,
-		s.client.url+"2.0/clusters/permanent-delete",
+		"2.0/clusters/permanent-delete",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -302,31 +284,23 @@ func (s *ClusterService) Delete(ctx context.Context, clusterID string) error {
 // can be described while they are running, or up to 30 days after they are
 // terminated.
 func (s *ClusterService) Get(ctx context.Context, clusterID string) (*ClusterGetResponse, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterService.Get",
 		http.MethodGet,
-		s.client.url+"2.0/clusters/get",
+		"2.0/clusters/get",
 		nil,
 	)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
 	q := req.URL.Query()
 	q.Add("cluster_id", clusterID)
 	req.URL.RawQuery = q.Encode()
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return nil, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		body, err := ioutil.ReadAll(res.Body)
-		defer res.Body.Close()
-		if err != nil {
-			return nil, err
-		}
-		return nil, fmt.Errorf(
-			"Failed to return a 2XX response (%d): %s", res.StatusCode, body)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
@@ -347,23 +321,21 @@ func (s *ClusterService) Pin(ctx context.Context, clusterID string) error {
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterService.Pin",
 		http.MethodPost,
-		s.client.url+"2.0/clusters/pin",
+		"2.0/clusters/pin",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -372,23 +344,29 @@ func (s *ClusterService) Pin(ctx context.Context, clusterID string) error {
 // list returned by the List API. Unpinning a cluster that is not pinned has no
 // effect.
 func (s *ClusterService) Unpin(ctx context.Context, clusterID string) error {
-	req, err := http.NewRequest(
+	raw, err := json.Marshal(struct {
+		ClusterID string `json:"cluster_id"`
+	}{
+		clusterID,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterService.Unpin",
 		http.MethodPost,
-		s.client.url+"2.0/clusters/unpin",
-		nil,
+		"2.0/clusters/unpin",
+		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -402,23 +380,20 @@ func (s *ClusterService) Unpin(ctx context.Context, clusterID string) error {
 // pinned cluster, 4 active clusters, all 45 terminated interactive clusters,
 // and the 30 most recently terminated job clusters.
 func (s *ClusterService) List(ctx context.Context) ([]ClusterInfo, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterService.List",
 		http.MethodGet,
-		s.client.url+"2.0/clusters/list",
+		"2.0/clusters/list",
 		nil,
 	)
 	if err != nil {
 		return []ClusterInfo{}, err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return []ClusterInfo{}, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []ClusterInfo{}, fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
@@ -436,23 +411,20 @@ func (s *ClusterService) List(ctx context.Context) ([]ClusterInfo, error) {
 func (s *ClusterService) Zones(
 	ctx context.Context,
 ) (*ClusterZoneResponse, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterService.Zones",
 		http.MethodGet,
-		s.client.url+"2.0/clusters/list-zones",
+		"2.0/clusters/list-zones",
 		nil,
 	)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return nil, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return nil, fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
@@ -466,30 +438,29 @@ func (s *ClusterService) Zones(
 // NodeTypes returns a list of supported Spark node types. These node types can
 // be used to launch a cluster.
 func (s *ClusterService) NodeTypes(ctx context.Context) ([]NodeType, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterService.NodeTypes",
 		http.MethodGet,
-		s.client.url+"2.0/clusters/list-node-types",
+		"2.0/clusters/list-node-types",
 		nil,
 	)
 	if err != nil {
 		return []NodeType{}, err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return []NodeType{}, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []NodeType{}, fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
-	nodeTypes := []NodeType{}
-	err = decoder.Decode(&nodeTypes)
+	nodeTypesRes := struct {
+		NodeTypes []NodeType `json:"node_types"`
+	}{[]NodeType{}}
+	err = decoder.Decode(&nodeTypesRes)
 
-	return nodeTypes, err
+	return nodeTypesRes.NodeTypes, err
 }
 
 // SparkVersions returns the list of available Spark versions. These versions
@@ -497,23 +468,20 @@ func (s *ClusterService) NodeTypes(ctx context.Context) ([]NodeType, error) {
 func (s *ClusterService) SparkVersions(
 	ctx context.Context,
 ) ([]SparkNodeAwsAttributes, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterService.SparkVersions",
 		http.MethodGet,
-		s.client.url+"2.0/clusters/spark-versions",
+		"2.0/clusters/spark-versions",
 		nil,
 	)
 	if err != nil {
 		return []SparkNodeAwsAttributes{}, err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return []SparkNodeAwsAttributes{}, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []SparkNodeAwsAttributes{}, fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
@@ -538,23 +506,20 @@ func (s *ClusterService) Events(
 		return nil, err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"ClusterService.Events",
 		http.MethodPost,
-		s.client.url+"2.0/clusters/events",
+		"2.0/clusters/events",
 		bytes.NewBuffer(rawData),
 	)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return nil, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return nil, fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
@@ -450,8 +450,12 @@ func Test_ClusterService_Zones(t *testing.T) {
 }
 
 func Test_ClusterService_NodeTypes(t *testing.T) {
-	res, err := json.Marshal([]NodeType{
-		NodeType{},
+	res, err := json.Marshal(struct {
+		NodeTypes []NodeType `json:"node_types"`
+	}{
+		[]NodeType{
+			NodeType{},
+		},
 	})
 	if err != nil {
 		t.Fatal(err)
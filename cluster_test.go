@@ -0,0 +1,175 @@
+package databricks
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// readPayload reads a recorded API payload from testdata.
+func readPayload(t *testing.T, name string) []byte {
+	t.Helper()
+	raw, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+// expectRoundTrip decodes a payload into v, encodes v again and fails if
+// the result differs from the payload. Fields with a zero value, like
+// "enable_local_disk_encryption": false, are omitted by the model and not
+// compared.
+func expectRoundTrip(t *testing.T, raw []byte, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(raw, v); err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected, got interface{}
+	if err := json.Unmarshal(raw, &expected); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(encoded, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(withoutZeros(expected), withoutZeros(got)) {
+		t.Fatalf("Round trip changed the payload:\nexpected %s\ngot      %s", raw, encoded)
+	}
+}
+
+func Test_ClusterInfo_RoundTrip(t *testing.T) {
+	t.Parallel()
+	var cluster ClusterGetResponse
+	expectRoundTrip(t, readPayload(t, "clusters_get.json"), &cluster)
+
+	if cluster.CustomTags["team"] != "data" ||
+		cluster.SparkConf["spark.speculation"] != "true" ||
+		cluster.SparkEnvVars["PYSPARK_PYTHON"] == "" {
+		t.Fatalf("Unexpected spec: %+v", cluster.ClusterSpec)
+	}
+	if cluster.Driver == nil || len(cluster.Executors) != 1 ||
+		!cluster.Executors[0].NodeAWSAttributes.IsSpot {
+		t.Fatalf("Unexpected nodes: %+v %+v", cluster.Driver, cluster.Executors)
+	}
+	if cluster.TerminationReason.Code != TerminationInactivity ||
+		cluster.ClusterSource != UI ||
+		*cluster.AWSAttributes.EBSVolumeType != SSD {
+		t.Fatalf("Unexpected cluster: %+v", cluster)
+	}
+	if len(cluster.InitScripts) != 2 || cluster.InitScripts[0].Workspace == nil {
+		t.Fatalf("Unexpected init scripts: %+v", cluster.InitScripts)
+	}
+}
+
+func Test_ClusterCreateRequest_RoundTrip(t *testing.T) {
+	t.Parallel()
+	raw := readPayload(t, "clusters_create.json")
+	var createReq ClusterCreateRequest
+	expectRoundTrip(t, raw, &createReq)
+
+	// Edit takes the same spec as create, and the ID of the cluster.
+	editReq := ClusterEditRequest{
+		ClusterID:   "0412-221636-jolt512",
+		ClusterSpec: createReq.ClusterSpec,
+	}
+	encoded, err := json.Marshal(editReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var edit map[string]interface{}
+	if err := json.Unmarshal(encoded, &edit); err != nil {
+		t.Fatal(err)
+	}
+	var create map[string]interface{}
+	if err := json.Unmarshal(raw, &create); err != nil {
+		t.Fatal(err)
+	}
+	create["cluster_id"] = "0412-221636-jolt512"
+	if !reflect.DeepEqual(create, edit) {
+		t.Fatalf("Unexpected edit request: %s", encoded)
+	}
+}
+
+func Test_ClusterSpec_Autotermination(t *testing.T) {
+	t.Parallel()
+	// 0 disables automatic termination, so it must be sent, while nil
+	// leaves the default of the API.
+	disabled := int32(0)
+	for _, minutes := range []*int32{&disabled, nil} {
+		encoded, err := json.Marshal(ClusterSpec{AutoterminationMinutes: minutes})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(encoded, &fields); err != nil {
+			t.Fatal(err)
+		}
+		if value, ok := fields["autotermination_minutes"]; ok != (minutes != nil) ||
+			ok && value != 0.0 {
+			t.Fatalf("Unexpected encoding of %v: %s", minutes, encoded)
+		}
+		var spec ClusterSpec
+		if err := json.Unmarshal(encoded, &spec); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(spec.AutoterminationMinutes, minutes) {
+			t.Fatalf("Expected %v, got %v", minutes, spec.AutoterminationMinutes)
+		}
+	}
+}
+
+func Test_NewCluster_RoundTrip(t *testing.T) {
+	t.Parallel()
+	payload := struct {
+		Settings struct {
+			NewCluster json.RawMessage `json:"new_cluster"`
+		} `json:"settings"`
+	}{}
+	raw := readPayload(t, "jobs_get.json")
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		t.Fatal(err)
+	}
+	var newCluster NewCluster
+	expectRoundTrip(t, payload.Settings.NewCluster, &newCluster)
+
+	var job JobGetResponse
+	if err := json.Unmarshal(raw, &job); err != nil {
+		t.Fatal(err)
+	}
+	cluster := job.Settings.NewCluster
+	if cluster == nil || *cluster.NumWorkers != 4 ||
+		cluster.CustomTags["job"] != "nightly" ||
+		cluster.ClusterLogConf.S3.CannedACL != "bucket-owner-full-control" {
+		t.Fatalf("Unexpected new cluster: %+v", cluster)
+	}
+}
+
+// withoutZeros removes the fields with a zero value from a decoded JSON
+// value.
+func withoutZeros(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		pruned := map[string]interface{}{}
+		for key, field := range v {
+			switch field {
+			case nil, false, "", 0.0:
+				continue
+			}
+			pruned[key] = withoutZeros(field)
+		}
+		return pruned
+	case []interface{}:
+		pruned := make([]interface{}, len(v))
+		for i, item := range v {
+			pruned[i] = withoutZeros(item)
+		}
+		return pruned
+	}
+	return value
+}
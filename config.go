@@ -0,0 +1,390 @@
+package databricks
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// Environment variables read by LoadConfig. These match the official
+// Databricks CLI.
+const (
+	EnvHost          = "DATABRICKS_HOST"
+	EnvToken         = "DATABRICKS_TOKEN"
+	EnvUsername      = "DATABRICKS_USERNAME"
+	EnvPassword      = "DATABRICKS_PASSWORD"
+	EnvClientID      = "DATABRICKS_CLIENT_ID"
+	EnvClientSecret  = "DATABRICKS_CLIENT_SECRET"
+	EnvAuthType      = "DATABRICKS_AUTH_TYPE"
+	EnvConfigFile    = "DATABRICKS_CONFIG_FILE"
+	EnvConfigProfile = "DATABRICKS_CONFIG_PROFILE"
+)
+
+// DefaultConfigFile is the default location of the Databricks CLI
+// configuration file.
+const DefaultConfigFile = "~/.databrickscfg"
+
+// DefaultProfile is the profile used when none is specified.
+const DefaultProfile = "DEFAULT"
+
+// AuthType is the type of authentication used by a Client.
+type AuthType string
+
+const (
+	// AuthTypePAT uses a personal access token as a bearer token.
+	AuthTypePAT AuthType = "pat"
+	// AuthTypeBasic uses a username and password.
+	AuthTypeBasic AuthType = "basic"
+	// AuthTypeNetrc uses credentials from the user's .netrc file.
+	AuthTypeNetrc AuthType = "netrc"
+	// AuthTypeOAuthM2M uses OAuth machine-to-machine authentication with a
+	// service principal's client id and secret.
+	AuthTypeOAuthM2M AuthType = "oauth-m2m"
+	// AuthTypeOAuthU2M uses OAuth user-to-machine authentication, logging
+	// in with the browser. It is never detected and must be set explicitly.
+	AuthTypeOAuthU2M AuthType = "external-browser"
+)
+
+// Config is a resolved Client configuration.
+//
+// Each attribute is resolved with the following precedence, from highest to
+// lowest:
+//
+//  1. Values set explicitly with a ConfigOpt.
+//  2. Environment variables (DATABRICKS_HOST, DATABRICKS_TOKEN, ...).
+//  3. The selected profile of the configuration file (~/.databrickscfg, or
+//     DATABRICKS_CONFIG_FILE). The profile is selected with ConfigProfile,
+//     then DATABRICKS_CONFIG_PROFILE, and defaults to DEFAULT.
+//
+// When no token, password or OAuth client secret is found the AuthType falls
+// back to netrc.
+type Config struct {
+	Host         string
+	Token        string
+	Username     string
+	Password     string
+	ClientID     string
+	ClientSecret string
+	AuthType     AuthType
+	Profile      string
+	ConfigFile   string
+
+	// Sources records where each attribute was resolved from, keyed by
+	// the attribute name used in the configuration file (e.g. "host").
+	Sources map[string]string
+
+	getenv func(string) string
+}
+
+// ConfigOpt is used for configuring how a Config is loaded.
+type ConfigOpt func(*Config) error
+
+// ConfigProfile selects the profile of the configuration file to use.
+func ConfigProfile(profile string) ConfigOpt {
+	return func(c *Config) error {
+		c.Profile = profile
+		c.Sources["profile"] = "option"
+		return nil
+	}
+}
+
+// ConfigFile sets the path of the configuration file to use.
+func ConfigFile(path string) ConfigOpt {
+	return func(c *Config) error {
+		c.ConfigFile = path
+		c.Sources["config_file"] = "option"
+		return nil
+	}
+}
+
+// ConfigHost sets the workspace host, overriding the environment and the
+// configuration file.
+func ConfigHost(host string) ConfigOpt {
+	return func(c *Config) error {
+		c.Host = host
+		c.Sources["host"] = "option"
+		return nil
+	}
+}
+
+// ConfigToken sets the personal access token, overriding the environment
+// and the configuration file.
+func ConfigToken(token string) ConfigOpt {
+	return func(c *Config) error {
+		c.Token = token
+		c.Sources["token"] = "option"
+		return nil
+	}
+}
+
+// configGetenv replaces os.Getenv, it is used for testing.
+func configGetenv(getenv func(string) string) ConfigOpt {
+	return func(c *Config) error {
+		c.getenv = getenv
+		return nil
+	}
+}
+
+// LoadConfig resolves a Config from the options, the environment and the
+// configuration file.
+func LoadConfig(opts ...ConfigOpt) (*Config, error) {
+	c := &Config{
+		Sources: map[string]string{},
+		getenv:  os.Getenv,
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	c.fromEnv("config_file", &c.ConfigFile, EnvConfigFile)
+	c.fromEnv("profile", &c.Profile, EnvConfigProfile)
+	c.fromEnv("host", &c.Host, EnvHost)
+	c.fromEnv("token", &c.Token, EnvToken)
+	c.fromEnv("username", &c.Username, EnvUsername)
+	c.fromEnv("password", &c.Password, EnvPassword)
+	c.fromEnv("client_id", &c.ClientID, EnvClientID)
+	c.fromEnv("client_secret", &c.ClientSecret, EnvClientSecret)
+	authType := string(c.AuthType)
+	c.fromEnv("auth_type", &authType, EnvAuthType)
+	c.AuthType = AuthType(authType)
+
+	if err := c.fromFile(); err != nil {
+		return nil, err
+	}
+
+	if c.Host == "" {
+		return nil, fmt.Errorf(
+			"No host configured, set %s or host in profile %s of %s",
+			EnvHost, c.Profile, c.ConfigFile,
+		)
+	}
+	host, err := normalizeHost(c.Host)
+	if err != nil {
+		return nil, err
+	}
+	c.Host = host
+
+	if c.AuthType == "" {
+		switch {
+		case c.Token != "":
+			c.AuthType = AuthTypePAT
+		case c.Username != "" && c.Password != "":
+			c.AuthType = AuthTypeBasic
+		case c.ClientID != "" && c.ClientSecret != "":
+			c.AuthType = AuthTypeOAuthM2M
+		default:
+			c.AuthType = AuthTypeNetrc
+		}
+		c.Sources["auth_type"] = "detected"
+	}
+
+	return c, nil
+}
+
+// fromEnv sets an attribute from an environment variable if it hasn't
+// already been set.
+func (c *Config) fromEnv(attr string, value *string, env string) {
+	if *value != "" {
+		return
+	}
+	if v := c.getenv(env); v != "" {
+		*value = v
+		c.Sources[attr] = "env " + env
+	}
+}
+
+// fromFile sets the attributes that haven't already been set from the
+// selected profile of the configuration file.
+func (c *Config) fromFile() error {
+	profileRequired := c.Profile != ""
+	if c.Profile == "" {
+		c.Profile = DefaultProfile
+		c.Sources["profile"] = "default"
+	}
+	if c.ConfigFile == "" {
+		c.ConfigFile = DefaultConfigFile
+		c.Sources["config_file"] = "default"
+	}
+	path, err := homedir.Expand(c.ConfigFile)
+	if err != nil {
+		return err
+	}
+	c.ConfigFile = path
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) && !profileRequired {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	profiles, err := parseConfigFile(f)
+	if err != nil {
+		return fmt.Errorf("Error parsing config file at %q: %s", path, err)
+	}
+	profile, ok := profiles[c.Profile]
+	if !ok {
+		if profileRequired {
+			return fmt.Errorf(
+				"Profile %q not found in %q", c.Profile, path)
+		}
+		return nil
+	}
+
+	source := fmt.Sprintf("profile %s of %s", c.Profile, path)
+	set := func(attr string, value *string) {
+		if *value == "" && profile[attr] != "" {
+			*value = profile[attr]
+			c.Sources[attr] = source
+		}
+	}
+	set("host", &c.Host)
+	set("token", &c.Token)
+	set("username", &c.Username)
+	set("password", &c.Password)
+	set("client_id", &c.ClientID)
+	set("client_secret", &c.ClientSecret)
+	authType := string(c.AuthType)
+	set("auth_type", &authType)
+	c.AuthType = AuthType(authType)
+
+	return nil
+}
+
+// parseConfigFile parses the INI format of ~/.databrickscfg into its
+// profiles.
+func parseConfigFile(r io.Reader) (map[string]map[string]string, error) {
+	profiles := map[string]map[string]string{}
+	var current map[string]string
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || text[0] == '#' || text[0] == ';' {
+			continue
+		}
+		if text[0] == '[' {
+			if !strings.HasSuffix(text, "]") {
+				return nil, fmt.Errorf("line %d: invalid section %q", line, text)
+			}
+			name := strings.TrimSpace(text[1 : len(text)-1])
+			if _, ok := profiles[name]; !ok {
+				profiles[name] = map[string]string{}
+			}
+			current = profiles[name]
+			continue
+		}
+		i := strings.IndexAny(text, "=:")
+		if i < 0 {
+			return nil, fmt.Errorf("line %d: expected key = value", line)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("line %d: key outside of a profile", line)
+		}
+		key := strings.ToLower(strings.TrimSpace(text[:i]))
+		current[key] = strings.TrimSpace(text[i+1:])
+	}
+	return profiles, scanner.Err()
+}
+
+// Describe returns a description of the resolved configuration and where
+// each attribute came from, with secrets redacted. It is meant for
+// debugging.
+func (c *Config) Describe() string {
+	values := map[string]string{
+		"host":          c.Host,
+		"token":         redact(c.Token),
+		"username":      c.Username,
+		"password":      redact(c.Password),
+		"client_id":     c.ClientID,
+		"client_secret": redact(c.ClientSecret),
+		"auth_type":     string(c.AuthType),
+		"profile":       c.Profile,
+		"config_file":   c.ConfigFile,
+	}
+	attrs := make([]string, 0, len(c.Sources))
+	for attr := range c.Sources {
+		attrs = append(attrs, attr)
+	}
+	sort.Strings(attrs)
+
+	parts := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		parts = append(parts, fmt.Sprintf(
+			"%s=%s (from %s)", attr, values[attr], c.Sources[attr]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "***"
+}
+
+// credentials returns a CredentialsProvider for the Config's AuthType.
+func (c *Config) credentials() (CredentialsProvider, error) {
+	switch c.AuthType {
+	case AuthTypePAT:
+		if c.Token == "" {
+			return nil, fmt.Errorf("Auth type %s requires a token", c.AuthType)
+		}
+		return BearerCredentials(c.Token), nil
+	case AuthTypeBasic:
+		if c.Username == "" || c.Password == "" {
+			return nil, fmt.Errorf(
+				"Auth type %s requires a username and password", c.AuthType)
+		}
+		return BasicCredentials(c.Username, c.Password), nil
+	case AuthTypeNetrc:
+		return NetrcCredentials(), nil
+	case AuthTypeOAuthM2M:
+		creds, err := NewOAuthM2MCredentials(
+			c.Host, c.ClientID, c.ClientSecret)
+		if err != nil {
+			return nil, err
+		}
+		return TokenSourceCredentials(string(c.AuthType), creds), nil
+	case AuthTypeOAuthU2M:
+		creds, err := NewOAuthU2MCredentials(c.Host)
+		if err != nil {
+			return nil, err
+		}
+		return TokenSourceCredentials(string(c.AuthType), creds), nil
+	}
+	return nil, fmt.Errorf("Unknown auth type: %q", c.AuthType)
+}
+
+// NewClientFromConfig returns a new Databricks client for the workspace and
+// credentials of a Config. If cfg is nil it is loaded with LoadConfig. The
+// options are applied after the configuration, so they may override it, for
+// example ClientTransport sets the transport the credentials are added on
+// top of.
+func NewClientFromConfig(cfg *Config, opts ...ClientOpt) (*Client, error) {
+	if cfg == nil {
+		var err error
+		cfg, err = LoadConfig()
+		if err != nil {
+			return nil, err
+		}
+	}
+	credentials, err := cfg.credentials()
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(
+		"",
+		append([]ClientOpt{
+			ClientHost(cfg.Host),
+			ClientCredentials(credentials),
+		}, opts...)...,
+	)
+}
@@ -0,0 +1,175 @@
+package databricks
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testConfigFile = `
+; Databricks CLI configuration
+[DEFAULT]
+host = https://dbc-a1b2c3d4-e5f6.cloud.databricks.com
+token = dapi-default
+
+[azure]
+host  = adb-1234567890123456.7.azuredatabricks.net/
+token = dapi-azure
+
+[basic]
+host = https://basic.cloud.databricks.com
+username = user@example.com
+password = hunter2
+`
+
+func configFileHelper(t *testing.T, contents string) string {
+	dir, err := ioutil.TempDir("", "databrickscfg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, ".databrickscfg")
+	if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func envHelper(env map[string]string) ConfigOpt {
+	return configGetenv(func(key string) string {
+		return env[key]
+	})
+}
+
+func Test_LoadConfig(t *testing.T) {
+	t.Parallel()
+	path := configFileHelper(t, testConfigFile)
+
+	// Default profile
+	cfg, err := LoadConfig(envHelper(map[string]string{
+		EnvConfigFile: path,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Host != "https://dbc-a1b2c3d4-e5f6.cloud.databricks.com" ||
+		cfg.Token != "dapi-default" || cfg.AuthType != AuthTypePAT {
+		t.Fatalf("Unexpected config: %s", cfg.Describe())
+	}
+	if cfg.Sources["token"] != "profile DEFAULT of "+path {
+		t.Fatalf("Unexpected token source: %q", cfg.Sources["token"])
+	}
+
+	// Profile from the environment, token from the environment
+	cfg, err = LoadConfig(envHelper(map[string]string{
+		EnvConfigFile:    path,
+		EnvConfigProfile: "azure",
+		EnvToken:         "dapi-env",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Host != "https://adb-1234567890123456.7.azuredatabricks.net" {
+		t.Fatalf("Unexpected host: %q", cfg.Host)
+	}
+	if cfg.Token != "dapi-env" || cfg.Sources["token"] != "env "+EnvToken {
+		t.Fatalf("Expected the environment to take precedence: %s", cfg.Describe())
+	}
+	if strings.Contains(cfg.Describe(), "dapi-env") {
+		t.Fatalf("Describe leaked the token: %s", cfg.Describe())
+	}
+
+	// Options take precedence over the environment
+	cfg, err = LoadConfig(
+		envHelper(map[string]string{
+			EnvConfigFile:    path,
+			EnvConfigProfile: "azure",
+		}),
+		ConfigProfile("basic"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.AuthType != AuthTypeBasic || cfg.Username != "user@example.com" {
+		t.Fatalf("Unexpected config: %s", cfg.Describe())
+	}
+
+	// Missing profiles are an error when requested
+	_, err = LoadConfig(envHelper(map[string]string{
+		EnvConfigFile:    path,
+		EnvConfigProfile: "missing",
+	}))
+	if err == nil {
+		t.Fatalf("Expected an error for a missing profile")
+	}
+
+	// No configuration file falls back to the environment and netrc
+	cfg, err = LoadConfig(envHelper(map[string]string{
+		EnvConfigFile: filepath.Join(filepath.Dir(path), "missing"),
+		EnvHost:       "https://env.cloud.databricks.com",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.AuthType != AuthTypeNetrc {
+		t.Fatalf("Expected netrc auth, got %q", cfg.AuthType)
+	}
+
+	// A host is required
+	_, err = LoadConfig(envHelper(map[string]string{
+		EnvConfigFile: filepath.Join(filepath.Dir(path), "missing"),
+	}))
+	if err == nil {
+		t.Fatalf("Expected an error without a host")
+	}
+}
+
+func Test_parseConfigFile(t *testing.T) {
+	t.Parallel()
+	profiles, err := parseConfigFile(strings.NewReader(testConfigFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles) != 3 {
+		t.Fatalf("Expected 3 profiles, got %d", len(profiles))
+	}
+	if profiles["basic"]["password"] != "hunter2" {
+		t.Fatalf("Unexpected profile: %v", profiles["basic"])
+	}
+
+	for _, invalid := range []string{
+		"host = outside",
+		"[DEFAULT\nhost = x",
+		"[DEFAULT]\nhost",
+	} {
+		if _, err := parseConfigFile(strings.NewReader(invalid)); err == nil {
+			t.Errorf("Expected an error parsing %q", invalid)
+		}
+	}
+}
+
+func Test_NewClientFromConfig(t *testing.T) {
+	t.Parallel()
+	path := configFileHelper(t, testConfigFile)
+	cfg, err := LoadConfig(
+		envHelper(map[string]string{EnvConfigFile: path}),
+		ConfigProfile("azure"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewClientFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.Host() != cfg.Host || !client.Cloud().IsAzure() {
+		t.Fatalf("Unexpected client host %q", client.Host())
+	}
+
+	cfg.AuthType = "unknown"
+	if _, err := NewClientFromConfig(cfg); err == nil {
+		t.Fatalf("Expected an error for an unknown auth type")
+	}
+}
@@ -0,0 +1,448 @@
+package databricks
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ErrNoCredentials is returned by a CredentialsProvider that has no
+// credentials for a request, so that a chain can try the next provider.
+var ErrNoCredentials = errors.New("No credentials found")
+
+// CredentialsProvider authenticates requests to a workspace.
+type CredentialsProvider interface {
+	// Name identifies the provider, e.g. "pat" or "netrc".
+	Name() string
+	// Authenticate adds credentials to a request. It returns
+	// ErrNoCredentials if the provider has no credentials for the request's
+	// host.
+	Authenticate(req *http.Request) error
+}
+
+// transportSetter is implemented by providers that make their own HTTP
+// requests, such as OAuth token requests, so they can use the same base
+// transport as the Client.
+type transportSetter interface {
+	setTransport(base http.RoundTripper)
+}
+
+// ClientCredentials configures the CredentialsProvider used to authenticate
+// every request. The provider wraps the transport of the Client's HTTP
+// client (see ClientHTTPClient and ClientTransport) instead of replacing it.
+func ClientCredentials(provider CredentialsProvider) ClientOpt {
+	return func(c *Client) error {
+		c.credentials = provider
+		return nil
+	}
+}
+
+// ClientTransport configures the base http.RoundTripper of the Client, for
+// example to use a proxy or custom TLS settings. Credentials configured with
+// ClientCredentials are added on top of it.
+func ClientTransport(transport http.RoundTripper) ClientOpt {
+	return func(c *Client) error {
+		client := *c.client
+		client.Transport = transport
+		c.client = &client
+		return nil
+	}
+}
+
+// credentialsTransport is an http.RoundTripper that authenticates requests
+// with a CredentialsProvider before handing them to a base RoundTripper.
+type credentialsTransport struct {
+	provider CredentialsProvider
+	base     http.RoundTripper
+}
+
+// newCredentialsTransport returns a credentialsTransport, configuring the
+// provider to use the base transport for its own requests.
+func newCredentialsTransport(
+	provider CredentialsProvider,
+	base http.RoundTripper,
+) *credentialsTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if setter, ok := provider.(transportSetter); ok {
+		setter.setTransport(base)
+	}
+	return &credentialsTransport{provider: provider, base: base}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *credentialsTransport) RoundTrip(
+	req *http.Request,
+) (*http.Response, error) {
+	// A RoundTripper must not modify the request it was given.
+	authReq := req.Clone(req.Context())
+	if err := t.provider.Authenticate(authReq); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, fmt.Errorf(
+			"Failed to authenticate with %s: %w", t.provider.Name(), err)
+	}
+	return t.base.RoundTrip(authReq)
+}
+
+// newCredentialsHTTPClient returns an http.Client using the default
+// transport that authenticates with a CredentialsProvider.
+func newCredentialsHTTPClient(provider CredentialsProvider) *http.Client {
+	return &http.Client{
+		Transport: newCredentialsTransport(provider, nil),
+	}
+}
+
+// BearerCredentials authenticates with a personal access token.
+func BearerCredentials(token string) CredentialsProvider {
+	return bearerCredentials{token: token}
+}
+
+type bearerCredentials struct {
+	token string
+}
+
+// Name implements the CredentialsProvider interface.
+func (bearerCredentials) Name() string {
+	return string(AuthTypePAT)
+}
+
+// Authenticate implements the CredentialsProvider interface.
+func (p bearerCredentials) Authenticate(req *http.Request) error {
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", p.token))
+	return nil
+}
+
+// BasicCredentials authenticates with a username and password.
+func BasicCredentials(username, password string) CredentialsProvider {
+	return basicCredentials{username: username, password: password}
+}
+
+type basicCredentials struct {
+	username string
+	password string
+}
+
+// Name implements the CredentialsProvider interface.
+func (basicCredentials) Name() string {
+	return string(AuthTypeBasic)
+}
+
+// Authenticate implements the CredentialsProvider interface.
+func (p basicCredentials) Authenticate(req *http.Request) error {
+	req.SetBasicAuth(p.username, p.password)
+	return nil
+}
+
+// NetrcCredentials authenticates with the login and password of the
+// request's host in the user's .netrc file (or the file set by NETRC).
+func NetrcCredentials() CredentialsProvider {
+	return netrcCredentials{}
+}
+
+type netrcCredentials struct{}
+
+// Name implements the CredentialsProvider interface.
+func (netrcCredentials) Name() string {
+	return string(AuthTypeNetrc)
+}
+
+// Authenticate implements the CredentialsProvider interface.
+func (netrcCredentials) Authenticate(req *http.Request) error {
+	u := *req.URL
+	u.User = nil
+	if err := addAuthFromNetrc(&u); err != nil {
+		return err
+	}
+	if u.User == nil {
+		return ErrNoCredentials
+	}
+	password, _ := u.User.Password()
+	req.SetBasicAuth(u.User.Username(), password)
+	return nil
+}
+
+// TokenSourceCredentials authenticates with tokens from a TokenSource, such
+// as OAuthM2MCredentials or OAuthU2MCredentials.
+func TokenSourceCredentials(name string, source TokenSource) CredentialsProvider {
+	return &tokenSourceCredentials{name: name, source: source}
+}
+
+type tokenSourceCredentials struct {
+	name   string
+	source TokenSource
+}
+
+// Name implements the CredentialsProvider interface.
+func (p *tokenSourceCredentials) Name() string {
+	return p.name
+}
+
+// Authenticate implements the CredentialsProvider interface.
+func (p *tokenSourceCredentials) Authenticate(req *http.Request) error {
+	token, err := p.source.Token(req.Context())
+	if err != nil {
+		return err
+	}
+	req.Header.Set(
+		"Authorization", fmt.Sprintf("%s %s", token.TokenType, token.AccessToken))
+	return nil
+}
+
+func (p *tokenSourceCredentials) setTransport(base http.RoundTripper) {
+	if setter, ok := p.source.(transportSetter); ok {
+		setter.setTransport(base)
+	}
+}
+
+// lazyCredentials builds a provider the first time a request is
+// authenticated, from the request's host. This lets the providers of the
+// default chain be created before the host is known.
+type lazyCredentials struct {
+	name  string
+	build func(host string) (CredentialsProvider, error)
+
+	mu        sync.Mutex
+	base      http.RoundTripper
+	providers map[string]CredentialsProvider
+}
+
+func newLazyCredentials(
+	name string,
+	build func(host string) (CredentialsProvider, error),
+) *lazyCredentials {
+	return &lazyCredentials{
+		name:      name,
+		build:     build,
+		providers: map[string]CredentialsProvider{},
+	}
+}
+
+// Name implements the CredentialsProvider interface.
+func (p *lazyCredentials) Name() string {
+	return p.name
+}
+
+// Authenticate implements the CredentialsProvider interface.
+func (p *lazyCredentials) Authenticate(req *http.Request) error {
+	host := requestHost(req)
+	p.mu.Lock()
+	provider, ok := p.providers[host]
+	if !ok {
+		var err error
+		provider, err = p.build(host)
+		if err != nil {
+			p.mu.Unlock()
+			return err
+		}
+		if provider == nil {
+			provider = noCredentials{}
+		}
+		if setter, ok := provider.(transportSetter); ok && p.base != nil {
+			setter.setTransport(p.base)
+		}
+		p.providers[host] = provider
+	}
+	p.mu.Unlock()
+	return provider.Authenticate(req)
+}
+
+func (p *lazyCredentials) setTransport(base http.RoundTripper) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.base = base
+}
+
+type noCredentials struct{}
+
+// Name implements the CredentialsProvider interface.
+func (noCredentials) Name() string {
+	return "none"
+}
+
+// Authenticate implements the CredentialsProvider interface.
+func (noCredentials) Authenticate(req *http.Request) error {
+	return ErrNoCredentials
+}
+
+// EnvCredentials authenticates with the credentials of the
+// DATABRICKS_TOKEN, DATABRICKS_USERNAME and DATABRICKS_PASSWORD, or
+// DATABRICKS_CLIENT_ID and DATABRICKS_CLIENT_SECRET environment variables.
+func EnvCredentials() CredentialsProvider {
+	return envCredentials(os.Getenv)
+}
+
+func envCredentials(getenv func(string) string) CredentialsProvider {
+	return newLazyCredentials("env", func(host string) (CredentialsProvider, error) {
+		cfg := &Config{
+			Host:         host,
+			Token:        getenv(EnvToken),
+			Username:     getenv(EnvUsername),
+			Password:     getenv(EnvPassword),
+			ClientID:     getenv(EnvClientID),
+			ClientSecret: getenv(EnvClientSecret),
+			AuthType:     AuthType(getenv(EnvAuthType)),
+		}
+		return cfg.explicitCredentials()
+	})
+}
+
+// ProfileCredentials authenticates with the credentials of a profile in
+// ~/.databrickscfg. The profile and file are selected as in LoadConfig, but
+// credentials in the environment are ignored. The profile's host is ignored
+// too, the credentials are used for the Client's host.
+func ProfileCredentials(opts ...ConfigOpt) CredentialsProvider {
+	return profileCredentials(os.Getenv, opts...)
+}
+
+func profileCredentials(
+	getenv func(string) string,
+	opts ...ConfigOpt,
+) CredentialsProvider {
+	return newLazyCredentials("profile", func(host string) (CredentialsProvider, error) {
+		// Only the environment variables selecting the profile are used.
+		profileEnv := configGetenv(func(key string) string {
+			if key == EnvConfigFile || key == EnvConfigProfile {
+				return getenv(key)
+			}
+			return ""
+		})
+		cfg, err := LoadConfig(append(
+			[]ConfigOpt{profileEnv, ConfigHost(host)}, opts...,
+		)...)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Host = host
+		return cfg.explicitCredentials()
+	})
+}
+
+// BrowserCredentials authenticates with OAuthU2MCredentials, logging in
+// with the browser when no cached token can be used.
+func BrowserCredentials(opts ...OAuthU2MOpt) CredentialsProvider {
+	return newLazyCredentials(
+		string(AuthTypeOAuthU2M),
+		func(host string) (CredentialsProvider, error) {
+			creds, err := NewOAuthU2MCredentials(host, opts...)
+			if err != nil {
+				return nil, err
+			}
+			return TokenSourceCredentials(string(AuthTypeOAuthU2M), creds), nil
+		},
+	)
+}
+
+// CredentialsChain tries a list of providers in order. The first provider
+// that has credentials for a host is used for all following requests to
+// that host.
+type CredentialsChain struct {
+	providers []CredentialsProvider
+
+	mu       sync.Mutex
+	selected map[string]CredentialsProvider
+}
+
+// NewCredentialsChain returns a CredentialsChain of the providers.
+func NewCredentialsChain(providers ...CredentialsProvider) *CredentialsChain {
+	return &CredentialsChain{
+		providers: providers,
+		selected:  map[string]CredentialsProvider{},
+	}
+}
+
+// DefaultCredentials returns the default CredentialsChain: the environment,
+// then the config profile, then .netrc and finally a browser login.
+func DefaultCredentials() *CredentialsChain {
+	return NewCredentialsChain(
+		EnvCredentials(),
+		ProfileCredentials(),
+		NetrcCredentials(),
+		BrowserCredentials(),
+	)
+}
+
+// Name implements the CredentialsProvider interface.
+func (c *CredentialsChain) Name() string {
+	names := make([]string, 0, len(c.providers))
+	for _, provider := range c.providers {
+		names = append(names, provider.Name())
+	}
+	return "chain(" + strings.Join(names, ", ") + ")"
+}
+
+// Selected returns the provider used for a host, or nil if no request has
+// been authenticated for the host yet.
+func (c *CredentialsChain) Selected(host string) CredentialsProvider {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.selected[strings.TrimSuffix(host, "/")]
+}
+
+// Authenticate implements the CredentialsProvider interface.
+func (c *CredentialsChain) Authenticate(req *http.Request) error {
+	host := requestHost(req)
+	c.mu.Lock()
+	selected, ok := c.selected[host]
+	c.mu.Unlock()
+	if ok {
+		return selected.Authenticate(req)
+	}
+
+	for _, provider := range c.providers {
+		err := provider.Authenticate(req)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", provider.Name(), err)
+		}
+		c.mu.Lock()
+		c.selected[host] = provider
+		c.mu.Unlock()
+		return nil
+	}
+	return ErrNoCredentials
+}
+
+func (c *CredentialsChain) setTransport(base http.RoundTripper) {
+	for _, provider := range c.providers {
+		if setter, ok := provider.(transportSetter); ok {
+			setter.setTransport(base)
+		}
+	}
+}
+
+// explicitCredentials returns a provider for the credentials set in the
+// Config, or nil if there are none.
+func (c *Config) explicitCredentials() (CredentialsProvider, error) {
+	switch c.AuthType {
+	case "":
+	case AuthTypeNetrc:
+		return nil, nil
+	default:
+		return c.credentials()
+	}
+	switch {
+	case c.Token != "":
+		return BearerCredentials(c.Token), nil
+	case c.Username != "" && c.Password != "":
+		return BasicCredentials(c.Username, c.Password), nil
+	case c.ClientID != "" && c.ClientSecret != "":
+		m2m := *c
+		m2m.AuthType = AuthTypeOAuthM2M
+		return m2m.credentials()
+	}
+	return nil, nil
+}
+
+// requestHost returns the scheme and host of a request's URL, which is the
+// form of a normalized workspace host.
+func requestHost(req *http.Request) string {
+	return req.URL.Scheme + "://" + req.URL.Host
+}
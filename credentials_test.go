@@ -0,0 +1,180 @@
+package databricks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// countingTransport is a base transport that records the requests it sends.
+type countingTransport struct {
+	requests []*http.Request
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests = append(t.requests, req)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func authServerHelper(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"clusters":[],"auth":%q}`, r.Header.Get("Authorization"))
+		},
+	))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func Test_ClientCredentials_Transport(t *testing.T) {
+	t.Parallel()
+	server := authServerHelper(t)
+	transport := &countingTransport{}
+
+	client, err := NewClient(
+		"",
+		ClientHost(server.URL),
+		ClientTransport(transport),
+		ClientCredentials(BearerCredentials("dapi-token")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Cluster().List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(transport.requests) != 1 {
+		t.Fatalf("Expected the base transport to be used, got %d requests",
+			len(transport.requests))
+	}
+	got := transport.requests[0].Header.Get("Authorization")
+	if got != "Bearer dapi-token" {
+		t.Fatalf("Unexpected Authorization header: %q", got)
+	}
+}
+
+func Test_credentialsTransport_Clone(t *testing.T) {
+	t.Parallel()
+	server := authServerHelper(t)
+	transport := &countingTransport{}
+	client := &http.Client{
+		Transport: newCredentialsTransport(
+			BasicCredentials("user", "pass"), transport),
+	}
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if req.Header.Get("Authorization") != "" {
+		t.Fatal("Expected the original request not to be modified")
+	}
+	if _, _, ok := transport.requests[0].BasicAuth(); !ok {
+		t.Fatal("Expected basic auth on the sent request")
+	}
+}
+
+// staticCredentials is a CredentialsProvider that returns a fixed error,
+// or sets a header with its name.
+type staticCredentials struct {
+	name  string
+	err   error
+	calls int
+}
+
+// Name implements the CredentialsProvider interface.
+func (p *staticCredentials) Name() string {
+	return p.name
+}
+
+// Authenticate implements the CredentialsProvider interface.
+func (p *staticCredentials) Authenticate(req *http.Request) error {
+	p.calls++
+	if p.err != nil {
+		return p.err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.name)
+	return nil
+}
+
+func Test_CredentialsChain(t *testing.T) {
+	t.Parallel()
+	empty := &staticCredentials{name: "empty", err: ErrNoCredentials}
+	first := &staticCredentials{name: "first"}
+	second := &staticCredentials{name: "second"}
+	chain := NewCredentialsChain(empty, first, second)
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "https://example.com/api/", nil)
+		if err := chain.Authenticate(req); err != nil {
+			t.Fatal(err)
+		}
+		if got := req.Header.Get("Authorization"); got != "Bearer first" {
+			t.Fatalf("Unexpected Authorization header: %q", got)
+		}
+	}
+	if empty.calls != 1 || first.calls != 2 || second.calls != 0 {
+		t.Fatalf(
+			"Expected the selected provider to be remembered, calls: %d %d %d",
+			empty.calls, first.calls, second.calls,
+		)
+	}
+	if chain.Selected("https://example.com") != first {
+		t.Fatal("Expected first to be selected")
+	}
+
+	// Errors other than ErrNoCredentials stop the chain.
+	broken := errors.New("broken")
+	chain = NewCredentialsChain(&staticCredentials{name: "broken", err: broken}, first)
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/api/", nil)
+	if err := chain.Authenticate(req); !errors.Is(err, broken) {
+		t.Fatalf("Expected the provider error, got: %v", err)
+	}
+
+	chain = NewCredentialsChain(empty)
+	if err := chain.Authenticate(req); !errors.Is(err, ErrNoCredentials) {
+		t.Fatalf("Expected ErrNoCredentials, got: %v", err)
+	}
+}
+
+func Test_EnvCredentials(t *testing.T) {
+	t.Parallel()
+	path := configFileHelper(t, testConfigFile)
+	env := map[string]string{}
+	getenv := func(key string) string { return env[key] }
+	chain := NewCredentialsChain(
+		envCredentials(getenv),
+		profileCredentials(getenv, ConfigFile(path), ConfigProfile("basic")),
+	)
+
+	// The environment has no credentials, the profile is used.
+	req := httptest.NewRequest(http.MethodGet, "https://one.example.com/api/", nil)
+	if err := chain.Authenticate(req); err != nil {
+		t.Fatal(err)
+	}
+	if user, _, _ := req.BasicAuth(); user != "user@example.com" {
+		t.Fatalf("Expected the basic profile to be used, got %q", user)
+	}
+	if chain.Selected("https://one.example.com").Name() != "profile" {
+		t.Fatal("Expected the profile provider to be selected")
+	}
+
+	// The environment takes precedence.
+	env[EnvToken] = "dapi-env"
+	req = httptest.NewRequest(http.MethodGet, "https://two.example.com/api/", nil)
+	if err := chain.Authenticate(req); err != nil {
+		t.Fatal(err)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer dapi-env" {
+		t.Fatalf("Unexpected Authorization header: %q", got)
+	}
+}
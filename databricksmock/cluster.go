@@ -0,0 +1,221 @@
+package databricksmock
+
+import (
+	"context"
+
+	"github.com/medivo/databricks-go"
+)
+
+// Cluster is a mock of databricks.ClusterAPI.
+type Cluster struct {
+	Mock
+}
+
+var _ databricks.ClusterAPI = (*Cluster)(nil)
+
+// Create implements databricks.ClusterAPI.
+func (m *Cluster) Create(ctx context.Context, createReq *databricks.ClusterCreateRequest) (string, error) {
+	ret, err := m.Called("Create", createReq)
+	if err != nil {
+		return "", err
+	}
+	return value[string](ret, 0), ret.Error(1)
+}
+
+// Edit implements databricks.ClusterAPI.
+func (m *Cluster) Edit(ctx context.Context, editReq *databricks.ClusterEditRequest) error {
+	ret, err := m.Called("Edit", editReq)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Start implements databricks.ClusterAPI.
+func (m *Cluster) Start(ctx context.Context, clusterID string) error {
+	ret, err := m.Called("Start", clusterID)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Restart implements databricks.ClusterAPI.
+func (m *Cluster) Restart(ctx context.Context, clusterID string) error {
+	ret, err := m.Called("Restart", clusterID)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// ResizeWorkers implements databricks.ClusterAPI.
+func (m *Cluster) ResizeWorkers(ctx context.Context, clusterID string, workers int) error {
+	ret, err := m.Called("ResizeWorkers", clusterID, workers)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// ResizeAutoscale implements databricks.ClusterAPI.
+func (m *Cluster) ResizeAutoscale(ctx context.Context, clusterID string, autoscale databricks.Autoscale) error {
+	ret, err := m.Called("ResizeAutoscale", clusterID, autoscale)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Terminate implements databricks.ClusterAPI.
+func (m *Cluster) Terminate(ctx context.Context, clusterID string) error {
+	ret, err := m.Called("Terminate", clusterID)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Delete implements databricks.ClusterAPI.
+func (m *Cluster) Delete(ctx context.Context, clusterID string) error {
+	ret, err := m.Called("Delete", clusterID)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Get implements databricks.ClusterAPI.
+func (m *Cluster) Get(ctx context.Context, clusterID string) (*databricks.ClusterGetResponse, error) {
+	ret, err := m.Called("Get", clusterID)
+	if err != nil {
+		return nil, err
+	}
+	return value[*databricks.ClusterGetResponse](ret, 0), ret.Error(1)
+}
+
+// Pin implements databricks.ClusterAPI.
+func (m *Cluster) Pin(ctx context.Context, clusterID string) error {
+	ret, err := m.Called("Pin", clusterID)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Unpin implements databricks.ClusterAPI.
+func (m *Cluster) Unpin(ctx context.Context, clusterID string) error {
+	ret, err := m.Called("Unpin", clusterID)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// List implements databricks.ClusterAPI.
+func (m *Cluster) List(ctx context.Context) ([]databricks.ClusterInfo, error) {
+	ret, err := m.Called("List")
+	if err != nil {
+		return nil, err
+	}
+	return value[[]databricks.ClusterInfo](ret, 0), ret.Error(1)
+}
+
+// Zones implements databricks.ClusterAPI.
+func (m *Cluster) Zones(ctx context.Context) (*databricks.ClusterZoneResponse, error) {
+	ret, err := m.Called("Zones")
+	if err != nil {
+		return nil, err
+	}
+	return value[*databricks.ClusterZoneResponse](ret, 0), ret.Error(1)
+}
+
+// NodeTypes implements databricks.ClusterAPI.
+func (m *Cluster) NodeTypes(ctx context.Context) ([]databricks.NodeType, error) {
+	ret, err := m.Called("NodeTypes")
+	if err != nil {
+		return nil, err
+	}
+	return value[[]databricks.NodeType](ret, 0), ret.Error(1)
+}
+
+// SparkVersions implements databricks.ClusterAPI.
+func (m *Cluster) SparkVersions(ctx context.Context) ([]databricks.SparkNodeAwsAttributes, error) {
+	ret, err := m.Called("SparkVersions")
+	if err != nil {
+		return nil, err
+	}
+	return value[[]databricks.SparkNodeAwsAttributes](ret, 0), ret.Error(1)
+}
+
+// Events implements databricks.ClusterAPI.
+func (m *Cluster) Events(ctx context.Context, eventReq *databricks.ClusterEventRequest) (*databricks.ClusterEventResponse, error) {
+	ret, err := m.Called("Events", eventReq)
+	if err != nil {
+		return nil, err
+	}
+	return value[*databricks.ClusterEventResponse](ret, 0), ret.Error(1)
+}
+
+// EventsIter implements databricks.ClusterAPI.
+func (m *Cluster) EventsIter(ctx context.Context, eventReq *databricks.ClusterEventRequest, opts ...databricks.IterOpt) *databricks.Iterator[databricks.ClusterEvent] {
+	ret, err := m.Called("EventsIter", eventReq)
+	if err != nil {
+		return errIterator[databricks.ClusterEvent](ctx, err)
+	}
+	return iterator[databricks.ClusterEvent](ctx, ret, 0)
+}
+
+// WatchEvents implements databricks.ClusterAPI.
+func (m *Cluster) WatchEvents(ctx context.Context, clusterID string, types ...databricks.ClusterEventType) (<-chan databricks.ClusterEvent, <-chan error) {
+	ret, err := m.Called("WatchEvents", clusterID)
+	if err != nil {
+		return errChannels[databricks.ClusterEvent](err)
+	}
+	return channels[databricks.ClusterEvent](ret)
+}
+
+// WaitForClusterState implements databricks.ClusterAPI.
+func (m *Cluster) WaitForClusterState(ctx context.Context, clusterID string, target databricks.ClusterState, opts ...databricks.WaitOpt) (*databricks.ClusterGetResponse, error) {
+	ret, err := m.Called("WaitForClusterState", clusterID, target)
+	if err != nil {
+		return nil, err
+	}
+	return value[*databricks.ClusterGetResponse](ret, 0), ret.Error(1)
+}
+
+// StartAndWait implements databricks.ClusterAPI.
+func (m *Cluster) StartAndWait(ctx context.Context, clusterID string, opts ...databricks.WaitOpt) (*databricks.ClusterGetResponse, error) {
+	ret, err := m.Called("StartAndWait", clusterID)
+	if err != nil {
+		return nil, err
+	}
+	return value[*databricks.ClusterGetResponse](ret, 0), ret.Error(1)
+}
+
+// CreateAndWait implements databricks.ClusterAPI.
+func (m *Cluster) CreateAndWait(ctx context.Context, createReq *databricks.ClusterCreateRequest, opts ...databricks.WaitOpt) (string, *databricks.ClusterGetResponse, error) {
+	ret, err := m.Called("CreateAndWait", createReq)
+	if err != nil {
+		return "", nil, err
+	}
+	return value[string](ret, 0), value[*databricks.ClusterGetResponse](ret, 1), ret.Error(2)
+}
+
+// TerminateAndWait implements databricks.ClusterAPI.
+func (m *Cluster) TerminateAndWait(ctx context.Context, clusterID string, opts ...databricks.WaitOpt) (*databricks.ClusterGetResponse, error) {
+	ret, err := m.Called("TerminateAndWait", clusterID)
+	if err != nil {
+		return nil, err
+	}
+	return value[*databricks.ClusterGetResponse](ret, 0), ret.Error(1)
+}
+
+// Ensure implements databricks.ClusterAPI.
+func (m *Cluster) Ensure(ctx context.Context, spec *databricks.ClusterSpec, opts ...databricks.EnsureOpt) (*databricks.EnsureResult, error) {
+	ret, err := m.Called("Ensure", spec)
+	if err != nil {
+		return nil, err
+	}
+	return value[*databricks.EnsureResult](ret, 0), ret.Error(1)
+}
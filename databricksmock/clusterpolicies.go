@@ -0,0 +1,59 @@
+package databricksmock
+
+import (
+	"context"
+
+	"github.com/medivo/databricks-go"
+)
+
+// ClusterPolicies is a mock of databricks.ClusterPoliciesAPI.
+type ClusterPolicies struct {
+	Mock
+}
+
+var _ databricks.ClusterPoliciesAPI = (*ClusterPolicies)(nil)
+
+// Create implements databricks.ClusterPoliciesAPI.
+func (m *ClusterPolicies) Create(ctx context.Context, policy *databricks.Policy) (string, error) {
+	ret, err := m.Called("Create", policy)
+	if err != nil {
+		return "", err
+	}
+	return value[string](ret, 0), ret.Error(1)
+}
+
+// Edit implements databricks.ClusterPoliciesAPI.
+func (m *ClusterPolicies) Edit(ctx context.Context, policy *databricks.Policy) error {
+	ret, err := m.Called("Edit", policy)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Get implements databricks.ClusterPoliciesAPI.
+func (m *ClusterPolicies) Get(ctx context.Context, policyID string) (*databricks.Policy, error) {
+	ret, err := m.Called("Get", policyID)
+	if err != nil {
+		return nil, err
+	}
+	return value[*databricks.Policy](ret, 0), ret.Error(1)
+}
+
+// List implements databricks.ClusterPoliciesAPI.
+func (m *ClusterPolicies) List(ctx context.Context) ([]databricks.Policy, error) {
+	ret, err := m.Called("List")
+	if err != nil {
+		return nil, err
+	}
+	return value[[]databricks.Policy](ret, 0), ret.Error(1)
+}
+
+// Delete implements databricks.ClusterPoliciesAPI.
+func (m *ClusterPolicies) Delete(ctx context.Context, policyID string) error {
+	ret, err := m.Called("Delete", policyID)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
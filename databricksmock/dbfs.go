@@ -0,0 +1,104 @@
+package databricksmock
+
+import (
+	"context"
+
+	"github.com/medivo/databricks-go"
+)
+
+// DBFS is a mock of databricks.DBFSAPI.
+type DBFS struct {
+	Mock
+}
+
+var _ databricks.DBFSAPI = (*DBFS)(nil)
+
+// AddBlock implements databricks.DBFSAPI.
+func (m *DBFS) AddBlock(ctx context.Context, handle int64, data []byte) error {
+	ret, err := m.Called("AddBlock", handle, data)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Close implements databricks.DBFSAPI.
+func (m *DBFS) Close(ctx context.Context, handle int64) error {
+	ret, err := m.Called("Close", handle)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Create implements databricks.DBFSAPI.
+func (m *DBFS) Create(ctx context.Context, path string, overwrite bool) (int64, error) {
+	ret, err := m.Called("Create", path, overwrite)
+	if err != nil {
+		return 0, err
+	}
+	return value[int64](ret, 0), ret.Error(1)
+}
+
+// Delete implements databricks.DBFSAPI.
+func (m *DBFS) Delete(ctx context.Context, path string, recursive bool) error {
+	ret, err := m.Called("Delete", path, recursive)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// GetStatus implements databricks.DBFSAPI.
+func (m *DBFS) GetStatus(ctx context.Context, path string) (bool, int64, error) {
+	ret, err := m.Called("GetStatus", path)
+	if err != nil {
+		return false, 0, err
+	}
+	return value[bool](ret, 0), value[int64](ret, 1), ret.Error(2)
+}
+
+// List implements databricks.DBFSAPI.
+func (m *DBFS) List(ctx context.Context, path string) ([]databricks.FileInfo, error) {
+	ret, err := m.Called("List", path)
+	if err != nil {
+		return nil, err
+	}
+	return value[[]databricks.FileInfo](ret, 0), ret.Error(1)
+}
+
+// Mkdirs implements databricks.DBFSAPI.
+func (m *DBFS) Mkdirs(ctx context.Context, path string) error {
+	ret, err := m.Called("Mkdirs", path)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Move implements databricks.DBFSAPI.
+func (m *DBFS) Move(ctx context.Context, src string, dest string) error {
+	ret, err := m.Called("Move", src, dest)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Put implements databricks.DBFSAPI.
+func (m *DBFS) Put(ctx context.Context, path string, content []byte, overwrite bool) error {
+	ret, err := m.Called("Put", path, content, overwrite)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Read implements databricks.DBFSAPI.
+func (m *DBFS) Read(ctx context.Context, path string, offset int64, length int64) (int64, []byte, error) {
+	ret, err := m.Called("Read", path, offset, length)
+	if err != nil {
+		return 0, nil, err
+	}
+	return value[int64](ret, 0), value[[]byte](ret, 1), ret.Error(2)
+}
@@ -0,0 +1,95 @@
+package databricksmock
+
+import (
+	"context"
+
+	"github.com/medivo/databricks-go"
+)
+
+// Groups is a mock of databricks.GroupsAPI.
+type Groups struct {
+	Mock
+}
+
+var _ databricks.GroupsAPI = (*Groups)(nil)
+
+// AddMember implements databricks.GroupsAPI.
+func (m *Groups) AddMember(ctx context.Context, userName string, groupName string, parentName string) error {
+	ret, err := m.Called("AddMember", userName, groupName, parentName)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Create implements databricks.GroupsAPI.
+func (m *Groups) Create(ctx context.Context, groupName string) error {
+	ret, err := m.Called("Create", groupName)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Members implements databricks.GroupsAPI.
+func (m *Groups) Members(ctx context.Context, groupName string) ([]databricks.PrincipalName, error) {
+	ret, err := m.Called("Members", groupName)
+	if err != nil {
+		return nil, err
+	}
+	return value[[]databricks.PrincipalName](ret, 0), ret.Error(1)
+}
+
+// Groups implements databricks.GroupsAPI.
+func (m *Groups) Groups(ctx context.Context) ([]string, error) {
+	ret, err := m.Called("Groups")
+	if err != nil {
+		return nil, err
+	}
+	return value[[]string](ret, 0), ret.Error(1)
+}
+
+// UserParents implements databricks.GroupsAPI.
+func (m *Groups) UserParents(ctx context.Context, userName string) ([]string, error) {
+	ret, err := m.Called("UserParents", userName)
+	if err != nil {
+		return nil, err
+	}
+	return value[[]string](ret, 0), ret.Error(1)
+}
+
+// GroupParents implements databricks.GroupsAPI.
+func (m *Groups) GroupParents(ctx context.Context, groupName string) ([]string, error) {
+	ret, err := m.Called("GroupParents", groupName)
+	if err != nil {
+		return nil, err
+	}
+	return value[[]string](ret, 0), ret.Error(1)
+}
+
+// RemoveUser implements databricks.GroupsAPI.
+func (m *Groups) RemoveUser(ctx context.Context, userName string) error {
+	ret, err := m.Called("RemoveUser", userName)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// RemoveGroup implements databricks.GroupsAPI.
+func (m *Groups) RemoveGroup(ctx context.Context, groupName string) error {
+	ret, err := m.Called("RemoveGroup", groupName)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Delete implements databricks.GroupsAPI.
+func (m *Groups) Delete(ctx context.Context, groupName string) error {
+	ret, err := m.Called("Delete", groupName)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
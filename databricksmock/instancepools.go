@@ -0,0 +1,59 @@
+package databricksmock
+
+import (
+	"context"
+
+	"github.com/medivo/databricks-go"
+)
+
+// InstancePools is a mock of databricks.InstancePoolsAPI.
+type InstancePools struct {
+	Mock
+}
+
+var _ databricks.InstancePoolsAPI = (*InstancePools)(nil)
+
+// Create implements databricks.InstancePoolsAPI.
+func (m *InstancePools) Create(ctx context.Context, spec *databricks.InstancePoolSpec) (string, error) {
+	ret, err := m.Called("Create", spec)
+	if err != nil {
+		return "", err
+	}
+	return value[string](ret, 0), ret.Error(1)
+}
+
+// Edit implements databricks.InstancePoolsAPI.
+func (m *InstancePools) Edit(ctx context.Context, editReq *databricks.InstancePoolEditRequest) error {
+	ret, err := m.Called("Edit", editReq)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Get implements databricks.InstancePoolsAPI.
+func (m *InstancePools) Get(ctx context.Context, instancePoolID string) (*databricks.InstancePool, error) {
+	ret, err := m.Called("Get", instancePoolID)
+	if err != nil {
+		return nil, err
+	}
+	return value[*databricks.InstancePool](ret, 0), ret.Error(1)
+}
+
+// List implements databricks.InstancePoolsAPI.
+func (m *InstancePools) List(ctx context.Context) ([]databricks.InstancePool, error) {
+	ret, err := m.Called("List")
+	if err != nil {
+		return nil, err
+	}
+	return value[[]databricks.InstancePool](ret, 0), ret.Error(1)
+}
+
+// Delete implements databricks.InstancePoolsAPI.
+func (m *InstancePools) Delete(ctx context.Context, instancePoolID string) error {
+	ret, err := m.Called("Delete", instancePoolID)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
@@ -0,0 +1,167 @@
+package databricksmock
+
+import (
+	"context"
+
+	"github.com/medivo/databricks-go"
+)
+
+// Jobs is a mock of databricks.JobsAPI.
+type Jobs struct {
+	Mock
+}
+
+var _ databricks.JobsAPI = (*Jobs)(nil)
+
+// Create implements databricks.JobsAPI.
+func (m *Jobs) Create(ctx context.Context, createReq *databricks.JobCreateRequest) (int64, error) {
+	ret, err := m.Called("Create", createReq)
+	if err != nil {
+		return 0, err
+	}
+	return value[int64](ret, 0), ret.Error(1)
+}
+
+// List implements databricks.JobsAPI.
+func (m *Jobs) List(ctx context.Context) ([]databricks.Job, error) {
+	ret, err := m.Called("List")
+	if err != nil {
+		return nil, err
+	}
+	return value[[]databricks.Job](ret, 0), ret.Error(1)
+}
+
+// ListPage implements databricks.JobsAPI.
+func (m *Jobs) ListPage(ctx context.Context, offset int, limit int) ([]databricks.Job, bool, error) {
+	ret, err := m.Called("ListPage", offset, limit)
+	if err != nil {
+		return nil, false, err
+	}
+	return value[[]databricks.Job](ret, 0), value[bool](ret, 1), ret.Error(2)
+}
+
+// ListIter implements databricks.JobsAPI.
+func (m *Jobs) ListIter(ctx context.Context, opts ...databricks.IterOpt) *databricks.Iterator[databricks.Job] {
+	ret, err := m.Called("ListIter")
+	if err != nil {
+		return errIterator[databricks.Job](ctx, err)
+	}
+	return iterator[databricks.Job](ctx, ret, 0)
+}
+
+// Delete implements databricks.JobsAPI.
+func (m *Jobs) Delete(ctx context.Context, jobID int64) error {
+	ret, err := m.Called("Delete", jobID)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Get implements databricks.JobsAPI.
+func (m *Jobs) Get(ctx context.Context, jobID int64) (*databricks.JobGetResponse, error) {
+	ret, err := m.Called("Get", jobID)
+	if err != nil {
+		return nil, err
+	}
+	return value[*databricks.JobGetResponse](ret, 0), ret.Error(1)
+}
+
+// Reset implements databricks.JobsAPI.
+func (m *Jobs) Reset(ctx context.Context, jobID int64, settings databricks.JobSettings) error {
+	ret, err := m.Called("Reset", jobID, settings)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// RunNow implements databricks.JobsAPI.
+func (m *Jobs) RunNow(ctx context.Context, settings *databricks.JobRunNowSettings) (int64, int64, error) {
+	ret, err := m.Called("RunNow", settings)
+	if err != nil {
+		return 0, 0, err
+	}
+	return value[int64](ret, 0), value[int64](ret, 1), ret.Error(2)
+}
+
+// RunSubmit implements databricks.JobsAPI.
+func (m *Jobs) RunSubmit(ctx context.Context, settings *databricks.JobSubmitSettings) (int64, error) {
+	ret, err := m.Called("RunSubmit", settings)
+	if err != nil {
+		return 0, err
+	}
+	return value[int64](ret, 0), ret.Error(1)
+}
+
+// RunsList implements databricks.JobsAPI.
+func (m *Jobs) RunsList(ctx context.Context, runListReq *databricks.JobRunListRequest) ([]databricks.Run, bool, error) {
+	ret, err := m.Called("RunsList", runListReq)
+	if err != nil {
+		return nil, false, err
+	}
+	return value[[]databricks.Run](ret, 0), value[bool](ret, 1), ret.Error(2)
+}
+
+// RunsIter implements databricks.JobsAPI.
+func (m *Jobs) RunsIter(ctx context.Context, runListReq *databricks.JobRunListRequest, opts ...databricks.IterOpt) *databricks.Iterator[databricks.Run] {
+	ret, err := m.Called("RunsIter", runListReq)
+	if err != nil {
+		return errIterator[databricks.Run](ctx, err)
+	}
+	return iterator[databricks.Run](ctx, ret, 0)
+}
+
+// RunsGet implements databricks.JobsAPI.
+func (m *Jobs) RunsGet(ctx context.Context, runID int64) (*databricks.JobRunGetResponse, error) {
+	ret, err := m.Called("RunsGet", runID)
+	if err != nil {
+		return nil, err
+	}
+	return value[*databricks.JobRunGetResponse](ret, 0), ret.Error(1)
+}
+
+// RunsExport implements databricks.JobsAPI.
+func (m *Jobs) RunsExport(ctx context.Context, runID int64, viewToExport string) ([]databricks.View, error) {
+	ret, err := m.Called("RunsExport", runID, viewToExport)
+	if err != nil {
+		return nil, err
+	}
+	return value[[]databricks.View](ret, 0), ret.Error(1)
+}
+
+// RunsCancel implements databricks.JobsAPI.
+func (m *Jobs) RunsCancel(ctx context.Context, runID int64) error {
+	ret, err := m.Called("RunsCancel", runID)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// RunsGetOutput implements databricks.JobsAPI.
+func (m *Jobs) RunsGetOutput(ctx context.Context, runID int64) (string, *databricks.Run, error) {
+	ret, err := m.Called("RunsGetOutput", runID)
+	if err != nil {
+		return "", nil, err
+	}
+	return value[string](ret, 0), value[*databricks.Run](ret, 1), ret.Error(2)
+}
+
+// RunsDelete implements databricks.JobsAPI.
+func (m *Jobs) RunsDelete(ctx context.Context, runID int64) error {
+	ret, err := m.Called("RunsDelete", runID)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// WaitForRun implements databricks.JobsAPI.
+func (m *Jobs) WaitForRun(ctx context.Context, runID int64, opts ...databricks.WaitOpt) (*databricks.JobRunGetResponse, error) {
+	ret, err := m.Called("WaitForRun", runID)
+	if err != nil {
+		return nil, err
+	}
+	return value[*databricks.JobRunGetResponse](ret, 0), ret.Error(1)
+}
@@ -0,0 +1,59 @@
+package databricksmock
+
+import (
+	"context"
+
+	"github.com/medivo/databricks-go"
+)
+
+// Libraries is a mock of databricks.LibrariesAPI.
+type Libraries struct {
+	Mock
+}
+
+var _ databricks.LibrariesAPI = (*Libraries)(nil)
+
+// AllClusterStatuses implements databricks.LibrariesAPI.
+func (m *Libraries) AllClusterStatuses(ctx context.Context) ([]databricks.ClusterLibraryStatuses, error) {
+	ret, err := m.Called("AllClusterStatuses")
+	if err != nil {
+		return nil, err
+	}
+	return value[[]databricks.ClusterLibraryStatuses](ret, 0), ret.Error(1)
+}
+
+// ClusterStatus implements databricks.LibrariesAPI.
+func (m *Libraries) ClusterStatus(ctx context.Context, clusterID string) ([]databricks.LibraryFullStatus, error) {
+	ret, err := m.Called("ClusterStatus", clusterID)
+	if err != nil {
+		return nil, err
+	}
+	return value[[]databricks.LibraryFullStatus](ret, 0), ret.Error(1)
+}
+
+// Install implements databricks.LibrariesAPI.
+func (m *Libraries) Install(ctx context.Context, clusterID string, libraries []databricks.Library) error {
+	ret, err := m.Called("Install", clusterID, libraries)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Uninstall implements databricks.LibrariesAPI.
+func (m *Libraries) Uninstall(ctx context.Context, clusterID string, libraries []databricks.Library) error {
+	ret, err := m.Called("Uninstall", clusterID, libraries)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// WaitForInstall implements databricks.LibrariesAPI.
+func (m *Libraries) WaitForInstall(ctx context.Context, clusterID string, opts ...databricks.WaitOpt) ([]databricks.LibraryFullStatus, error) {
+	ret, err := m.Called("WaitForInstall", clusterID)
+	if err != nil {
+		return nil, err
+	}
+	return value[[]databricks.LibraryFullStatus](ret, 0), ret.Error(1)
+}
@@ -0,0 +1,437 @@
+// Package databricksmock provides mock implementations of the service
+// interfaces of the databricks package, for unit testing code that depends
+// on them.
+//
+// Every mock embeds a Mock, on which the expected calls are set up by
+// method name and arguments. The context argument of a method is never
+// matched:
+//
+//	jobs := &databricksmock.Jobs{}
+//	jobs.On("RunNow", databricksmock.Anything).Return(int64(7), int64(1), nil).Once()
+//	jobs.On("RunsGet", int64(7)).Return(&databricks.JobRunGetResponse{}, nil)
+//	defer jobs.AssertExpectations(t)
+//
+// Methods that return an iterator also accept a slice of items, which is
+// iterated as a single page, or an error that the iterator stops with:
+//
+//	jobs.On("ListIter").Return([]databricks.Job{{JobID: 1}})
+//
+// A call that was not expected returns an error wrapping ErrUnexpectedCall,
+// and fails AssertExpectations.
+package databricksmock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/medivo/databricks-go"
+)
+
+// ErrUnexpectedCall is wrapped by the error that a mock returns for a call
+// that was not expected.
+var ErrUnexpectedCall = errors.New("unexpected call")
+
+// Anything matches any argument.
+const Anything = "databricksmock.Anything"
+
+// argumentMatcher matches an argument with a function.
+type argumentMatcher struct {
+	fn reflect.Value
+}
+
+// MatchedBy returns an argument matcher that calls fn with the argument,
+// which must be a func with a single parameter that returns a bool. The
+// argument does not match if it is not assignable to the parameter.
+//
+//	clusters.On("Create", databricksmock.MatchedBy(
+//		func(req *databricks.ClusterCreateRequest) bool {
+//			return req.ClusterName == "etl"
+//		},
+//	)).Return("0101-120000-abc", nil)
+func MatchedBy(fn interface{}) interface{} {
+	v := reflect.ValueOf(fn)
+	t := v.Type()
+	if t.Kind() != reflect.Func ||
+		t.NumIn() != 1 ||
+		t.NumOut() != 1 ||
+		t.Out(0).Kind() != reflect.Bool {
+		panic(fmt.Sprintf("databricksmock: MatchedBy needs a func(T) bool, got %s", t))
+	}
+	return argumentMatcher{fn: v}
+}
+
+func (m argumentMatcher) match(arg interface{}) bool {
+	in := m.fn.Type().In(0)
+	var v reflect.Value
+	if arg == nil {
+		switch in.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface,
+			reflect.Map, reflect.Ptr, reflect.Slice:
+			v = reflect.Zero(in)
+		default:
+			return false
+		}
+	} else {
+		v = reflect.ValueOf(arg)
+		if !v.Type().AssignableTo(in) {
+			return false
+		}
+	}
+	return m.fn.Call([]reflect.Value{v})[0].Bool()
+}
+
+// Arguments are the arguments or return values of a call.
+type Arguments []interface{}
+
+// Get returns the value at index i, or nil if there is none.
+func (a Arguments) Get(i int) interface{} {
+	if i < 0 || i >= len(a) {
+		return nil
+	}
+	return a[i]
+}
+
+// String returns the string at index i.
+func (a Arguments) String(i int) string {
+	s, _ := a.Get(i).(string)
+	return s
+}
+
+// Int returns the int at index i.
+func (a Arguments) Int(i int) int {
+	n, _ := a.Get(i).(int)
+	return n
+}
+
+// Int64 returns the int64 at index i.
+func (a Arguments) Int64(i int) int64 {
+	n, _ := a.Get(i).(int64)
+	return n
+}
+
+// Bool returns the bool at index i.
+func (a Arguments) Bool(i int) bool {
+	b, _ := a.Get(i).(bool)
+	return b
+}
+
+// Error returns the error at index i.
+func (a Arguments) Error(i int) error {
+	err, _ := a.Get(i).(error)
+	return err
+}
+
+// value returns the value at index i as a T, or the zero T if there is
+// none. Values of the same kind are converted, so Return(7, "READ") works
+// for methods that return an int64 and an ACLPermission.
+func value[T any](a Arguments, i int) T {
+	var zero T
+	v := a.Get(i)
+	if v == nil {
+		return zero
+	}
+	if t, ok := v.(T); ok {
+		return t
+	}
+	rv := reflect.ValueOf(v)
+	to := reflect.TypeOf(zero)
+	if to != nil && (rv.Kind() == to.Kind() || isInt(rv.Kind()) && isInt(to.Kind())) &&
+		rv.Type().ConvertibleTo(to) {
+		return rv.Convert(to).Interface().(T)
+	}
+	return zero
+}
+
+// iterator returns the value at index i as an iterator. Besides an
+// iterator, the value may be a slice of items, which is iterated as a single
+// page, or an error, which the iterator stops with. Nothing is returned as
+// an empty iterator.
+func iterator[T any](ctx context.Context, a Arguments, i int) *databricks.Iterator[T] {
+	var items []T
+	switch v := a.Get(i).(type) {
+	case *databricks.Iterator[T]:
+		if v != nil {
+			return v
+		}
+	case []T:
+		items = v
+	case error:
+		return errIterator[T](ctx, v)
+	}
+	return databricks.NewIterator(ctx, func(context.Context) ([]T, bool, error) {
+		return items, false, nil
+	})
+}
+
+// errIterator returns an iterator that stops with err.
+func errIterator[T any](ctx context.Context, err error) *databricks.Iterator[T] {
+	return databricks.NewIterator(ctx, func(context.Context) ([]T, bool, error) {
+		return nil, false, err
+	})
+}
+
+// channels returns the first two values as the event and error channels of
+// a watch. Nothing is returned as closed channels.
+func channels[T any](a Arguments) (<-chan T, <-chan error) {
+	items, errs := value[<-chan T](a, 0), value[<-chan error](a, 1)
+	if items == nil && errs == nil {
+		return errChannels[T](nil)
+	}
+	return items, errs
+}
+
+// errChannels returns a closed channel of items, and a channel of errors
+// that yields err, if any, before it is closed.
+func errChannels[T any](err error) (<-chan T, <-chan error) {
+	items := make(chan T)
+	close(items)
+	errs := make(chan error, 1)
+	if err != nil {
+		errs <- err
+	}
+	close(errs)
+	return items, errs
+}
+
+func isInt(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func (a Arguments) matches(args []interface{}) bool {
+	if len(a) != len(args) {
+		return false
+	}
+	for i, expected := range a {
+		switch e := expected.(type) {
+		case string:
+			if e == Anything {
+				continue
+			}
+		case argumentMatcher:
+			if !e.match(args[i]) {
+				return false
+			}
+			continue
+		}
+		if !equal(expected, args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// equal compares an expected argument with an actual one. Like value, it
+// converts values of the same kind, so "READ" matches an ACLPermission.
+func equal(expected, actual interface{}) bool {
+	if reflect.DeepEqual(expected, actual) {
+		return true
+	}
+	if expected == nil || actual == nil {
+		return false
+	}
+	ev, av := reflect.ValueOf(expected), reflect.ValueOf(actual)
+	if ev.Kind() != av.Kind() && !(isInt(ev.Kind()) && isInt(av.Kind())) ||
+		!ev.Type().ConvertibleTo(av.Type()) {
+		return false
+	}
+	return reflect.DeepEqual(ev.Convert(av.Type()).Interface(), actual)
+}
+
+// Call is an expected call of a mock method.
+type Call struct {
+	mock      *Mock
+	method    string
+	args      Arguments
+	returns   Arguments
+	run       func(args Arguments)
+	times     int
+	optional  bool
+	callCount int
+}
+
+// Return sets the values returned by the call, in the order of the
+// results of the method. Missing values are returned as zero values.
+func (c *Call) Return(values ...interface{}) *Call {
+	c.mock.mu.Lock()
+	defer c.mock.mu.Unlock()
+	c.returns = values
+	return c
+}
+
+// Run sets a function that is called with the arguments of the call,
+// without the context, before it returns.
+func (c *Call) Run(fn func(args Arguments)) *Call {
+	c.mock.mu.Lock()
+	defer c.mock.mu.Unlock()
+	c.run = fn
+	return c
+}
+
+// Times sets the number of times the call is expected. By default a call
+// may be made any number of times, at least once.
+func (c *Call) Times(n int) *Call {
+	c.mock.mu.Lock()
+	defer c.mock.mu.Unlock()
+	c.times = n
+	return c
+}
+
+// Once expects the call exactly once.
+func (c *Call) Once() *Call {
+	return c.Times(1)
+}
+
+// Maybe makes the call optional, so AssertExpectations does not fail if it
+// was never made.
+func (c *Call) Maybe() *Call {
+	c.mock.mu.Lock()
+	defer c.mock.mu.Unlock()
+	c.optional = true
+	return c
+}
+
+func (c *Call) String() string {
+	args := make([]string, len(c.args))
+	for i, arg := range c.args {
+		args[i] = fmt.Sprintf("%#v", arg)
+	}
+	return fmt.Sprintf("%s(%s)", c.method, strings.Join(args, ", "))
+}
+
+// TestingT is the subset of testing.TB that a Mock reports to.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Mock records the expected and actual calls of a mock. The zero value is
+// ready to use.
+type Mock struct {
+	mu         sync.Mutex
+	expected   []*Call
+	calls      []actualCall
+	unexpected []string
+}
+
+// actualCall is a call made to a mock.
+type actualCall struct {
+	method string
+	args   Arguments
+}
+
+// On expects a call of the named method with the given arguments, without
+// the context. Arguments are compared with reflect.DeepEqual unless they
+// are Anything or made with MatchedBy. When several expectations match a
+// call, the first one that has not been used up is used.
+func (m *Mock) On(method string, args ...interface{}) *Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := &Call{mock: m, method: method, args: args}
+	m.expected = append(m.expected, c)
+	return c
+}
+
+// Called records a call of the named method and returns the values of the
+// expected call that it matches. It is used by the mock implementations.
+// If the call was not expected, the returned error wraps ErrUnexpectedCall.
+func (m *Mock) Called(method string, args ...interface{}) (Arguments, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, actualCall{method: method, args: args})
+	var call *Call
+	for _, c := range m.expected {
+		if c.method != method || !c.args.matches(args) {
+			continue
+		}
+		if c.times > 0 && c.callCount >= c.times {
+			continue
+		}
+		call = c
+		break
+	}
+	if call == nil {
+		desc := (&Call{method: method, args: args}).String()
+		m.unexpected = append(m.unexpected, desc)
+		m.mu.Unlock()
+		return nil, fmt.Errorf("databricksmock: %w: %s", ErrUnexpectedCall, desc)
+	}
+	call.callCount++
+	run, returns := call.run, call.returns
+	m.mu.Unlock()
+
+	if run != nil {
+		run(args)
+	}
+	return returns, nil
+}
+
+// AssertExpectations fails the test if a call was not expected, or if an
+// expected call was not made as many times as expected.
+func (m *Mock) AssertExpectations(t TestingT) bool {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ok := true
+	for _, desc := range m.unexpected {
+		t.Errorf("databricksmock: unexpected call %s", desc)
+		ok = false
+	}
+	for _, c := range m.expected {
+		switch {
+		case c.times > 0 && c.callCount != c.times:
+			t.Errorf("databricksmock: expected %s %d times, got %d",
+				c, c.times, c.callCount)
+			ok = false
+		case c.times == 0 && c.callCount == 0 && !c.optional:
+			t.Errorf("databricksmock: expected call %s was not made", c)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// AssertCalled fails the test if the named method was not called with
+// arguments matching args.
+func (m *Mock) AssertCalled(t TestingT, method string, args ...interface{}) bool {
+	t.Helper()
+	if m.count(method, args) == 0 {
+		t.Errorf("databricksmock: %s was not called",
+			(&Call{method: method, args: args}).String())
+		return false
+	}
+	return true
+}
+
+// AssertNumberOfCalls fails the test if the named method was not called n
+// times, with any arguments.
+func (m *Mock) AssertNumberOfCalls(t TestingT, method string, n int) bool {
+	t.Helper()
+	if count := m.count(method, nil); count != n {
+		t.Errorf("databricksmock: expected %s to be called %d times, got %d",
+			method, n, count)
+		return false
+	}
+	return true
+}
+
+// count returns the number of calls of a method with arguments matching
+// args, or with any arguments if args is nil.
+func (m *Mock) count(method string, args Arguments) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	count := 0
+	for _, c := range m.calls {
+		if c.method == method && (args == nil || args.matches(c.args)) {
+			count++
+		}
+	}
+	return count
+}
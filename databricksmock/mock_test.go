@@ -0,0 +1,226 @@
+package databricksmock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/medivo/databricks-go"
+)
+
+// recordingT records the failures reported by a Mock.
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+// deleteRuns is the kind of code that the mocks are meant to test.
+func deleteRuns(ctx context.Context, services databricks.Services) error {
+	runs, _, err := services.Jobs().RunsList(
+		ctx, &databricks.JobRunListRequest{JobID: 1})
+	if err != nil {
+		return err
+	}
+	for _, run := range runs {
+		if err := services.Jobs().RunsDelete(ctx, run.RunID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func Test_Services(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	services := &Services{}
+	services.JobsMock.
+		On("RunsList", &databricks.JobRunListRequest{JobID: 1}).
+		Return([]databricks.Run{{RunID: 1}, {RunID: 2}}, false, nil).
+		Once()
+	services.JobsMock.On("RunsDelete", Anything).Return(nil).Times(2)
+
+	if err := deleteRuns(ctx, services); err != nil {
+		t.Fatal(err)
+	}
+	services.AssertExpectations(t)
+	services.JobsMock.AssertCalled(t, "RunsDelete", int64(2))
+	services.JobsMock.AssertNumberOfCalls(t, "RunsDelete", 2)
+}
+
+func Test_Mock_Return(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	clusters := &Cluster{}
+	clusters.On("Get", "a").Return(&databricks.ClusterGetResponse{
+		ClusterID: "a",
+	}, nil)
+	clusters.On("Get", "b").Return(nil, errors.New("boom"))
+
+	res, err := clusters.Get(ctx, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.ClusterID != "a" {
+		t.Fatalf("Unexpected cluster: %+v", res)
+	}
+	res, err = clusters.Get(ctx, "b")
+	if err == nil || err.Error() != "boom" {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if res != nil {
+		t.Fatalf("Expected no cluster, got %+v", res)
+	}
+	clusters.AssertExpectations(t)
+}
+
+func Test_Mock_MatchedBy(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	secrets := &Secrets{}
+	secrets.On("PutSecret", "scope", MatchedBy(func(key string) bool {
+		return len(key) > 3
+	}), Anything).Return(nil)
+
+	if err := secrets.PutSecret(ctx, "scope", "password", "x"); err != nil {
+		t.Fatal(err)
+	}
+	err := secrets.PutSecret(ctx, "scope", "pw", "x")
+	if !errors.Is(err, ErrUnexpectedCall) {
+		t.Fatalf("Expected an unexpected call error, got %v", err)
+	}
+}
+
+func Test_Mock_Run(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dbfs := &DBFS{}
+	var written []byte
+	dbfs.On("Put", "/a", Anything, true).Run(func(args Arguments) {
+		written = args.Get(1).([]byte)
+	}).Return(nil)
+
+	if err := dbfs.Put(ctx, "/a", []byte("data"), true); err != nil {
+		t.Fatal(err)
+	}
+	if string(written) != "data" {
+		t.Fatalf("Unexpected contents: %q", written)
+	}
+}
+
+func Test_Mock_AssertExpectations(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	tokens := &Token{}
+	tokens.On("List").Return([]databricks.PublicTokenInfo{}, nil).Once()
+	tokens.On("Create", uint(60)).Return("dapi", nil, nil)
+	tokens.On("Revoke", Anything).Return(nil).Maybe()
+
+	if _, err := tokens.List(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tokens.List(ctx); !errors.Is(err, ErrUnexpectedCall) {
+		t.Fatalf("Expected an unexpected call error, got %v", err)
+	}
+
+	rt := &recordingT{}
+	if tokens.AssertExpectations(rt) {
+		t.Fatal("AssertExpectations succeeded")
+	}
+	if len(rt.errors) != 2 {
+		t.Fatalf("Unexpected failures: %q", rt.errors)
+	}
+	if rt.errors[0] != `databricksmock: unexpected call List()` {
+		t.Fatalf("Unexpected failure: %q", rt.errors[0])
+	}
+	if rt.errors[1] != `databricksmock: expected call Create(0x3c) was not made` {
+		t.Fatalf("Unexpected failure: %q", rt.errors[1])
+	}
+}
+
+func Test_Mock_Conversion(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	secrets := &Secrets{}
+	secrets.On("GetSecretACL", "scope", "alice").Return("MANAGE", nil)
+	secrets.On("PutSecretACL", "scope", "bob", "READ").Return(nil)
+	jobs := &Jobs{}
+	jobs.On("Create", Anything).Return(7, nil)
+
+	permission, err := secrets.GetSecretACL(ctx, "scope", "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if permission != databricks.ACLPermissionManage {
+		t.Fatalf("Unexpected permission: %q", permission)
+	}
+	if err := secrets.PutSecretACL(ctx, "scope", "bob", databricks.ACLPermissionRead); err != nil {
+		t.Fatal(err)
+	}
+	jobID, err := jobs.Create(ctx, &databricks.JobCreateRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobID != 7 {
+		t.Fatalf("Unexpected job ID: %d", jobID)
+	}
+}
+
+func Test_Mock_Iterator(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	fail := errors.New("fail")
+	jobs := &Jobs{}
+	jobs.On("ListIter").Return([]databricks.Job{{JobID: 1}, {JobID: 2}}).Once()
+	jobs.On("ListIter").Return(fail).Once()
+	jobs.On("RunsIter", Anything).Return()
+
+	list, err := jobs.ListIter(ctx).Collect()
+	if err != nil || len(list) != 2 || list[1].JobID != 2 {
+		t.Fatalf("Unexpected jobs: %+v %v", list, err)
+	}
+	if _, err := jobs.ListIter(ctx).Collect(); !errors.Is(err, fail) {
+		t.Fatalf("Expected the returned error, got %v", err)
+	}
+	runs, err := jobs.RunsIter(ctx, &databricks.JobRunListRequest{}).Collect()
+	if err != nil || len(runs) != 0 {
+		t.Fatalf("Expected no runs, got %+v %v", runs, err)
+	}
+	_, err = jobs.ListIter(ctx).Collect()
+	if !errors.Is(err, ErrUnexpectedCall) {
+		t.Fatalf("Expected an unexpected call, got %v", err)
+	}
+}
+
+func Test_Mock_WatchEvents(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	events := make(chan databricks.ClusterEvent, 1)
+	events <- databricks.ClusterEvent{Type: databricks.ClusterEventResizing}
+	close(events)
+	errs := make(chan error, 1)
+	errs <- context.Canceled
+	clusters := &Cluster{}
+	clusters.On("WatchEvents", "a").Return(events, errs)
+
+	received, receivedErrs := clusters.WatchEvents(ctx, "a", databricks.ClusterEventResizing)
+	if event := <-received; event.Type != databricks.ClusterEventResizing {
+		t.Fatalf("Unexpected event: %+v", event)
+	}
+	if err := <-receivedErrs; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	received, receivedErrs = clusters.WatchEvents(ctx, "b")
+	if _, ok := <-received; ok {
+		t.Fatalf("Expected the events of an unexpected call to be closed")
+	}
+	if err := <-receivedErrs; !errors.Is(err, ErrUnexpectedCall) {
+		t.Fatalf("Expected an unexpected call, got %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package databricksmock
+
+import (
+	"context"
+
+	"github.com/medivo/databricks-go"
+)
+
+// Permissions is a mock of databricks.PermissionsAPI.
+type Permissions struct {
+	Mock
+}
+
+var _ databricks.PermissionsAPI = (*Permissions)(nil)
+
+// Get implements databricks.PermissionsAPI.
+func (m *Permissions) Get(ctx context.Context, objectType databricks.PermissionObjectType, objectID string) (*databricks.ObjectPermissions, error) {
+	ret, err := m.Called("Get", objectType, objectID)
+	if err != nil {
+		return nil, err
+	}
+	return value[*databricks.ObjectPermissions](ret, 0), ret.Error(1)
+}
+
+// Set implements databricks.PermissionsAPI.
+func (m *Permissions) Set(ctx context.Context, objectType databricks.PermissionObjectType, objectID string, acl []databricks.AccessControlRequest) (*databricks.ObjectPermissions, error) {
+	ret, err := m.Called("Set", objectType, objectID, acl)
+	if err != nil {
+		return nil, err
+	}
+	return value[*databricks.ObjectPermissions](ret, 0), ret.Error(1)
+}
+
+// Update implements databricks.PermissionsAPI.
+func (m *Permissions) Update(ctx context.Context, objectType databricks.PermissionObjectType, objectID string, acl []databricks.AccessControlRequest) (*databricks.ObjectPermissions, error) {
+	ret, err := m.Called("Update", objectType, objectID, acl)
+	if err != nil {
+		return nil, err
+	}
+	return value[*databricks.ObjectPermissions](ret, 0), ret.Error(1)
+}
+
+// PermissionLevels implements databricks.PermissionsAPI.
+func (m *Permissions) PermissionLevels(ctx context.Context, objectType databricks.PermissionObjectType, objectID string) ([]databricks.PermissionLevelDescription, error) {
+	ret, err := m.Called("PermissionLevels", objectType, objectID)
+	if err != nil {
+		return nil, err
+	}
+	return value[[]databricks.PermissionLevelDescription](ret, 0), ret.Error(1)
+}
@@ -0,0 +1,41 @@
+package databricksmock
+
+import (
+	"context"
+
+	"github.com/medivo/databricks-go"
+)
+
+// Profiles is a mock of databricks.ProfilesAPI.
+type Profiles struct {
+	Mock
+}
+
+var _ databricks.ProfilesAPI = (*Profiles)(nil)
+
+// Add implements databricks.ProfilesAPI.
+func (m *Profiles) Add(ctx context.Context, profileARN string, skipValidation bool) error {
+	ret, err := m.Called("Add", profileARN, skipValidation)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// List implements databricks.ProfilesAPI.
+func (m *Profiles) List(ctx context.Context) ([]string, error) {
+	ret, err := m.Called("List")
+	if err != nil {
+		return nil, err
+	}
+	return value[[]string](ret, 0), ret.Error(1)
+}
+
+// Remove implements databricks.ProfilesAPI.
+func (m *Profiles) Remove(ctx context.Context, profileARN string) error {
+	ret, err := m.Called("Remove", profileARN)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
@@ -0,0 +1,104 @@
+package databricksmock
+
+import (
+	"context"
+
+	"github.com/medivo/databricks-go"
+)
+
+// Secrets is a mock of databricks.SecretsAPI.
+type Secrets struct {
+	Mock
+}
+
+var _ databricks.SecretsAPI = (*Secrets)(nil)
+
+// CreateSecretScope implements databricks.SecretsAPI.
+func (m *Secrets) CreateSecretScope(ctx context.Context, scope string, initialManagePrincipal string) error {
+	ret, err := m.Called("CreateSecretScope", scope, initialManagePrincipal)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// DeleteSecretScope implements databricks.SecretsAPI.
+func (m *Secrets) DeleteSecretScope(ctx context.Context, scope string) error {
+	ret, err := m.Called("DeleteSecretScope", scope)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// ListSecretScopes implements databricks.SecretsAPI.
+func (m *Secrets) ListSecretScopes(ctx context.Context) ([]databricks.SecretScope, error) {
+	ret, err := m.Called("ListSecretScopes")
+	if err != nil {
+		return nil, err
+	}
+	return value[[]databricks.SecretScope](ret, 0), ret.Error(1)
+}
+
+// PutSecret implements databricks.SecretsAPI.
+func (m *Secrets) PutSecret(ctx context.Context, scope string, key string, value string) error {
+	ret, err := m.Called("PutSecret", scope, key, value)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// DeleteSecret implements databricks.SecretsAPI.
+func (m *Secrets) DeleteSecret(ctx context.Context, scope string, key string) error {
+	ret, err := m.Called("DeleteSecret", scope, key)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// ListSecrets implements databricks.SecretsAPI.
+func (m *Secrets) ListSecrets(ctx context.Context) ([]databricks.SecretMetadata, error) {
+	ret, err := m.Called("ListSecrets")
+	if err != nil {
+		return nil, err
+	}
+	return value[[]databricks.SecretMetadata](ret, 0), ret.Error(1)
+}
+
+// PutSecretACL implements databricks.SecretsAPI.
+func (m *Secrets) PutSecretACL(ctx context.Context, scope string, principal string, permission databricks.ACLPermission) error {
+	ret, err := m.Called("PutSecretACL", scope, principal, permission)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// DeleteSecretACL implements databricks.SecretsAPI.
+func (m *Secrets) DeleteSecretACL(ctx context.Context, scope string, principal string) error {
+	ret, err := m.Called("DeleteSecretACL", scope, principal)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// GetSecretACL implements databricks.SecretsAPI.
+func (m *Secrets) GetSecretACL(ctx context.Context, scope string, principal string) (databricks.ACLPermission, error) {
+	ret, err := m.Called("GetSecretACL", scope, principal)
+	if err != nil {
+		return "", err
+	}
+	return value[databricks.ACLPermission](ret, 0), ret.Error(1)
+}
+
+// ListSecretACLs implements databricks.SecretsAPI.
+func (m *Secrets) ListSecretACLs(ctx context.Context, scope string) ([]databricks.ACLItem, error) {
+	ret, err := m.Called("ListSecretACLs", scope)
+	if err != nil {
+		return nil, err
+	}
+	return value[[]databricks.ACLItem](ret, 0), ret.Error(1)
+}
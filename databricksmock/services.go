@@ -0,0 +1,94 @@
+package databricksmock
+
+import (
+	"github.com/medivo/databricks-go"
+)
+
+// Services is a mock of databricks.Services that returns a mock of every
+// service. The zero value is ready to use:
+//
+//	services := &databricksmock.Services{}
+//	services.JobsMock.On("Delete", int64(1)).Return(nil)
+//	err := deleteJobs(ctx, services)
+//	services.AssertExpectations(t)
+type Services struct {
+	ClusterMock         Cluster
+	ClusterPoliciesMock ClusterPolicies
+	DBFSMock            DBFS
+	GroupsMock          Groups
+	InstancePoolsMock   InstancePools
+	JobsMock            Jobs
+	LibrariesMock       Libraries
+	PermissionsMock     Permissions
+	ProfilesMock        Profiles
+	SecretsMock         Secrets
+	TokenMock           Token
+	WorkspaceMock       Workspace
+}
+
+var _ databricks.Services = (*Services)(nil)
+
+// Cluster implements databricks.Services.
+func (s *Services) Cluster() databricks.ClusterAPI { return &s.ClusterMock }
+
+// ClusterPolicies implements databricks.Services.
+func (s *Services) ClusterPolicies() databricks.ClusterPoliciesAPI {
+	return &s.ClusterPoliciesMock
+}
+
+// DBFS implements databricks.Services.
+func (s *Services) DBFS() databricks.DBFSAPI { return &s.DBFSMock }
+
+// Groups implements databricks.Services.
+func (s *Services) Groups() databricks.GroupsAPI { return &s.GroupsMock }
+
+// InstancePools implements databricks.Services.
+func (s *Services) InstancePools() databricks.InstancePoolsAPI {
+	return &s.InstancePoolsMock
+}
+
+// Jobs implements databricks.Services.
+func (s *Services) Jobs() databricks.JobsAPI { return &s.JobsMock }
+
+// Libraries implements databricks.Services.
+func (s *Services) Libraries() databricks.LibrariesAPI { return &s.LibrariesMock }
+
+// Permissions implements databricks.Services.
+func (s *Services) Permissions() databricks.PermissionsAPI {
+	return &s.PermissionsMock
+}
+
+// Profiles implements databricks.Services.
+func (s *Services) Profiles() databricks.ProfilesAPI { return &s.ProfilesMock }
+
+// Secrets implements databricks.Services.
+func (s *Services) Secrets() databricks.SecretsAPI { return &s.SecretsMock }
+
+// Token implements databricks.Services.
+func (s *Services) Token() databricks.TokenAPI { return &s.TokenMock }
+
+// Workspace implements databricks.Services.
+func (s *Services) Workspace() databricks.WorkspaceAPI { return &s.WorkspaceMock }
+
+// AssertExpectations asserts the expectations of every service mock.
+func (s *Services) AssertExpectations(t TestingT) bool {
+	t.Helper()
+	ok := true
+	for _, m := range []*Mock{
+		&s.ClusterMock.Mock,
+		&s.ClusterPoliciesMock.Mock,
+		&s.DBFSMock.Mock,
+		&s.GroupsMock.Mock,
+		&s.InstancePoolsMock.Mock,
+		&s.JobsMock.Mock,
+		&s.LibrariesMock.Mock,
+		&s.PermissionsMock.Mock,
+		&s.ProfilesMock.Mock,
+		&s.SecretsMock.Mock,
+		&s.TokenMock.Mock,
+		&s.WorkspaceMock.Mock,
+	} {
+		ok = m.AssertExpectations(t) && ok
+	}
+	return ok
+}
@@ -0,0 +1,41 @@
+package databricksmock
+
+import (
+	"context"
+
+	"github.com/medivo/databricks-go"
+)
+
+// Token is a mock of databricks.TokenAPI.
+type Token struct {
+	Mock
+}
+
+var _ databricks.TokenAPI = (*Token)(nil)
+
+// Create implements databricks.TokenAPI.
+func (m *Token) Create(ctx context.Context, lifetimeSec uint) (string, *databricks.PublicTokenInfo, error) {
+	ret, err := m.Called("Create", lifetimeSec)
+	if err != nil {
+		return "", nil, err
+	}
+	return value[string](ret, 0), value[*databricks.PublicTokenInfo](ret, 1), ret.Error(2)
+}
+
+// List implements databricks.TokenAPI.
+func (m *Token) List(ctx context.Context) ([]databricks.PublicTokenInfo, error) {
+	ret, err := m.Called("List")
+	if err != nil {
+		return nil, err
+	}
+	return value[[]databricks.PublicTokenInfo](ret, 0), ret.Error(1)
+}
+
+// Revoke implements databricks.TokenAPI.
+func (m *Token) Revoke(ctx context.Context, tokens []databricks.PublicTokenInfo) error {
+	ret, err := m.Called("Revoke", tokens)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
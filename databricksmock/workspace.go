@@ -0,0 +1,68 @@
+package databricksmock
+
+import (
+	"context"
+
+	"github.com/medivo/databricks-go"
+)
+
+// Workspace is a mock of databricks.WorkspaceAPI.
+type Workspace struct {
+	Mock
+}
+
+var _ databricks.WorkspaceAPI = (*Workspace)(nil)
+
+// Delete implements databricks.WorkspaceAPI.
+func (m *Workspace) Delete(ctx context.Context, path string, recursive bool) error {
+	ret, err := m.Called("Delete", path, recursive)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// Export implements databricks.WorkspaceAPI.
+func (m *Workspace) Export(ctx context.Context, path string) ([]byte, error) {
+	ret, err := m.Called("Export", path)
+	if err != nil {
+		return nil, err
+	}
+	return value[[]byte](ret, 0), ret.Error(1)
+}
+
+// GetStatus implements databricks.WorkspaceAPI.
+func (m *Workspace) GetStatus(ctx context.Context, path string) (databricks.Language, databricks.ObjectType, error) {
+	ret, err := m.Called("GetStatus", path)
+	if err != nil {
+		return "", "", err
+	}
+	return value[databricks.Language](ret, 0), value[databricks.ObjectType](ret, 1), ret.Error(2)
+}
+
+// Import implements databricks.WorkspaceAPI.
+func (m *Workspace) Import(ctx context.Context, path string, content []byte, language databricks.Language, overwrite bool, format string) error {
+	ret, err := m.Called("Import", path, content, language, overwrite, format)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
+
+// List implements databricks.WorkspaceAPI.
+func (m *Workspace) List(ctx context.Context, path string) ([]databricks.ObjectInfo, error) {
+	ret, err := m.Called("List", path)
+	if err != nil {
+		return nil, err
+	}
+	return value[[]databricks.ObjectInfo](ret, 0), ret.Error(1)
+}
+
+// Mkdirs implements databricks.WorkspaceAPI.
+func (m *Workspace) Mkdirs(ctx context.Context, path string) error {
+	ret, err := m.Called("Mkdirs", path)
+	if err != nil {
+		return err
+	}
+	return ret.Error(0)
+}
@@ -0,0 +1,203 @@
+// Package databricksotel adds OpenTelemetry tracing to a Databricks client.
+//
+// Every API call made by a service method is recorded as a client span named
+// after the method, e.g. ClusterService.Start. Spans are children of the
+// span in the context passed to the method, and the trace context is
+// propagated to the API with the configured propagator.
+package databricksotel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/medivo/databricks-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the name of the tracer.
+const instrumentationName = "github.com/medivo/databricks-go/databricksotel"
+
+// Attribute keys of the spans.
+const (
+	AttrEndpoint   = attribute.Key("databricks.endpoint")
+	AttrOperation  = attribute.Key("databricks.operation")
+	AttrErrorCode  = attribute.Key("databricks.error_code")
+	AttrRetryCount = attribute.Key("databricks.retry_count")
+	AttrThrottles  = attribute.Key("databricks.throttle_count")
+	AttrClusterID  = attribute.Key("databricks.cluster_id")
+	AttrJobID      = attribute.Key("databricks.job_id")
+	AttrRunID      = attribute.Key("databricks.run_id")
+	AttrHTTPMethod = attribute.Key("http.request.method")
+	AttrHTTPStatus = attribute.Key("http.response.status_code")
+)
+
+// Option is used for configuring the tracing Middleware.
+type Option func(*middleware)
+
+// WithTracerProvider configures the TracerProvider used to create spans. It
+// defaults to the global TracerProvider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(m *middleware) {
+		m.provider = provider
+	}
+}
+
+// WithPropagator configures the propagator used to inject the trace context
+// into requests. It defaults to the global propagator.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(m *middleware) {
+		m.propagator = propagator
+	}
+}
+
+// NewMiddleware returns a Middleware that traces every API call, for use with
+// databricks.ClientMiddleware.
+func NewMiddleware(opts ...Option) databricks.Middleware {
+	m := &middleware{}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.provider == nil {
+		m.provider = otel.GetTracerProvider()
+	}
+	if m.propagator == nil {
+		m.propagator = otel.GetTextMapPropagator()
+	}
+	m.tracer = m.provider.Tracer(instrumentationName)
+	return m
+}
+
+type middleware struct {
+	provider   trace.TracerProvider
+	propagator propagation.TextMapPropagator
+	tracer     trace.Tracer
+}
+
+// Before implements the databricks.Middleware interface.
+func (m *middleware) Before(
+	ctx context.Context,
+	call *databricks.Call,
+) context.Context {
+	name := call.Method
+	if name == "" {
+		name = call.Operation
+	}
+	attrs := []attribute.KeyValue{
+		AttrEndpoint.String(call.Endpoint),
+		AttrOperation.String(call.Operation),
+		AttrHTTPMethod.String(call.Request.Method),
+	}
+	attrs = append(attrs, idAttributes(call.Request)...)
+	ctx, _ = m.tracer.Start(
+		ctx,
+		name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	m.propagator.Inject(ctx, propagation.HeaderCarrier(call.Request.Header))
+	return ctx
+}
+
+// After implements the databricks.Middleware interface.
+func (m *middleware) After(ctx context.Context, call *databricks.Call) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	span.SetAttributes(
+		AttrRetryCount.Int(retries(call)),
+		AttrThrottles.Int(call.Throttles),
+	)
+	var apiErr *databricks.APIError
+	switch {
+	case call.Response != nil:
+		span.SetAttributes(AttrHTTPStatus.Int(call.Response.StatusCode))
+	case errors.As(call.Err, &apiErr):
+		span.SetAttributes(
+			AttrHTTPStatus.Int(apiErr.StatusCode),
+			AttrErrorCode.String(string(apiErr.ErrorCode)),
+		)
+	}
+	if call.Err != nil {
+		span.RecordError(call.Err)
+		span.SetStatus(codes.Error, call.Err.Error())
+	}
+}
+
+func retries(call *databricks.Call) int {
+	if call.Attempts <= 1 {
+		return 0
+	}
+	return call.Attempts - 1
+}
+
+// idAttributes returns the attributes of the cluster, job and run IDs of a
+// request, from its query or JSON body.
+func idAttributes(req *http.Request) []attribute.KeyValue {
+	ids := map[string]string{}
+	idFields := []string{"cluster_id", "job_id", "run_id"}
+	q := req.URL.Query()
+	for _, key := range idFields {
+		if value := q.Get(key); value != "" {
+			ids[key] = value
+		}
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			raw, err := ioutil.ReadAll(body)
+			body.Close()
+			if err == nil {
+				fields := map[string]json.RawMessage{}
+				if json.Unmarshal(raw, &fields) == nil {
+					for _, key := range idFields {
+						if value, ok := jsonScalar(fields[key]); ok {
+							ids[key] = value
+						}
+					}
+				}
+			}
+		}
+	}
+
+	var attrs []attribute.KeyValue
+	if id, ok := ids["cluster_id"]; ok {
+		attrs = append(attrs, AttrClusterID.String(id))
+	}
+	if id, ok := ids["job_id"]; ok {
+		attrs = append(attrs, int64Attribute(AttrJobID, id))
+	}
+	if id, ok := ids["run_id"]; ok {
+		attrs = append(attrs, int64Attribute(AttrRunID, id))
+	}
+	return attrs
+}
+
+// jsonScalar returns a JSON string or number as a string.
+func jsonScalar(raw json.RawMessage) (string, bool) {
+	if len(raw) == 0 {
+		return "", false
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s, s != ""
+	}
+	var n json.Number
+	if json.Unmarshal(raw, &n) == nil {
+		return n.String(), true
+	}
+	return "", false
+}
+
+func int64Attribute(key attribute.Key, value string) attribute.KeyValue {
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return key.Int64(n)
+	}
+	return key.String(value)
+}
@@ -0,0 +1,120 @@
+package databricksotel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/medivo/databricks-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func tracedClientHelper(
+	t *testing.T,
+) (*databricks.Client, *tracetest.InMemoryExporter, *sdktrace.TracerProvider) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Traceparent") == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			switch r.URL.Path {
+			case "/api/2.0/jobs/runs/get":
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error_code":"RESOURCE_DOES_NOT_EXIST","message":"Run 42 does not exist."}`)
+			default:
+				fmt.Fprint(w, `{}`)
+			}
+		},
+	))
+	t.Cleanup(server.Close)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	client, err := databricks.NewClient(
+		"",
+		databricks.ClientHost(server.URL),
+		databricks.ClientMiddleware(NewMiddleware(
+			WithTracerProvider(provider),
+			WithPropagator(propagation.TraceContext{}),
+		)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, exporter, provider
+}
+
+func attributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func Test_Middleware(t *testing.T) {
+	t.Parallel()
+	client, exporter, provider := tracedClientHelper(t)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	if err := client.Cluster().Start(ctx, "0123-456789-abc"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Jobs().RunsGet(ctx, 42); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if err := client.Libraries().Install(ctx, "0123-456789-def", nil); err != nil {
+		t.Fatal(err)
+	}
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 4 {
+		t.Fatalf("Expected 4 spans, got %d", len(spans))
+	}
+
+	start := spans[0]
+	if start.Name != "ClusterService.Start" {
+		t.Fatalf("Unexpected span name: %q", start.Name)
+	}
+	if start.SpanKind != trace.SpanKindClient {
+		t.Fatalf("Unexpected span kind: %s", start.SpanKind)
+	}
+	if start.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Fatal("Expected the span to be a child of the context's span")
+	}
+	attrs := attributes(start)
+	if attrs[AttrEndpoint].AsString() != "2.0/clusters/start" ||
+		attrs[AttrClusterID].AsString() != "0123-456789-abc" ||
+		attrs[AttrHTTPStatus].AsInt64() != 200 ||
+		attrs[AttrRetryCount].AsInt64() != 0 {
+		t.Fatalf("Unexpected attributes: %v", start.Attributes)
+	}
+
+	runsGet := spans[1]
+	if runsGet.Name != "JobsService.RunsGet" {
+		t.Fatalf("Unexpected span name: %q", runsGet.Name)
+	}
+	if runsGet.Status.Code != codes.Error {
+		t.Fatalf("Expected an error status, got %v", runsGet.Status)
+	}
+	attrs = attributes(runsGet)
+	if attrs[AttrRunID].AsInt64() != 42 ||
+		attrs[AttrHTTPStatus].AsInt64() != 404 ||
+		attrs[AttrErrorCode].AsString() != "RESOURCE_DOES_NOT_EXIST" {
+		t.Fatalf("Unexpected attributes: %v", runsGet.Attributes)
+	}
+
+	install := spans[2]
+	if attrs := attributes(install); attrs[AttrClusterID].AsString() != "0123-456789-def" {
+		t.Fatalf("Unexpected attributes: %v", install.Attributes)
+	}
+}
@@ -0,0 +1,169 @@
+// Package databricksprom exposes Prometheus metrics for the API calls of a
+// Databricks client.
+//
+// All metrics are labeled by service (e.g. ClusterService) and operation, the
+// endpoint without its API version (e.g. clusters/list or dbfs/add-block).
+package databricksprom
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/medivo/databricks-go"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// transportErrorCode is the error_code label of calls that failed without
+// an API response, e.g. because of a network error.
+const transportErrorCode = "TRANSPORT_ERROR"
+
+// Option is used for configuring the metrics Middleware.
+type Option func(*middleware)
+
+// WithNamespace configures the namespace of the metrics. It defaults to
+// databricks.
+func WithNamespace(namespace string) Option {
+	return func(m *middleware) {
+		m.namespace = namespace
+	}
+}
+
+// WithBuckets configures the buckets of the latency histogram, in seconds.
+// It defaults to prometheus.DefBuckets.
+func WithBuckets(buckets []float64) Option {
+	return func(m *middleware) {
+		m.buckets = buckets
+	}
+}
+
+type middleware struct {
+	namespace string
+	buckets   []float64
+
+	requests  *prometheus.CounterVec
+	errors    *prometheus.CounterVec
+	latency   *prometheus.HistogramVec
+	retries   *prometheus.CounterVec
+	throttles *prometheus.CounterVec
+}
+
+// NewMiddleware returns a Middleware that records metrics for every API
+// call, for use with databricks.ClientMiddleware. The metrics are registered
+// on the registerer:
+//
+//   - databricks_requests_total counts calls.
+//   - databricks_request_errors_total counts failed calls by error_code.
+//   - databricks_request_duration_seconds is the latency of calls, including
+//     retries.
+//   - databricks_retries_total counts retried attempts.
+//   - databricks_throttles_total counts attempts rejected with a 429.
+//
+// If one of the metrics fails to register, none of them stay registered.
+func NewMiddleware(
+	registerer prometheus.Registerer,
+	opts ...Option,
+) (databricks.Middleware, error) {
+	m := &middleware{
+		namespace: "databricks",
+		buckets:   prometheus.DefBuckets,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	labels := []string{"service", "operation"}
+	m.requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: m.namespace,
+		Name:      "requests_total",
+		Help:      "Number of Databricks API calls.",
+	}, labels)
+	m.errors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: m.namespace,
+		Name:      "request_errors_total",
+		Help:      "Number of failed Databricks API calls by error code.",
+	}, append(labels, "error_code"))
+	m.latency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: m.namespace,
+		Name:      "request_duration_seconds",
+		Help:      "Latency of Databricks API calls, including retries.",
+		Buckets:   m.buckets,
+	}, labels)
+	m.retries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: m.namespace,
+		Name:      "retries_total",
+		Help:      "Number of retried Databricks API call attempts.",
+	}, labels)
+	m.throttles = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: m.namespace,
+		Name:      "throttles_total",
+		Help:      "Number of Databricks API call attempts rejected with a 429.",
+	}, labels)
+
+	collectors := []prometheus.Collector{
+		m.requests, m.errors, m.latency, m.retries, m.throttles,
+	}
+	for i, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			// Unregister the metrics registered so far, so that the
+			// registration can be retried.
+			for _, registered := range collectors[:i] {
+				registerer.Unregister(registered)
+			}
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Before implements the databricks.Middleware interface.
+func (m *middleware) Before(
+	ctx context.Context,
+	call *databricks.Call,
+) context.Context {
+	return ctx
+}
+
+// After implements the databricks.Middleware interface.
+func (m *middleware) After(ctx context.Context, call *databricks.Call) {
+	service, operation := labels(call)
+	m.requests.WithLabelValues(service, operation).Inc()
+	m.latency.WithLabelValues(service, operation).Observe(call.Latency.Seconds())
+	if call.Attempts > 1 {
+		m.retries.WithLabelValues(service, operation).Add(float64(call.Attempts - 1))
+	}
+	if call.Throttles > 0 {
+		m.throttles.WithLabelValues(service, operation).Add(float64(call.Throttles))
+	}
+	if call.Err != nil {
+		m.errors.WithLabelValues(service, operation, errorCode(call.Err)).Inc()
+	}
+}
+
+// labels returns the service and operation labels of a call.
+func labels(call *databricks.Call) (string, string) {
+	service := call.Method
+	if i := strings.Index(service, "."); i >= 0 {
+		service = service[:i]
+	}
+	operation := call.Endpoint
+	if i := strings.Index(operation, "/"); i >= 0 {
+		operation = operation[i+1:]
+	}
+	return service, operation
+}
+
+// errorCode returns the error_code label of an error.
+func errorCode(err error) string {
+	var apiErr *databricks.APIError
+	if !errors.As(err, &apiErr) {
+		return transportErrorCode
+	}
+	if apiErr.ErrorCode == "" {
+		// Responses without an error code, e.g. from a proxy, are labeled
+		// with their status code.
+		return "HTTP_" + strconv.Itoa(apiErr.StatusCode)
+	}
+	return string(apiErr.ErrorCode)
+}
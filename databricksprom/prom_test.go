@@ -0,0 +1,135 @@
+package databricksprom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/medivo/databricks-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func Test_Middleware(t *testing.T) {
+	t.Parallel()
+	var throttled bool
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/2.0/clusters/list":
+				if !throttled {
+					throttled = true
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				fmt.Fprint(w, `{"clusters":[]}`)
+			case "/api/2.0/clusters/get":
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error_code":"INVALID_PARAMETER_VALUE","message":"Cluster abc does not exist"}`)
+			default:
+				fmt.Fprint(w, `{}`)
+			}
+		},
+	))
+	t.Cleanup(server.Close)
+
+	registry := prometheus.NewRegistry()
+	middleware, err := NewMiddleware(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := databricks.NewClient(
+		"",
+		databricks.ClientHost(server.URL),
+		databricks.ClientRetryPolicy(databricks.RetryPolicy{
+			MaxAttempts: 2,
+			MinBackoff:  time.Millisecond,
+			MaxBackoff:  time.Millisecond,
+		}),
+		databricks.ClientMiddleware(middleware),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.Cluster().List(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Cluster().Get(ctx, "abc"); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if err := client.DBFS().AddBlock(ctx, 1, []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `
+# HELP databricks_request_errors_total Number of failed Databricks API calls by error code.
+# TYPE databricks_request_errors_total counter
+databricks_request_errors_total{error_code="INVALID_PARAMETER_VALUE",operation="clusters/get",service="ClusterService"} 1
+# HELP databricks_requests_total Number of Databricks API calls.
+# TYPE databricks_requests_total counter
+databricks_requests_total{operation="clusters/get",service="ClusterService"} 1
+databricks_requests_total{operation="clusters/list",service="ClusterService"} 1
+databricks_requests_total{operation="dbfs/add-block",service="DBFSService"} 1
+# HELP databricks_retries_total Number of retried Databricks API call attempts.
+# TYPE databricks_retries_total counter
+databricks_retries_total{operation="clusters/list",service="ClusterService"} 1
+# HELP databricks_throttles_total Number of Databricks API call attempts rejected with a 429.
+# TYPE databricks_throttles_total counter
+databricks_throttles_total{operation="clusters/list",service="ClusterService"} 1
+`
+	if err := testutil.GatherAndCompare(
+		registry,
+		strings.NewReader(expected),
+		"databricks_requests_total",
+		"databricks_request_errors_total",
+		"databricks_retries_total",
+		"databricks_throttles_total",
+	); err != nil {
+		t.Fatal(err)
+	}
+	if count := testutil.CollectAndCount(
+		registry, "databricks_request_duration_seconds",
+	); count != 3 {
+		t.Fatalf("Expected 3 latency histograms, got %d", count)
+	}
+
+	// Registering twice on the same registry fails.
+	if _, err := NewMiddleware(registry); err == nil {
+		t.Fatal("Expected a registration error")
+	}
+}
+
+// failingRegisterer fails the registration of the collector at index fail.
+type failingRegisterer struct {
+	prometheus.Registerer
+	calls int
+	fail  int
+}
+
+func (r *failingRegisterer) Register(collector prometheus.Collector) error {
+	r.calls++
+	if r.calls-1 == r.fail {
+		return fmt.Errorf("Failed to register collector %d", r.fail)
+	}
+	return r.Registerer.Register(collector)
+}
+
+func Test_NewMiddleware_RegisterError(t *testing.T) {
+	t.Parallel()
+	registerer := &failingRegisterer{Registerer: prometheus.NewRegistry(), fail: 3}
+	if _, err := NewMiddleware(registerer); err == nil {
+		t.Fatal("Expected a registration error")
+	}
+
+	// The metrics registered before the failure are unregistered, so a
+	// retry succeeds.
+	if _, err := NewMiddleware(registerer); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,432 @@
+package databrickstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scrubbed is the value that secrets are replaced with in a cassette.
+const Scrubbed = "REDACTED"
+
+// scrubbedFields are the JSON fields and form parameters that carry
+// credentials or secret values. Fields are matched with fieldKey, so both
+// token_value and TokenValue are scrubbed.
+var scrubbedFields = map[string]bool{
+	"tokenvalue":   true,
+	"stringvalue":  true,
+	"bytesvalue":   true,
+	"accesstoken":  true,
+	"refreshtoken": true,
+	"idtoken":      true,
+	"clientsecret": true,
+	"codeverifier": true,
+	"password":     true,
+}
+
+// unrecordedHeaders are response headers that are not recorded, as they
+// carry credentials or change on every request.
+var unrecordedHeaders = map[string]bool{
+	"Set-Cookie": true,
+	"Date":       true,
+}
+
+// Cassette is a recording of HTTP interactions with a workspace.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions" yaml:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request" yaml:"request"`
+	Response RecordedResponse `json:"response" yaml:"response"`
+}
+
+// RecordedRequest is a recorded request. Its body is normalized, so JSON
+// bodies that only differ in field order or whitespace are equal.
+type RecordedRequest struct {
+	Method string `json:"method" yaml:"method"`
+	Path   string `json:"path" yaml:"path"`
+	Query  string `json:"query,omitempty" yaml:"query,omitempty"`
+	Body   string `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded response.
+type RecordedResponse struct {
+	StatusCode int                 `json:"status_code" yaml:"status_code"`
+	Header     map[string][]string `json:"header,omitempty" yaml:"header,omitempty"`
+	Body       string              `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+// LoadCassette reads a cassette from a file. Files with a .yaml or .yml
+// extension are read as YAML, others as JSON.
+func LoadCassette(path string) (*Cassette, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cassette Cassette
+	if isYAML(path) {
+		err = yaml.Unmarshal(raw, &cassette)
+	} else {
+		err = json.Unmarshal(raw, &cassette)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Invalid cassette %s: %w", path, err)
+	}
+	return &cassette, nil
+}
+
+// Save writes the cassette to a file, in YAML for files with a .yaml or .yml
+// extension and in JSON otherwise.
+func (c *Cassette) Save(path string) error {
+	var raw []byte
+	var err error
+	if isYAML(path) {
+		raw, err = yaml.Marshal(c)
+	} else {
+		raw, err = json.MarshalIndent(c, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, raw, 0644)
+}
+
+func isYAML(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// Mode is the mode of a Recorder.
+type Mode int
+
+const (
+	// ModeReplay serves requests from the cassette. Requests that are not
+	// in the cassette are sent to the transport and added to it, unless
+	// the Recorder is strict.
+	ModeReplay Mode = iota
+	// ModeRecord sends every request to the transport and records a new
+	// cassette, replacing an existing one.
+	ModeRecord
+)
+
+// RecorderOption is used for configuring a Recorder.
+type RecorderOption func(*Recorder)
+
+// WithTransport configures the transport that a Recorder sends requests
+// to when recording. It defaults to http.DefaultTransport.
+func WithTransport(transport http.RoundTripper) RecorderOption {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithStrict makes a replaying Recorder fail requests that are not in the
+// cassette, instead of sending them to the transport. This is what CI
+// without network access wants.
+func WithStrict() RecorderOption {
+	return func(r *Recorder) {
+		r.strict = true
+	}
+}
+
+// WithScrubbedFields configures additional JSON fields and form parameters
+// whose values are replaced with Scrubbed in the cassette.
+func WithScrubbedFields(fields ...string) RecorderOption {
+	return func(r *Recorder) {
+		for _, field := range fields {
+			r.scrubbed[fieldKey(field)] = true
+		}
+	}
+}
+
+// Recorder is an http.RoundTripper that records interactions with a
+// workspace to a cassette file, or replays them from it. It is used with
+// databricks.ClientHTTPClient:
+//
+//	recorder, err := databrickstest.NewRecorder(
+//		"testdata/jobs.yaml", databrickstest.ModeReplay, databrickstest.WithStrict())
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer recorder.Stop()
+//	client, err := databricks.NewClient("",
+//		databricks.ClientHost(host),
+//		databricks.ClientHTTPClient(recorder.HTTPClient()),
+//	)
+//
+// Requests are matched on their method, path, query and normalized body.
+// Each recorded interaction is replayed once, in order, so polling the same
+// endpoint replays the recorded sequence of responses. Authorization
+// headers are never recorded, and secret values such as token_value and
+// string_value are scrubbed from the bodies of requests and responses.
+type Recorder struct {
+	path      string
+	mode      Mode
+	strict    bool
+	transport http.RoundTripper
+	scrubbed  map[string]bool
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+	changed  bool
+}
+
+// NewRecorder returns a Recorder for the cassette at the given path. In
+// ModeReplay the cassette must exist.
+func NewRecorder(
+	path string,
+	mode Mode,
+	opts ...RecorderOption,
+) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		scrubbed:  map[string]bool{},
+		cassette:  &Cassette{},
+	}
+	for field := range scrubbedFields {
+		r.scrubbed[field] = true
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	switch mode {
+	case ModeReplay:
+		cassette, err := LoadCassette(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = cassette
+		r.used = make([]bool, len(cassette.Interactions))
+	case ModeRecord:
+		r.changed = true
+	default:
+		return nil, fmt.Errorf("Unknown recorder mode: %d", mode)
+	}
+	return r, nil
+}
+
+// HTTPClient returns an http.Client that uses the Recorder as its
+// transport.
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Stop saves the cassette if interactions were recorded.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.changed {
+		return nil
+	}
+	r.changed = false
+	return r.cassette.Save(r.path)
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	recorded := r.recordRequest(req, body)
+
+	if r.mode == ModeReplay {
+		if res, ok := r.replay(req, recorded); ok {
+			return res, nil
+		}
+		if r.strict {
+			return nil, fmt.Errorf(
+				"No recorded interaction for %s %s in %s",
+				req.Method, req.URL.RequestURI(), r.path)
+		}
+	}
+
+	// A RoundTripper must not modify the request it was given.
+	out := req.Clone(req.Context())
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	res, err := r.transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(raw))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  recorded,
+		Response: r.recordResponse(res, raw),
+	})
+	r.used = append(r.used, true)
+	r.changed = true
+	return res, nil
+}
+
+// replay returns the response of the first unused interaction that matches
+// the request.
+func (r *Recorder) replay(
+	req *http.Request,
+	recorded RecordedRequest,
+) (*http.Response, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || interaction.Request != recorded {
+			continue
+		}
+		r.used[i] = true
+		header := http.Header{}
+		for name, values := range interaction.Response.Header {
+			header[http.CanonicalHeaderKey(name)] = values
+		}
+		return &http.Response{
+			Status: fmt.Sprintf("%d %s",
+				interaction.Response.StatusCode,
+				http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, true
+	}
+	return nil, false
+}
+
+func (r *Recorder) recordRequest(req *http.Request, body []byte) RecordedRequest {
+	query := req.URL.Query()
+	r.scrubForm(query)
+	return RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  query.Encode(),
+		Body: r.scrubBody(
+			req.Header.Get("Content-Type"), body),
+	}
+}
+
+func (r *Recorder) recordResponse(res *http.Response, body []byte) RecordedResponse {
+	header := map[string][]string{}
+	for name, values := range res.Header {
+		if !unrecordedHeaders[http.CanonicalHeaderKey(name)] {
+			header[name] = values
+		}
+	}
+	return RecordedResponse{
+		StatusCode: res.StatusCode,
+		Header:     header,
+		Body:       r.scrubBody(res.Header.Get("Content-Type"), body),
+	}
+}
+
+// scrubBody returns a normalized body with its secrets scrubbed. JSON is
+// re-encoded with sorted keys and form bodies with sorted parameters.
+func (r *Recorder) scrubBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if normalized, ok := r.normalizeJSON(body); ok {
+		return normalized
+	}
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			r.scrubForm(form)
+			return form.Encode()
+		}
+	}
+	return string(body)
+}
+
+// normalizeJSON re-encodes a JSON body with sorted keys and its secrets
+// scrubbed. Numbers are kept as sent, so that IDs above 2^53 are not
+// rounded, and <, > and & are not escaped.
+func (r *Recorder) normalizeJSON(body []byte) (string, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return "", false
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return "", false
+	}
+	var normalized bytes.Buffer
+	encoder := json.NewEncoder(&normalized)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(r.scrubJSON("", value)); err != nil {
+		return "", false
+	}
+	return strings.TrimSuffix(normalized.String(), "\n"), true
+}
+
+// scrubJSON returns a decoded JSON value with the values of secret fields
+// replaced.
+func (r *Recorder) scrubJSON(key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		scrubbed := make(map[string]interface{}, len(v))
+		for k, field := range v {
+			scrubbed[k] = r.scrubJSON(k, field)
+		}
+		return scrubbed
+	case []interface{}:
+		scrubbed := make([]interface{}, len(v))
+		for i, item := range v {
+			scrubbed[i] = r.scrubJSON(key, item)
+		}
+		return scrubbed
+	case string:
+		if r.scrubbed[fieldKey(key)] {
+			return Scrubbed
+		}
+	}
+	return value
+}
+
+func (r *Recorder) scrubForm(form url.Values) {
+	for key, values := range form {
+		if r.scrubbed[fieldKey(key)] {
+			for i := range values {
+				values[i] = Scrubbed
+			}
+		}
+	}
+}
+
+// fieldKey normalizes a JSON field name for matching.
+func fieldKey(key string) string {
+	return strings.ToLower(strings.Replace(key, "_", "", -1))
+}
@@ -0,0 +1,167 @@
+package databrickstest
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/medivo/databricks-go"
+)
+
+// recordedClient returns a client using a Recorder for the cassette.
+func recordedClient(
+	t *testing.T,
+	host, path string,
+	mode Mode,
+	opts ...RecorderOption,
+) (*Recorder, *databricks.Client) {
+	t.Helper()
+	recorder, err := NewRecorder(path, mode, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := databricks.NewClient("",
+		databricks.ClientHost(host),
+		databricks.ClientHTTPClient(recorder.HTTPClient()),
+		databricks.ClientCredentials(databricks.BearerCredentials("dapi-recorded")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return recorder, client
+}
+
+func Test_Recorder_RecordReplay(t *testing.T) {
+	t.Parallel()
+	for _, name := range []string{"secrets.json", "secrets.yaml"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			server := NewServer()
+			host := server.URL
+			path := filepath.Join(t.TempDir(), name)
+			ctx := context.Background()
+
+			recorder, client := recordedClient(t, host, path, ModeRecord)
+			if err := client.Secrets().CreateSecretScope(ctx, "etl", ""); err != nil {
+				t.Fatal(err)
+			}
+			if err := client.Secrets().PutSecret(ctx, "etl", "password", "hunter2"); err != nil {
+				t.Fatal(err)
+			}
+			token, _, err := client.Token().Create(ctx, 60)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = client.Secrets().CreateSecretScope(ctx, "etl", "")
+			expectCode(t, err, databricks.CodeResourceAlreadyExists)
+			if err := recorder.Stop(); err != nil {
+				t.Fatal(err)
+			}
+			server.Close()
+
+			raw, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, secret := range []string{"hunter2", token, "dapi-recorded"} {
+				if strings.Contains(string(raw), secret) {
+					t.Fatalf("Expected %q to be scrubbed from the cassette", secret)
+				}
+			}
+
+			// The server is closed, so every request is replayed.
+			recorder, client = recordedClient(t, host, path, ModeReplay, WithStrict())
+			if err := client.Secrets().CreateSecretScope(ctx, "etl", ""); err != nil {
+				t.Fatal(err)
+			}
+			if err := client.Secrets().PutSecret(ctx, "etl", "password", "other"); err != nil {
+				t.Fatal(err)
+			}
+			token, _, err = client.Token().Create(ctx, 60)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if token != Scrubbed {
+				t.Fatalf("Expected the token to be scrubbed, got %q", token)
+			}
+			err = client.Secrets().CreateSecretScope(ctx, "etl", "")
+			expectCode(t, err, databricks.CodeResourceAlreadyExists)
+
+			err = client.Secrets().CreateSecretScope(ctx, "etl", "")
+			if err == nil || !strings.Contains(err.Error(), "No recorded interaction") {
+				t.Fatalf("Expected an unmatched request error, got %v", err)
+			}
+			if err := recorder.Stop(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func Test_Recorder_Matching(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	cassette := &Cassette{Interactions: []Interaction{{
+		Request: RecordedRequest{
+			Method: http.MethodPost,
+			Path:   "/api/2.0/dbfs/mkdirs",
+			Body:   `{"path":"/tmp"}`,
+		},
+		Response: RecordedResponse{StatusCode: http.StatusOK, Body: "{}"},
+	}, {
+		Request: RecordedRequest{
+			Method: http.MethodGet,
+			Path:   "/api/2.0/dbfs/get-status",
+			Query:  "path=%2Ftmp",
+		},
+		Response: RecordedResponse{
+			StatusCode: http.StatusOK,
+			Body:       `{"path":"/tmp","is_dir":true}`,
+		},
+	}}}
+	if err := cassette.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	_, client := recordedClient(
+		t, "https://example.cloud.databricks.com", path, ModeReplay, WithStrict())
+	ctx := context.Background()
+	if err := client.DBFS().Mkdirs(ctx, "/tmp"); err != nil {
+		t.Fatal(err)
+	}
+	isDir, _, err := client.DBFS().GetStatus(ctx, "/tmp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isDir {
+		t.Fatalf("Expected /tmp to be a directory")
+	}
+	if _, _, err := client.DBFS().GetStatus(ctx, "/other"); err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+}
+
+func Test_Recorder_scrubBody(t *testing.T) {
+	t.Parallel()
+	recorder, err := NewRecorder(
+		filepath.Join(t.TempDir(), "cassette.json"), ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]string{
+		// IDs above 2^53 must not be rounded.
+		`{"run_id": 9007199254740993, "job_id": 1}`: `{"job_id":1,"run_id":9007199254740993}`,
+		`{"notebook_path": "/a<b>&c"}`:              `{"notebook_path":"/a<b>&c"}`,
+		`{"token_value": "dapi123"}`:                `{"token_value":"REDACTED"}`,
+		`{"path": "/tmp"} {"path": "/other"}`:       `{"path": "/tmp"} {"path": "/other"}`,
+	}
+	for body, expected := range tests {
+		if scrubbed := recorder.scrubBody("application/json", []byte(body)); scrubbed != expected {
+			t.Errorf("scrubBody(%s) = %s, want %s", body, scrubbed, expected)
+		}
+	}
+}
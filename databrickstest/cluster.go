@@ -0,0 +1,574 @@
+package databrickstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/medivo/databricks-go"
+)
+
+// Limits of the cluster events API.
+const (
+	defaultEventsLimit = 50
+	maxEventsLimit     = 500
+)
+
+// Simulated node resources, reported for clusters of any node type.
+const (
+	nodeMemoryMB = 15616
+	nodeCores    = 4
+)
+
+type clusterEvent struct {
+	ClusterID string                      `json:"cluster_id"`
+	Timestamp int64                       `json:"timestamp"`
+	Type      databricks.ClusterEventType `json:"type"`
+	Details   map[string]interface{}      `json:"details"`
+}
+
+type cluster struct {
+	id    string
+	spec  map[string]interface{}
+	state databricks.ClusterState
+	since time.Time
+
+	message           string
+	creator           string
+	pinned            bool
+	started           int64
+	terminated        int64
+	lastActivity      int64
+	sparkContextID    int64
+	terminationReason map[string]interface{}
+	events            []clusterEvent
+}
+
+// transient returns whether the cluster moves to another state on its own.
+func (c *cluster) transient() bool {
+	switch c.state {
+	case databricks.Pending,
+		databricks.Restarting,
+		databricks.Resizing,
+		databricks.Terminating:
+		return true
+	}
+	return false
+}
+
+// workers returns the current number of workers of the cluster.
+func (c *cluster) workers() int64 {
+	if c.state != databricks.Running && c.state != databricks.Resizing {
+		return 0
+	}
+	if autoscale, ok := c.spec["autoscale"].(map[string]interface{}); ok {
+		return jsonInt64(autoscale["min_workers"])
+	}
+	return jsonInt64(c.spec["num_workers"])
+}
+
+func (s *Server) clusterRoutes() map[string]route {
+	return map[string]route{
+		"clusters/create":           {http.MethodPost, s.clusterCreate},
+		"clusters/delete":           {http.MethodPost, s.clusterDelete},
+		"clusters/edit":             {http.MethodPost, s.clusterEdit},
+		"clusters/events":           {http.MethodPost, s.clusterEvents},
+		"clusters/get":              {http.MethodGet, s.clusterGet},
+		"clusters/list":             {http.MethodGet, s.clusterList},
+		"clusters/list-node-types":  {http.MethodGet, s.clusterNodeTypes},
+		"clusters/list-zones":       {http.MethodGet, s.clusterZones},
+		"clusters/permanent-delete": {http.MethodPost, s.clusterPermanentDelete},
+		"clusters/pin":              {http.MethodPost, s.clusterPin},
+		"clusters/resize":           {http.MethodPost, s.clusterResize},
+		"clusters/restart":          {http.MethodPost, s.clusterRestart},
+		"clusters/spark-versions":   {http.MethodGet, s.clusterSparkVersions},
+		"clusters/start":            {http.MethodPost, s.clusterStart},
+		"clusters/unpin":            {http.MethodPost, s.clusterUnpin},
+	}
+}
+
+// setClusterState moves a cluster to a state and records the event of
+// entering it, if any.
+func (s *Server) setClusterState(
+	c *cluster,
+	state databricks.ClusterState,
+	message string,
+	event databricks.ClusterEventType,
+	details map[string]interface{},
+) {
+	c.state = state
+	c.message = message
+	c.since = s.now()
+	if event != "" {
+		s.clusterEvent(c, event, details)
+	}
+}
+
+func (s *Server) clusterEvent(
+	c *cluster,
+	event databricks.ClusterEventType,
+	details map[string]interface{},
+) {
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	c.events = append(c.events, clusterEvent{
+		ClusterID: c.id,
+		Timestamp: s.millis(),
+		Type:      event,
+		Details:   details,
+	})
+}
+
+// stepCluster moves a cluster out of its transient state.
+func (s *Server) stepCluster(c *cluster) {
+	switch c.state {
+	case databricks.Pending:
+		c.sparkContextID = s.id()
+		c.lastActivity = s.millis()
+		s.setClusterState(c, databricks.Running, "", databricks.ClusterEventRunning, map[string]interface{}{
+			"current_num_workers": c.workers(),
+			"target_num_workers":  c.workers(),
+		})
+	case databricks.Restarting:
+		c.sparkContextID = s.id()
+		s.setClusterState(c, databricks.Running, "", databricks.ClusterEventRunning, map[string]interface{}{
+			"current_num_workers": c.workers(),
+			"target_num_workers":  c.workers(),
+		})
+	case databricks.Resizing:
+		s.setClusterState(c, databricks.Running, "", databricks.ClusterEventUpsizeCompleted, map[string]interface{}{
+			"current_num_workers": c.workers(),
+			"target_num_workers":  c.workers(),
+		})
+	case databricks.Terminating:
+		c.terminated = s.millis()
+		s.setClusterState(c, databricks.Terminated, c.message, "", nil)
+	}
+}
+
+// terminateCluster starts terminating a cluster for the given reason.
+func (s *Server) terminateCluster(
+	c *cluster,
+	code databricks.TerminationCode,
+	message string,
+) {
+	c.terminationReason = map[string]interface{}{
+		"code":       code,
+		"parameters": map[string]string{"username": s.userName},
+	}
+	s.setClusterState(c, databricks.Terminating, message, databricks.ClusterEventTerminating,
+		map[string]interface{}{"reason": c.terminationReason})
+}
+
+// cluster returns the cluster with the given ID.
+func (s *Server) cluster(id string) (*cluster, error) {
+	if id == "" {
+		return nil, missingField("cluster_id")
+	}
+	c, ok := s.clusters[id]
+	if !ok {
+		return nil, invalidParameter("Cluster %s does not exist", id)
+	}
+	return c, nil
+}
+
+// clusterByID decodes the cluster ID of a request and returns the cluster.
+func (s *Server) clusterByID(r *http.Request) (*cluster, error) {
+	req := struct {
+		ClusterID string `json:"cluster_id"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	return s.cluster(req.ClusterID)
+}
+
+// clusterSpec decodes and validates the cluster spec of a create or edit
+// request, after applying its policy. It returns the spec without the
+// cluster ID, and the ID.
+func (s *Server) clusterSpec(r *http.Request) (map[string]interface{}, string, error) {
+	var spec map[string]interface{}
+	if err := decode(r, &spec); err != nil {
+		return nil, "", err
+	}
+	spec = compact(spec)
+	id, _ := spec["cluster_id"].(string)
+	delete(spec, "cluster_id")
+	if err := s.applyPolicy(spec); err != nil {
+		return nil, "", err
+	}
+	if spec["spark_version"] == nil || spec["spark_version"] == "" {
+		return nil, "", missingField("spark_version")
+	}
+	if (spec["node_type_id"] == nil || spec["node_type_id"] == "") &&
+		spec["instance_pool_id"] == nil {
+		return nil, "", missingField("node_type_id")
+	}
+	if spec["num_workers"] != nil && spec["autoscale"] != nil {
+		return nil, "", invalidParameter(
+			"Only one of num_workers and autoscale can be set.")
+	}
+	return spec, id, nil
+}
+
+func (s *Server) clusterInfo(c *cluster) map[string]interface{} {
+	info := map[string]interface{}{}
+	for key, value := range c.spec {
+		info[key] = value
+	}
+	workers := c.workers()
+	name, _ := c.spec["cluster_name"].(string)
+	info["cluster_id"] = c.id
+	info["creator_user_name"] = c.creator
+	info["state"] = c.state
+	info["state_message"] = c.message
+	info["start_time"] = c.started
+	info["cluster_source"] = databricks.API
+	info["spark_context_id"] = c.sparkContextID
+	info["jdbc_port"] = 10000
+	info["default_tags"] = map[string]string{
+		"Vendor":      "Databricks",
+		"Creator":     c.creator,
+		"ClusterName": name,
+		"ClusterId":   c.id,
+	}
+	if c.lastActivity > 0 {
+		info["last_activity_time"] = c.lastActivity
+	}
+	if c.terminated > 0 {
+		info["terminated_time"] = c.terminated
+	}
+	if c.terminationReason != nil {
+		info["termination_reason"] = c.terminationReason
+	}
+	if c.state == databricks.Running || c.state == databricks.Resizing {
+		info["driver"] = s.sparkNode(c, 0)
+		executors := []map[string]interface{}{}
+		for i := int64(1); i <= workers; i++ {
+			executors = append(executors, s.sparkNode(c, i))
+		}
+		info["executors"] = executors
+		info["cluster_memory_mb"] = (workers + 1) * nodeMemoryMB
+		info["cluster_cores"] = (workers + 1) * nodeCores
+	}
+	return info
+}
+
+func (s *Server) sparkNode(c *cluster, i int64) map[string]interface{} {
+	return map[string]interface{}{
+		"private_ip":      fmt.Sprintf("10.0.0.%d", i+1),
+		"node_id":         fmt.Sprintf("%s-node-%d", c.id, i),
+		"instance_id":     fmt.Sprintf("i-%s%d", c.id, i),
+		"start_timestamp": c.started,
+		"host_private_ip": fmt.Sprintf("10.0.1.%d", i+1),
+	}
+}
+
+func (s *Server) clusterCreate(r *http.Request) (interface{}, error) {
+	spec, _, err := s.clusterSpec(r)
+	if err != nil {
+		return nil, err
+	}
+	c := &cluster{
+		id:      fmt.Sprintf("%s-fake%d", s.now().UTC().Format("0102-150405"), s.id()),
+		spec:    spec,
+		creator: s.userName,
+		started: s.millis(),
+	}
+	s.clusters[c.id] = c
+	s.clusterEvent(c, databricks.ClusterEventCreating, map[string]interface{}{"user": s.userName})
+	s.setClusterState(c, databricks.Pending, "Starting Spark", "", nil)
+	return map[string]string{"cluster_id": c.id}, nil
+}
+
+func (s *Server) clusterEdit(r *http.Request) (interface{}, error) {
+	spec, id, err := s.clusterSpec(r)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.cluster(id)
+	if err != nil {
+		return nil, err
+	}
+	switch c.state {
+	case databricks.Running, databricks.Terminated:
+	default:
+		return nil, invalidState(
+			"Cluster %s is in unexpected state %s.", c.id, c.state)
+	}
+	previous := c.spec
+	c.spec = spec
+	s.clusterEvent(c, databricks.ClusterEventEdited, map[string]interface{}{
+		"previous_attributes": previous,
+		"attributes":          spec,
+		"user":                s.userName,
+	})
+	if c.state == databricks.Running {
+		s.setClusterState(c, databricks.Restarting, "Restarting Spark",
+			databricks.ClusterEventRestarting, map[string]interface{}{"user": s.userName})
+	}
+	return nil, nil
+}
+
+func (s *Server) clusterStart(r *http.Request) (interface{}, error) {
+	c, err := s.clusterByID(r)
+	if err != nil {
+		return nil, err
+	}
+	if c.state != databricks.Terminated {
+		return nil, invalidState(
+			"Cluster %s is in unexpected state %s.", c.id, c.state)
+	}
+	c.started = s.millis()
+	c.terminated = 0
+	c.terminationReason = nil
+	s.setClusterState(c, databricks.Pending, "Starting Spark", databricks.ClusterEventStarting,
+		map[string]interface{}{"user": s.userName})
+	return nil, nil
+}
+
+func (s *Server) clusterRestart(r *http.Request) (interface{}, error) {
+	c, err := s.clusterByID(r)
+	if err != nil {
+		return nil, err
+	}
+	if c.state != databricks.Running {
+		return nil, invalidState(
+			"Cluster %s is in unexpected state %s.", c.id, c.state)
+	}
+	s.setClusterState(c, databricks.Restarting, "Restarting Spark",
+		databricks.ClusterEventRestarting, map[string]interface{}{"user": s.userName})
+	return nil, nil
+}
+
+func (s *Server) clusterResize(r *http.Request) (interface{}, error) {
+	req := struct {
+		ClusterID  string      `json:"cluster_id"`
+		NumWorkers *int32      `json:"num_workers"`
+		Autoscale  interface{} `json:"autoscale"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	c, err := s.cluster(req.ClusterID)
+	if err != nil {
+		return nil, err
+	}
+	if (req.NumWorkers == nil) == (req.Autoscale == nil) {
+		return nil, invalidParameter(
+			"Exactly one of num_workers and autoscale must be set.")
+	}
+	if c.state != databricks.Running {
+		return nil, invalidState(
+			"Cluster %s is in unexpected state %s.", c.id, c.state)
+	}
+	current := c.workers()
+	if req.NumWorkers != nil {
+		if *req.NumWorkers < 0 {
+			return nil, invalidParameter(
+				"The number of workers (%d) must not be negative.",
+				*req.NumWorkers,
+			)
+		}
+		delete(c.spec, "autoscale")
+		c.spec["num_workers"] = json.Number(fmt.Sprint(*req.NumWorkers))
+	} else {
+		delete(c.spec, "num_workers")
+		c.spec["autoscale"] = req.Autoscale
+	}
+	s.setClusterState(c, databricks.Resizing, "Resizing cluster", databricks.ClusterEventResizing,
+		map[string]interface{}{
+			"current_num_workers": current,
+			"target_num_workers":  c.workers(),
+			"user":                s.userName,
+		})
+	return nil, nil
+}
+
+func (s *Server) clusterDelete(r *http.Request) (interface{}, error) {
+	c, err := s.clusterByID(r)
+	if err != nil {
+		return nil, err
+	}
+	if c.state == databricks.Terminating || c.state == databricks.Terminated {
+		return nil, nil
+	}
+	s.terminateCluster(c, databricks.TerminationUserRequest, "Terminated by user")
+	return nil, nil
+}
+
+func (s *Server) clusterPermanentDelete(r *http.Request) (interface{}, error) {
+	c, err := s.clusterByID(r)
+	if err != nil {
+		return nil, err
+	}
+	delete(s.clusters, c.id)
+	return nil, nil
+}
+
+func (s *Server) clusterPin(r *http.Request) (interface{}, error) {
+	c, err := s.clusterByID(r)
+	if err != nil {
+		return nil, err
+	}
+	if !c.pinned {
+		c.pinned = true
+		s.clusterEvent(c, databricks.ClusterEventPinned, map[string]interface{}{"user": s.userName})
+	}
+	return nil, nil
+}
+
+func (s *Server) clusterUnpin(r *http.Request) (interface{}, error) {
+	c, err := s.clusterByID(r)
+	if err != nil {
+		return nil, err
+	}
+	if c.pinned {
+		c.pinned = false
+		s.clusterEvent(c, databricks.ClusterEventUnpinned, map[string]interface{}{"user": s.userName})
+	}
+	return nil, nil
+}
+
+func (s *Server) clusterGet(r *http.Request) (interface{}, error) {
+	c, err := s.cluster(r.URL.Query().Get("cluster_id"))
+	if err != nil {
+		return nil, err
+	}
+	return s.clusterInfo(c), nil
+}
+
+func (s *Server) clusterList(r *http.Request) (interface{}, error) {
+	ids := make([]string, 0, len(s.clusters))
+	for id := range s.clusters {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	clusters := []map[string]interface{}{}
+	for _, id := range ids {
+		clusters = append(clusters, s.clusterInfo(s.clusters[id]))
+	}
+	return map[string]interface{}{"clusters": clusters}, nil
+}
+
+func (s *Server) clusterEvents(r *http.Request) (interface{}, error) {
+	req := struct {
+		ClusterID  string                        `json:"cluster_id"`
+		StartTime  *int64                        `json:"start_time"`
+		EndTime    *int64                        `json:"end_time"`
+		Order      *string                       `json:"order"`
+		EventTypes []databricks.ClusterEventType `json:"event_types"`
+		Offset     int64                         `json:"offset"`
+		Limit      int64                         `json:"limit"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	c, err := s.cluster(req.ClusterID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultEventsLimit
+	}
+	if req.Limit < 0 || req.Limit > maxEventsLimit || req.Offset < 0 {
+		return nil, invalidParameter(
+			"The limit (%d) must be between 1 and %d, and the offset (%d) "+
+				"must not be negative.", req.Limit, maxEventsLimit, req.Offset)
+	}
+	types := map[databricks.ClusterEventType]bool{}
+	for _, t := range req.EventTypes {
+		types[t] = true
+	}
+	events := []clusterEvent{}
+	for _, e := range c.events {
+		if req.StartTime != nil && e.Timestamp < *req.StartTime ||
+			req.EndTime != nil && e.Timestamp > *req.EndTime ||
+			len(types) > 0 && !types[e.Type] {
+			continue
+		}
+		events = append(events, e)
+	}
+	if req.Order == nil || *req.Order != "ASC" {
+		for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+			events[i], events[j] = events[j], events[i]
+		}
+	}
+	total := int64(len(events))
+	res := map[string]interface{}{"total_count": total}
+	start, end := req.Offset, req.Offset+req.Limit
+	if start > total {
+		start = total
+	}
+	if end < total {
+		next := req
+		next.Offset = end
+		res["next_page"] = next
+	} else {
+		end = total
+	}
+	res["events"] = events[start:end]
+	return res, nil
+}
+
+func (s *Server) clusterNodeTypes(r *http.Request) (interface{}, error) {
+	return map[string][]databricks.NodeType{
+		"node_types": {
+			{
+				NodeTypeID:     "i3.xlarge",
+				MemoryMB:       31232,
+				NumCores:       4,
+				Description:    "i3.xlarge",
+				InstanceTypeID: "i3.xlarge",
+			},
+			{
+				NodeTypeID:     "m5d.large",
+				MemoryMB:       nodeMemoryMB,
+				NumCores:       2,
+				Description:    "m5d.large",
+				InstanceTypeID: "m5d.large",
+			},
+		},
+	}, nil
+}
+
+func (s *Server) clusterZones(r *http.Request) (interface{}, error) {
+	return databricks.ClusterZoneResponse{
+		Zones:       []string{"us-west-2a", "us-west-2b", "us-west-2c"},
+		DefaultZone: "us-west-2a",
+	}, nil
+}
+
+func (s *Server) clusterSparkVersions(r *http.Request) (interface{}, error) {
+	return map[string][]databricks.SparkVersion{
+		"versions": {
+			{Key: "7.3.x-scala2.12", Name: "7.3 LTS (includes Apache Spark 3.0.1, Scala 2.12)"},
+			{Key: "9.1.x-scala2.12", Name: "9.1 LTS (includes Apache Spark 3.1.2, Scala 2.12)"},
+			{Key: "10.4.x-scala2.12", Name: "10.4 LTS (includes Apache Spark 3.2.1, Scala 2.12)"},
+		},
+	}, nil
+}
+
+// compact removes the null fields of a JSON object, which the client sends
+// for unset optional fields.
+func compact(object map[string]interface{}) map[string]interface{} {
+	for key, value := range object {
+		if value == nil {
+			delete(object, key)
+		}
+	}
+	return object
+}
+
+// jsonInt64 returns the integer value of a decoded JSON number.
+func jsonInt64(value interface{}) int64 {
+	n, ok := value.(json.Number)
+	if !ok {
+		return 0
+	}
+	i, _ := n.Int64()
+	return i
+}
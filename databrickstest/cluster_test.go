@@ -0,0 +1,410 @@
+package databrickstest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/medivo/databricks-go"
+)
+
+// fixedClock returns a clock that always returns the same time.
+func fixedClock() func() time.Time {
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	return func() time.Time { return now }
+}
+
+func testCluster(t *testing.T, client *databricks.Client) string {
+	t.Helper()
+	workers := int32(2)
+	id, err := client.Cluster().Create(context.Background(), &databricks.ClusterCreateRequest{
+		ClusterSpec: databricks.ClusterSpec{
+			NumWorkers:   &workers,
+			ClusterName:  "etl",
+			SparkVersion: "7.3.x-scala2.12",
+			NodeTypeID:   "i3.xlarge",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func expectState(
+	t *testing.T,
+	client *databricks.Client,
+	id string,
+	state databricks.ClusterState,
+) *databricks.ClusterGetResponse {
+	t.Helper()
+	info, err := client.Cluster().Get(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.State != state {
+		t.Fatalf("Expected cluster state %s, got %s", state, info.State)
+	}
+	return info
+}
+
+func Test_Cluster_Lifecycle(t *testing.T) {
+	t.Parallel()
+	server, client := testClient(t, WithClock(fixedClock()))
+	clusters := client.Cluster()
+	ctx := context.Background()
+
+	_, err := clusters.Create(ctx, &databricks.ClusterCreateRequest{
+		ClusterSpec: databricks.ClusterSpec{NodeTypeID: "i3.xlarge"},
+	})
+	expectCode(t, err, databricks.CodeInvalidParameterValue)
+
+	id := testCluster(t, client)
+	expectState(t, client, id, databricks.Pending)
+	err = clusters.Restart(ctx, id)
+	expectCode(t, err, databricks.CodeInvalidState)
+
+	server.Advance(DefaultStateDelay)
+	info := expectState(t, client, id, databricks.Running)
+	if len(info.Executors) != 2 || info.Driver == nil || info.ClusterName != "etl" {
+		t.Fatalf("Unexpected cluster: %+v", info)
+	}
+
+	if err := clusters.ResizeWorkers(ctx, id, 4); err != nil {
+		t.Fatal(err)
+	}
+	expectState(t, client, id, databricks.Resizing)
+	server.Advance(DefaultStateDelay)
+	info = expectState(t, client, id, databricks.Running)
+	if len(info.Executors) != 4 {
+		t.Fatalf("Expected 4 executors, got %d", len(info.Executors))
+	}
+
+	if err := clusters.Terminate(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	expectState(t, client, id, databricks.Terminating)
+	server.Advance(DefaultStateDelay)
+	info = expectState(t, client, id, databricks.Terminated)
+	if info.TerminatedTime == 0 || len(info.Executors) != 0 {
+		t.Fatalf("Unexpected terminated cluster: %+v", info)
+	}
+
+	if err := clusters.Start(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	server.Advance(10 * DefaultStateDelay)
+	expectState(t, client, id, databricks.Running)
+	if err := clusters.Terminate(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	server.Advance(DefaultStateDelay)
+	expectState(t, client, id, databricks.Terminated)
+
+	it := clusters.EventsIter(ctx, &databricks.ClusterEventRequest{
+		ClusterID: id,
+	}, databricks.IterPageSize(3))
+	events, err := it.Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 8 || it.Pages() != 3 {
+		t.Fatalf("Expected 8 events in 3 pages, got %d in %d: %+v",
+			len(events), it.Pages(), events)
+	}
+	if events[0].Type != databricks.ClusterEventTerminating {
+		t.Fatalf("Expected the latest event first, got %+v", events)
+	}
+	if events[0].Timestamp.IsZero() || events[0].ClusterID != id {
+		t.Fatalf("Unexpected event: %+v", events[0])
+	}
+
+	if err := clusters.Delete(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	_, err = clusters.Get(ctx, id)
+	expectCode(t, err, databricks.CodeInvalidParameterValue)
+}
+
+func Test_Cluster_List(t *testing.T) {
+	t.Parallel()
+	_, client := testClient(t)
+	clusters := client.Cluster()
+	ctx := context.Background()
+
+	id := testCluster(t, client)
+	if err := clusters.Pin(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if err := clusters.Unpin(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	list, err := clusters.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].ClusterID != id {
+		t.Fatalf("Unexpected clusters: %+v", list)
+	}
+
+	nodeTypes, err := clusters.NodeTypes(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodeTypes) == 0 {
+		t.Fatalf("Expected node types")
+	}
+	zones, err := clusters.Zones(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zones.DefaultZone == "" {
+		t.Fatalf("Expected a default zone")
+	}
+}
+
+func Test_Cluster_Wait(t *testing.T) {
+	t.Parallel()
+	_, client := testClient(t, WithStateDelay(5*time.Millisecond))
+	clusters := client.Cluster()
+	ctx := context.Background()
+	opts := []databricks.WaitOpt{
+		databricks.WaitPollInterval(time.Millisecond),
+		databricks.WaitTimeout(5 * time.Second),
+	}
+
+	id := testCluster(t, client)
+	info, err := clusters.WaitForClusterState(ctx, id, databricks.Running, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Driver == nil {
+		t.Fatalf("Expected a running cluster, got %+v", info)
+	}
+
+	info, err = clusters.TerminateAndWait(ctx, id, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.TerminationReason == nil ||
+		info.TerminationReason.Code != databricks.TerminationUserRequest {
+		t.Fatalf("Unexpected termination reason: %+v", info.TerminationReason)
+	}
+	if _, err := clusters.StartAndWait(ctx, id, opts...); err != nil {
+		t.Fatal(err)
+	}
+
+	// A cluster terminated while waiting for it to run never gets there.
+	if err := clusters.Restart(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if err := clusters.Terminate(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	_, err = clusters.WaitForClusterState(ctx, id, databricks.Running, opts...)
+	var waitErr *databricks.WaitError
+	if !errors.As(err, &waitErr) {
+		t.Fatalf("Expected a WaitError, got %v", err)
+	}
+}
+
+func Test_Cluster_WatchEvents(t *testing.T) {
+	t.Parallel()
+	server := NewServer(WithStateDelay(5 * time.Millisecond))
+	t.Cleanup(server.Close)
+	client, err := server.Client(databricks.ClientEventPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clusters := client.Cluster()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	opts := []databricks.WaitOpt{databricks.WaitPollInterval(time.Millisecond)}
+
+	id := testCluster(t, client)
+	if _, err := clusters.WaitForClusterState(ctx, id, databricks.Running, opts...); err != nil {
+		t.Fatal(err)
+	}
+	// Events from before watching are not delivered.
+	if err := clusters.ResizeWorkers(ctx, id, 4); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := clusters.WaitForClusterState(ctx, id, databricks.Running, opts...); err != nil {
+		t.Fatal(err)
+	}
+	watchCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	events, errs := clusters.WatchEvents(watchCtx, id,
+		databricks.ClusterEventResizing,
+		databricks.ClusterEventTerminating,
+	)
+
+	if err := clusters.ResizeWorkers(ctx, id, 3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := clusters.WaitForClusterState(ctx, id, databricks.Running, opts...); err != nil {
+		t.Fatal(err)
+	}
+	if err := clusters.Terminate(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	var received []databricks.ClusterEvent
+	for event := range events {
+		received = append(received, event)
+		if event.Type == databricks.ClusterEventTerminating {
+			stop()
+		}
+	}
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the watch to be canceled, got %v", err)
+	}
+	if len(received) != 2 ||
+		received[0].Type != databricks.ClusterEventResizing ||
+		received[0].Details.TargetNumWorkers != 3 {
+		t.Fatalf("Unexpected events: %+v", received)
+	}
+	reason := received[1].Details.Reason
+	if reason == nil || reason.Code != databricks.TerminationUserRequest {
+		t.Fatalf("Unexpected termination reason: %+v", reason)
+	}
+
+	_, errs = clusters.WatchEvents(ctx, "missing")
+	expectCode(t, <-errs, databricks.CodeInvalidParameterValue)
+}
+
+func Test_Cluster_Ensure(t *testing.T) {
+	t.Parallel()
+	server, client := testClient(t, WithClock(fixedClock()))
+	clusters := client.Cluster()
+	ctx := context.Background()
+
+	spec := &databricks.ClusterSpec{
+		ClusterName:  "shared",
+		SparkVersion: "7.3.x-scala2.12",
+		NodeTypeID:   "i3.xlarge",
+		Autoscale:    &databricks.Autoscale{Min: 1, Max: 4},
+		CustomTags:   map[string]string{"deployment": "blue"},
+		SparkConf:    map[string]string{"spark.speculation": "true"},
+	}
+	res, err := clusters.Ensure(ctx, spec, databricks.EnsureTag("deployment"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Action != databricks.EnsureCreated || len(res.Diff) != 7 {
+		t.Fatalf("Expected the cluster to be created, got %+v", res)
+	}
+	id := res.ClusterID
+
+	// Editing a pending cluster fails, so an unchanged spec must not be
+	// sent.
+	res, err = clusters.Ensure(ctx, spec, databricks.EnsureTag("deployment"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Action != databricks.EnsureUnchanged || res.ClusterID != id ||
+		len(res.Diff) != 0 {
+		t.Fatalf("Expected the cluster to be unchanged, got %+v", res)
+	}
+
+	server.Advance(DefaultStateDelay)
+	spec.Autoscale.Max = 8
+	spec.SparkConf = nil
+	res, err = clusters.Ensure(ctx, spec, databricks.EnsureTag("deployment"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var diff []string
+	for _, d := range res.Diff {
+		diff = append(diff, d.String())
+	}
+	expected := "[autoscale.max_workers: 4 -> 8 spark_conf.spark.speculation: true -> <unset>]"
+	if res.Action != databricks.EnsureUpdated || fmt.Sprint(diff) != expected {
+		t.Fatalf("Expected the cluster to be updated with %s, got %+v", expected, res)
+	}
+	info := expectState(t, client, id, databricks.Restarting)
+	if info.Autoscale.Max != 8 || info.SparkConf != nil {
+		t.Fatalf("Unexpected spec: %+v", info.ClusterSpec)
+	}
+
+	// A cluster of another deployment is created next to the first one,
+	// after which the name alone is ambiguous.
+	green := *spec
+	green.CustomTags = map[string]string{"deployment": "green"}
+	res, err = clusters.Ensure(ctx, &green, databricks.EnsureTag("deployment"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Action != databricks.EnsureCreated || res.ClusterID == id {
+		t.Fatalf("Expected a second cluster to be created, got %+v", res)
+	}
+	if _, err := clusters.Ensure(ctx, spec); err == nil {
+		t.Fatalf("Expected error for two clusters with the same name")
+	}
+	if _, err := clusters.Ensure(ctx, spec, databricks.EnsureTag("team")); err == nil {
+		t.Fatalf("Expected error for a tag missing from the spec")
+	}
+
+	// The values that a policy fills in are not differences, unless the
+	// spec sets them.
+	policyID, err := client.ClusterPolicies().Create(ctx, &databricks.Policy{
+		Name: "etl",
+		Definition: databricks.PolicyDefinition{
+			"custom_tags.owner":            {Type: databricks.PolicyFixed, Value: "data"},
+			"spark_conf.spark.speculation": {Type: databricks.PolicyFixed, Value: true},
+			"spark_env_vars.ENV": {
+				Type: databricks.PolicyUnlimited, DefaultValue: "prod",
+			},
+			"autotermination_minutes": {Type: databricks.PolicyFixed, Value: 30},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	workers := int32(2)
+	policySpec := &databricks.ClusterSpec{
+		ClusterName:              "policy",
+		SparkVersion:             "7.3.x-scala2.12",
+		NodeTypeID:               "i3.xlarge",
+		NumWorkers:               &workers,
+		PolicyID:                 policyID,
+		ApplyPolicyDefaultValues: true,
+	}
+	res, err = clusters.Ensure(ctx, policySpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Action != databricks.EnsureCreated {
+		t.Fatalf("Expected the cluster to be created, got %+v", res)
+	}
+	policyClusterID := res.ClusterID
+	info = expectState(t, client, policyClusterID, databricks.Pending)
+	if info.CustomTags["owner"] != "data" ||
+		info.SparkConf["spark.speculation"] != "true" ||
+		info.SparkEnvVars["ENV"] != "prod" {
+		t.Fatalf("Expected the policy values to be set, got %+v", info.ClusterSpec)
+	}
+	res, err = clusters.Ensure(ctx, policySpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Action != databricks.EnsureUnchanged || len(res.Diff) != 0 {
+		t.Fatalf("Expected the policy cluster to be unchanged, got %+v", res)
+	}
+
+	server.Advance(DefaultStateDelay)
+	policySpec.SparkEnvVars = map[string]string{"ENV": "dev"}
+	res, err = clusters.Ensure(ctx, policySpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "[spark_env_vars.ENV: prod -> dev]"
+	if res.Action != databricks.EnsureUpdated || fmt.Sprint(res.Diff) != expected {
+		t.Fatalf("Expected the cluster to be updated with %s, got %+v", expected, res)
+	}
+	info = expectState(t, client, policyClusterID, databricks.Restarting)
+	if info.CustomTags["owner"] != "data" || info.SparkEnvVars["ENV"] != "dev" {
+		t.Fatalf("Unexpected spec: %+v", info.ClusterSpec)
+	}
+}
@@ -0,0 +1,379 @@
+package databrickstest
+
+import (
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/medivo/databricks-go"
+)
+
+// DBFS limits of the API.
+const (
+	maxBlockSize      = 1 << 20
+	maxReadSize       = 1 << 20
+	defaultReadLength = 512 << 10
+)
+
+type dbfsFile struct {
+	isDir    bool
+	data     []byte
+	modified int64
+}
+
+type dbfsHandle struct {
+	path string
+	data []byte
+}
+
+type dbfsState struct {
+	files   map[string]*dbfsFile
+	handles map[int64]*dbfsHandle
+}
+
+func newDBFSState() *dbfsState {
+	return &dbfsState{
+		files:   map[string]*dbfsFile{"/": {isDir: true}},
+		handles: map[int64]*dbfsHandle{},
+	}
+}
+
+// fileInfo is the JSON representation of a DBFS file.
+type fileInfo struct {
+	Path             string `json:"path"`
+	IsDir            bool   `json:"is_dir"`
+	FileSize         int64  `json:"file_size"`
+	ModificationTime int64  `json:"modification_time"`
+}
+
+func (s *Server) dbfsRoutes() map[string]route {
+	return map[string]route{
+		"dbfs/add-block":  {http.MethodPost, s.dbfsAddBlock},
+		"dbfs/close":      {http.MethodPost, s.dbfsClose},
+		"dbfs/create":     {http.MethodPost, s.dbfsCreate},
+		"dbfs/delete":     {http.MethodPost, s.dbfsDelete},
+		"dbfs/get-status": {http.MethodGet, s.dbfsGetStatus},
+		"dbfs/list":       {http.MethodGet, s.dbfsList},
+		"dbfs/mkdirs":     {http.MethodPost, s.dbfsMkdirs},
+		"dbfs/move":       {http.MethodPost, s.dbfsMove},
+		"dbfs/put":        {http.MethodPost, s.dbfsPut},
+		"dbfs/read":       {http.MethodGet, s.dbfsRead},
+	}
+}
+
+// DBFSFile returns the contents of a DBFS file, e.g. to check what the code
+// under test uploaded.
+func (s *Server) DBFSFile(p string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, ok := s.dbfs.files[path.Clean(dbfsPath(p))]
+	if !ok || f.isDir {
+		return nil, false
+	}
+	return append([]byte{}, f.data...), true
+}
+
+// dbfsPath strips the optional dbfs: scheme of a path.
+func dbfsPath(p string) string {
+	return strings.TrimPrefix(p, "dbfs:")
+}
+
+func cleanDBFSPath(field, p string) (string, error) {
+	return cleanPath(field, dbfsPath(p))
+}
+
+// dbfsMkdirsAll creates a directory and its parents.
+func (s *Server) dbfsMkdirsAll(p string) error {
+	for _, dir := range append(parents(p), p) {
+		f, ok := s.dbfs.files[dir]
+		if !ok {
+			s.dbfs.files[dir] = &dbfsFile{isDir: true, modified: s.millis()}
+			continue
+		}
+		if !f.isDir {
+			return alreadyExists(
+				"A file or directory already exists at the input path %s.", dir)
+		}
+	}
+	return nil
+}
+
+// dbfsWrite writes a file, creating its parent directories.
+func (s *Server) dbfsWrite(p string, data []byte, overwrite bool) error {
+	if f, ok := s.dbfs.files[p]; ok {
+		if f.isDir || !overwrite {
+			return alreadyExists(
+				"A file or directory already exists at the input path %s.", p)
+		}
+	}
+	if err := s.dbfsMkdirsAll(path.Dir(p)); err != nil {
+		return err
+	}
+	s.dbfs.files[p] = &dbfsFile{data: data, modified: s.millis()}
+	return nil
+}
+
+func (s *Server) dbfsInfo(p string) fileInfo {
+	f := s.dbfs.files[p]
+	return fileInfo{
+		Path:             p,
+		IsDir:            f.isDir,
+		FileSize:         int64(len(f.data)),
+		ModificationTime: f.modified,
+	}
+}
+
+func (s *Server) dbfsCreate(r *http.Request) (interface{}, error) {
+	req := struct {
+		Path      string `json:"path"`
+		Overwrite bool   `json:"overwrite"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	p, err := cleanDBFSPath("path", req.Path)
+	if err != nil {
+		return nil, err
+	}
+	if f, ok := s.dbfs.files[p]; ok && (f.isDir || !req.Overwrite) {
+		return nil, alreadyExists(
+			"A file or directory already exists at the input path %s.", p)
+	}
+	handle := s.id()
+	s.dbfs.handles[handle] = &dbfsHandle{path: p}
+	return map[string]int64{"handle": handle}, nil
+}
+
+func (s *Server) dbfsAddBlock(r *http.Request) (interface{}, error) {
+	req := struct {
+		Handle int64  `json:"handle"`
+		Data   []byte `json:"data"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	h, ok := s.dbfs.handles[req.Handle]
+	if !ok {
+		return nil, notFound("The handle %d does not exist.", req.Handle)
+	}
+	if len(req.Data) > maxBlockSize {
+		return nil, newError(
+			http.StatusBadRequest,
+			databricks.CodeMaxBlockSizeExceeded,
+			"The block of data exceeds the limit of %d bytes.", maxBlockSize,
+		)
+	}
+	h.data = append(h.data, req.Data...)
+	return nil, nil
+}
+
+func (s *Server) dbfsClose(r *http.Request) (interface{}, error) {
+	req := struct {
+		Handle int64 `json:"handle"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	h, ok := s.dbfs.handles[req.Handle]
+	if !ok {
+		return nil, notFound("The handle %d does not exist.", req.Handle)
+	}
+	delete(s.dbfs.handles, req.Handle)
+	return nil, s.dbfsWrite(h.path, h.data, true)
+}
+
+func (s *Server) dbfsPut(r *http.Request) (interface{}, error) {
+	req := struct {
+		Path      string `json:"path"`
+		Contents  []byte `json:"contents"`
+		Overwrite bool   `json:"overwrite"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	p, err := cleanDBFSPath("path", req.Path)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Contents) > maxBlockSize {
+		return nil, newError(
+			http.StatusBadRequest,
+			databricks.CodeMaxBlockSizeExceeded,
+			"The contents exceed the limit of %d bytes, use the streaming "+
+				"upload instead.", maxBlockSize,
+		)
+	}
+	return nil, s.dbfsWrite(p, append([]byte{}, req.Contents...), req.Overwrite)
+}
+
+func (s *Server) dbfsRead(r *http.Request) (interface{}, error) {
+	p, err := cleanDBFSPath("path", r.URL.Query().Get("path"))
+	if err != nil {
+		return nil, err
+	}
+	offset, err := queryInt64(r, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	length, err := queryInt64(r, "length", defaultReadLength)
+	if err != nil {
+		return nil, err
+	}
+	if offset < 0 || length < 0 {
+		return nil, invalidParameter(
+			"Offset (%d) and length (%d) must be non-negative.", offset, length)
+	}
+	if length > maxReadSize {
+		return nil, newError(
+			http.StatusBadRequest,
+			databricks.CodeMaxReadSizeExceeded,
+			"Cannot read more than %d bytes, got %d.", maxReadSize, length,
+		)
+	}
+	f, ok := s.dbfs.files[p]
+	if !ok {
+		return nil, notFound("No file or directory exists on path %s.", p)
+	}
+	if f.isDir {
+		return nil, invalidParameter("Cannot read a directory: %s.", p)
+	}
+	data := []byte{}
+	if offset < int64(len(f.data)) {
+		end := offset + length
+		if end > int64(len(f.data)) {
+			end = int64(len(f.data))
+		}
+		data = f.data[offset:end]
+	}
+	return struct {
+		BytesRead int64  `json:"bytes_read"`
+		Data      []byte `json:"data"`
+	}{int64(len(data)), data}, nil
+}
+
+func (s *Server) dbfsGetStatus(r *http.Request) (interface{}, error) {
+	p, err := cleanDBFSPath("path", r.URL.Query().Get("path"))
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.dbfs.files[p]; !ok {
+		return nil, notFound("No file or directory exists on path %s.", p)
+	}
+	return s.dbfsInfo(p), nil
+}
+
+func (s *Server) dbfsList(r *http.Request) (interface{}, error) {
+	p, err := cleanDBFSPath("path", r.URL.Query().Get("path"))
+	if err != nil {
+		return nil, err
+	}
+	f, ok := s.dbfs.files[p]
+	if !ok {
+		return nil, notFound("No file or directory exists on path %s.", p)
+	}
+	files := []fileInfo{}
+	if !f.isDir {
+		files = append(files, s.dbfsInfo(p))
+	}
+	for child := range s.dbfs.files {
+		if f.isDir && isChild(p, child) {
+			files = append(files, s.dbfsInfo(child))
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return map[string][]fileInfo{"files": files}, nil
+}
+
+func (s *Server) dbfsMkdirs(r *http.Request) (interface{}, error) {
+	req := struct {
+		Path string `json:"path"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	p, err := cleanDBFSPath("path", req.Path)
+	if err != nil {
+		return nil, err
+	}
+	return nil, s.dbfsMkdirsAll(p)
+}
+
+func (s *Server) dbfsMove(r *http.Request) (interface{}, error) {
+	req := struct {
+		SourcePath      string `json:"source_path"`
+		DestinationPath string `json:"destination_path"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	src, err := cleanDBFSPath("source_path", req.SourcePath)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := cleanDBFSPath("destination_path", req.DestinationPath)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.dbfs.files[src]; !ok {
+		return nil, notFound("No file or directory exists on path %s.", src)
+	}
+	if _, ok := s.dbfs.files[dst]; ok {
+		return nil, alreadyExists(
+			"A file or directory already exists at the input path %s.", dst)
+	}
+	if src == "/" || isDescendant(src, dst) {
+		return nil, invalidParameter("Cannot move %s into itself.", src)
+	}
+	if err := s.dbfsMkdirsAll(path.Dir(dst)); err != nil {
+		return nil, err
+	}
+	moved := map[string]*dbfsFile{}
+	for p, f := range s.dbfs.files {
+		if p == src || isDescendant(src, p) {
+			moved[dst+strings.TrimPrefix(p, src)] = f
+			delete(s.dbfs.files, p)
+		}
+	}
+	for p, f := range moved {
+		s.dbfs.files[p] = f
+	}
+	return nil, nil
+}
+
+func (s *Server) dbfsDelete(r *http.Request) (interface{}, error) {
+	req := struct {
+		Path      string `json:"path"`
+		Recursive bool   `json:"recursive"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	p, err := cleanDBFSPath("path", req.Path)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.dbfs.files[p]; !ok {
+		// Deleting a missing path succeeds.
+		return nil, nil
+	}
+	for child := range s.dbfs.files {
+		if isDescendant(p, child) {
+			if !req.Recursive {
+				return nil, newError(
+					http.StatusBadRequest,
+					databricks.CodeIOError,
+					"Cannot delete the non-empty directory %s without recursive.",
+					p,
+				)
+			}
+			delete(s.dbfs.files, child)
+		}
+	}
+	if p != "/" {
+		delete(s.dbfs.files, p)
+	}
+	return nil, nil
+}
@@ -0,0 +1,104 @@
+package databrickstest
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/medivo/databricks-go"
+)
+
+func Test_DBFS_Streaming(t *testing.T) {
+	t.Parallel()
+	server, client := testClient(t)
+	dbfs := client.DBFS()
+	ctx := context.Background()
+
+	handle, err := dbfs.Create(ctx, "/tmp/data.csv", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, block := range []string{"a,b\n", "1,2\n"} {
+		if err := dbfs.AddBlock(ctx, handle, []byte(block)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := server.DBFSFile("/tmp/data.csv"); ok {
+		t.Fatalf("Expected the file to not exist before Close")
+	}
+	if err := dbfs.Close(ctx, handle); err != nil {
+		t.Fatal(err)
+	}
+	err = dbfs.Close(ctx, handle)
+	expectCode(t, err, databricks.CodeResourceDoesNotExist)
+
+	data, ok := server.DBFSFile("dbfs:/tmp/data.csv")
+	if !ok || string(data) != "a,b\n1,2\n" {
+		t.Fatalf("Unexpected file contents: %q", data)
+	}
+	n, data, err := dbfs.Read(ctx, "/tmp/data.csv", 4, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 4 || string(data) != "1,2\n" {
+		t.Fatalf("Unexpected read of %d bytes: %q", n, data)
+	}
+	isDir, size, err := dbfs.GetStatus(ctx, "/tmp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isDir || size != 0 {
+		t.Fatalf("Expected /tmp to be a directory")
+	}
+
+	_, err = dbfs.Create(ctx, "/tmp/data.csv", false)
+	expectCode(t, err, databricks.CodeResourceAlreadyExists)
+	handle, err = dbfs.Create(ctx, "/tmp/data.csv", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = dbfs.AddBlock(ctx, handle, bytes.Repeat([]byte("x"), maxBlockSize+1))
+	expectCode(t, err, databricks.CodeMaxBlockSizeExceeded)
+	_, _, err = dbfs.Read(ctx, "/tmp/data.csv", 0, maxReadSize+1)
+	expectCode(t, err, databricks.CodeMaxReadSizeExceeded)
+}
+
+func Test_DBFS_Files(t *testing.T) {
+	t.Parallel()
+	_, client := testClient(t)
+	dbfs := client.DBFS()
+	ctx := context.Background()
+
+	if err := dbfs.Put(ctx, "/a/b/one.txt", []byte("one"), false); err != nil {
+		t.Fatal(err)
+	}
+	if err := dbfs.Mkdirs(ctx, "/a/c"); err != nil {
+		t.Fatal(err)
+	}
+	files, err := dbfs.List(ctx, "/a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || files[0].Path != "/a/b" || !files[1].IsDir {
+		t.Fatalf("Unexpected files: %+v", files)
+	}
+
+	if err := dbfs.Move(ctx, "/a/b", "/a/c/b"); err != nil {
+		t.Fatal(err)
+	}
+	files, err = dbfs.List(ctx, "/a/c/b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Path != "/a/c/b/one.txt" || files[0].FileSize != 3 {
+		t.Fatalf("Unexpected files: %+v", files)
+	}
+
+	err = dbfs.Delete(ctx, "/a", false)
+	expectCode(t, err, databricks.CodeIOError)
+	if err := dbfs.Delete(ctx, "/a", true); err != nil {
+		t.Fatal(err)
+	}
+	_, err = dbfs.List(ctx, "/a")
+	expectCode(t, err, databricks.CodeResourceDoesNotExist)
+}
@@ -0,0 +1,228 @@
+package databrickstest
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/medivo/databricks-go"
+)
+
+// Built-in groups of a workspace, which can not be deleted.
+const (
+	adminsGroup = "admins"
+	usersGroup  = "users"
+)
+
+type group struct {
+	users  map[string]bool
+	groups map[string]bool
+}
+
+func newGroup() *group {
+	return &group{users: map[string]bool{}, groups: map[string]bool{}}
+}
+
+func newGroups() map[string]*group {
+	return map[string]*group{
+		adminsGroup: newGroup(),
+		usersGroup:  newGroup(),
+	}
+}
+
+func (s *Server) groupsRoutes() map[string]route {
+	return map[string]route{
+		"groups/add-member":    {http.MethodPost, s.groupsAddMember},
+		"groups/create":        {http.MethodPost, s.groupsCreate},
+		"groups/delete":        {http.MethodPost, s.groupsDelete},
+		"groups/list":          {http.MethodGet, s.groupsList},
+		"groups/list-members":  {http.MethodGet, s.groupsListMembers},
+		"groups/list-parents":  {http.MethodGet, s.groupsListParents},
+		"groups/remove-member": {http.MethodPost, s.groupsRemoveMember},
+	}
+}
+
+func (s *Server) group(name string) (*group, error) {
+	if name == "" {
+		return nil, missingField("group_name")
+	}
+	g, ok := s.groups[name]
+	if !ok {
+		return nil, notFound("Group %s does not exist.", name)
+	}
+	return g, nil
+}
+
+// groupMember is the member of a group in add-member and remove-member
+// requests.
+type groupMember struct {
+	UserName   string `json:"user_name"`
+	GroupName  string `json:"group_name"`
+	ParentName string `json:"parent_name"`
+}
+
+// members returns the member set of the parent that the member belongs in.
+func (s *Server) members(m groupMember) (map[string]bool, string, error) {
+	if (m.UserName == "") == (m.GroupName == "") {
+		return nil, "", invalidParameter(
+			"Exactly one of user_name and group_name must be set.")
+	}
+	if m.ParentName == "" {
+		return nil, "", missingField("parent_name")
+	}
+	parent, err := s.group(m.ParentName)
+	if err != nil {
+		return nil, "", err
+	}
+	if m.UserName != "" {
+		return parent.users, m.UserName, nil
+	}
+	if _, err := s.group(m.GroupName); err != nil {
+		return nil, "", err
+	}
+	return parent.groups, m.GroupName, nil
+}
+
+func (s *Server) groupsCreate(r *http.Request) (interface{}, error) {
+	req := struct {
+		GroupName string `json:"group_name"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.GroupName == "" {
+		return nil, missingField("group_name")
+	}
+	if _, ok := s.groups[req.GroupName]; ok {
+		return nil, alreadyExists("Group %s already exists.", req.GroupName)
+	}
+	s.groups[req.GroupName] = newGroup()
+	return map[string]string{"group_name": req.GroupName}, nil
+}
+
+func (s *Server) groupsDelete(r *http.Request) (interface{}, error) {
+	req := struct {
+		GroupName string `json:"group_name"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if _, err := s.group(req.GroupName); err != nil {
+		return nil, err
+	}
+	if req.GroupName == adminsGroup || req.GroupName == usersGroup {
+		return nil, invalidParameter(
+			"The %s group can not be deleted.", req.GroupName)
+	}
+	delete(s.groups, req.GroupName)
+	for _, g := range s.groups {
+		delete(g.groups, req.GroupName)
+	}
+	return nil, nil
+}
+
+func (s *Server) groupsAddMember(r *http.Request) (interface{}, error) {
+	var req groupMember
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	members, name, err := s.members(req)
+	if err != nil {
+		return nil, err
+	}
+	if req.GroupName != "" && s.isAncestor(req.GroupName, req.ParentName) {
+		return nil, invalidParameter(
+			"Adding group %s to %s would create a cycle.",
+			req.GroupName, req.ParentName,
+		)
+	}
+	members[name] = true
+	return nil, nil
+}
+
+func (s *Server) groupsRemoveMember(r *http.Request) (interface{}, error) {
+	var req groupMember
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	members, name, err := s.members(req)
+	if err != nil {
+		return nil, err
+	}
+	if !members[name] {
+		return nil, notFound("%s is not a member of %s.", name, req.ParentName)
+	}
+	delete(members, name)
+	return nil, nil
+}
+
+// isAncestor returns whether the group ancestor contains group, directly
+// or through other groups.
+func (s *Server) isAncestor(ancestor, group string) bool {
+	if ancestor == group {
+		return true
+	}
+	for child := range s.groups[ancestor].groups {
+		if s.isAncestor(child, group) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) groupsList(r *http.Request) (interface{}, error) {
+	names := []string{}
+	for name := range s.groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return map[string][]string{"group_names": names}, nil
+}
+
+func (s *Server) groupsListMembers(r *http.Request) (interface{}, error) {
+	g, err := s.group(r.URL.Query().Get("group_name"))
+	if err != nil {
+		return nil, err
+	}
+	members := []databricks.PrincipalName{}
+	for _, name := range sortedKeys(g.groups) {
+		name := name
+		members = append(members, databricks.PrincipalName{GroupName: &name})
+	}
+	for _, name := range sortedKeys(g.users) {
+		name := name
+		members = append(members, databricks.PrincipalName{UserName: &name})
+	}
+	return map[string][]databricks.PrincipalName{"members": members}, nil
+}
+
+func (s *Server) groupsListParents(r *http.Request) (interface{}, error) {
+	q := r.URL.Query()
+	userName, groupName := q.Get("user_name"), q.Get("group_name")
+	if (userName == "") == (groupName == "") {
+		return nil, invalidParameter(
+			"Exactly one of user_name and group_name must be set.")
+	}
+	if groupName != "" {
+		if _, err := s.group(groupName); err != nil {
+			return nil, err
+		}
+	}
+	names := []string{}
+	for name, g := range s.groups {
+		if g.users[userName] || g.groups[groupName] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return map[string][]string{"group_names": names}, nil
+}
+
+// sortedKeys returns the keys of a set in order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,73 @@
+package databrickstest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/medivo/databricks-go"
+)
+
+func Test_Groups(t *testing.T) {
+	t.Parallel()
+	_, client := testClient(t, WithUserName("alice@example.com"))
+	groups := client.Groups()
+	ctx := context.Background()
+
+	if err := groups.Create(ctx, "data-eng"); err != nil {
+		t.Fatal(err)
+	}
+	err := groups.Create(ctx, "data-eng")
+	expectCode(t, err, databricks.CodeResourceAlreadyExists)
+	if err := groups.Create(ctx, "analysts"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := groups.AddMember(ctx, "bob@example.com", "", "data-eng"); err != nil {
+		t.Fatal(err)
+	}
+	if err := groups.AddMember(ctx, "", "analysts", "data-eng"); err != nil {
+		t.Fatal(err)
+	}
+	err = groups.AddMember(ctx, "", "data-eng", "analysts")
+	expectCode(t, err, databricks.CodeInvalidParameterValue)
+	err = groups.AddMember(ctx, "bob@example.com", "", "missing")
+	expectCode(t, err, databricks.CodeResourceDoesNotExist)
+
+	members, err := groups.Members(ctx, "data-eng")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 2 ||
+		members[0].GroupName == nil || *members[0].GroupName != "analysts" ||
+		members[1].UserName == nil || *members[1].UserName != "bob@example.com" {
+		t.Fatalf("Unexpected members: %+v", members)
+	}
+
+	parents, err := groups.UserParents(ctx, "alice@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parents) != 2 || parents[0] != "admins" || parents[1] != "users" {
+		t.Fatalf("Unexpected parents: %v", parents)
+	}
+	parents, err = groups.GroupParents(ctx, "analysts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parents) != 1 || parents[0] != "data-eng" {
+		t.Fatalf("Unexpected parents: %v", parents)
+	}
+
+	err = groups.Delete(ctx, "users")
+	expectCode(t, err, databricks.CodeInvalidParameterValue)
+	if err := groups.Delete(ctx, "data-eng"); err != nil {
+		t.Fatal(err)
+	}
+	names, err := groups.Groups(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 3 {
+		t.Fatalf("Unexpected groups: %v", names)
+	}
+}
@@ -0,0 +1,645 @@
+package databrickstest
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/medivo/databricks-go"
+)
+
+// Limits of the jobs API.
+const (
+	defaultJobsLimit         = 20
+	maxJobsLimit             = 25
+	defaultRunsLimit         = 20
+	maxRunsLimit             = 1000
+	defaultMaxConcurrentRuns = 1
+)
+
+// Fields of job settings that describe the task of a run, and the cluster
+// it runs on.
+var (
+	taskFields    = []string{"notebook_task", "spark_jar_task", "spark_python_task", "spark_submit_task"}
+	clusterFields = []string{"existing_cluster_id", "new_cluster", "libraries"}
+)
+
+type job struct {
+	id       int64
+	settings map[string]interface{}
+	creator  string
+	created  int64
+	runs     int64
+}
+
+type run struct {
+	id      int64
+	jobID   int64
+	number  int64
+	runType string
+	name    string
+	trigger databricks.TriggerType
+	creator string
+
+	task        map[string]interface{}
+	clusterSpec map[string]interface{}
+	clusterID   string
+	params      map[string]interface{}
+
+	state   databricks.LifeCycleState
+	result  databricks.ResultState
+	message string
+	since   time.Time
+
+	start     int64
+	setup     int64
+	execution int64
+	cleanup   int64
+
+	output   string
+	failure  string
+	canceled bool
+}
+
+// terminal returns whether the run has finished.
+func (r *run) terminal() bool {
+	return r.state.IsTerminal()
+}
+
+func (s *Server) jobsRoutes() map[string]route {
+	return map[string]route{
+		"jobs/create":          {http.MethodPost, s.jobsCreate},
+		"jobs/delete":          {http.MethodPost, s.jobsDelete},
+		"jobs/get":             {http.MethodGet, s.jobsGet},
+		"jobs/list":            {http.MethodGet, s.jobsList},
+		"jobs/reset":           {http.MethodPost, s.jobsReset},
+		"jobs/run-now":         {http.MethodPost, s.jobsRunNow},
+		"jobs/runs/cancel":     {http.MethodPost, s.jobsRunsCancel},
+		"jobs/runs/delete":     {http.MethodPost, s.jobsRunsDelete},
+		"jobs/runs/export":     {http.MethodGet, s.jobsRunsExport},
+		"jobs/runs/get":        {http.MethodGet, s.jobsRunsGet},
+		"jobs/runs/get-output": {http.MethodGet, s.jobsRunsGetOutput},
+		"jobs/runs/list":       {http.MethodGet, s.jobsRunsList},
+		"jobs/runs/submit":     {http.MethodPost, s.jobsRunsSubmit},
+	}
+}
+
+// SetRunOutput sets the notebook output that a run reports once it has
+// succeeded, i.e. the value the notebook passed to dbutils.notebook.exit.
+func (s *Server) SetRunOutput(runID int64, result string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.runs[runID]
+	if !ok {
+		return fmt.Errorf("databrickstest: run %d does not exist", runID)
+	}
+	r.output = result
+	return nil
+}
+
+// FailRun makes a run that has not terminated yet end with the FAILED
+// result state and the given error message, instead of succeeding.
+func (s *Server) FailRun(runID int64, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.runs[runID]
+	if !ok {
+		return fmt.Errorf("databrickstest: run %d does not exist", runID)
+	}
+	if r.terminal() {
+		return fmt.Errorf("databrickstest: run %d has already terminated", runID)
+	}
+	r.failure = message
+	return nil
+}
+
+// stepRun moves a run to its next life cycle state.
+func (s *Server) stepRun(r *run) {
+	delay := s.delay.Nanoseconds() / int64(time.Millisecond)
+	switch r.state {
+	case databricks.LifeCycleStatePending:
+		r.setup = delay
+		r.state, r.message = databricks.LifeCycleStateRunning, "In run"
+	case databricks.LifeCycleStateRunning:
+		r.execution = delay
+		r.state, r.message = databricks.LifeCycleStateTerminating, ""
+	case databricks.LifeCycleStateTerminating:
+		r.cleanup = delay
+		r.state = databricks.LifeCycleStateTerminated
+		switch {
+		case r.canceled:
+			r.result = databricks.ResultStateCanceled
+		case r.failure != "":
+			r.result, r.message = databricks.ResultStateFailed, r.failure
+		default:
+			r.result = databricks.ResultStateSuccess
+		}
+	}
+}
+
+func (s *Server) job(id int64) (*job, error) {
+	j, ok := s.jobs[id]
+	if !ok {
+		return nil, invalidParameter("Job %d does not exist.", id)
+	}
+	return j, nil
+}
+
+func (s *Server) run(id int64) (*run, error) {
+	r, ok := s.runs[id]
+	if !ok {
+		return nil, invalidParameter("Run %d does not exist.", id)
+	}
+	return r, nil
+}
+
+// validateSettings checks the task and cluster fields of job settings.
+func (s *Server) validateSettings(settings map[string]interface{}) error {
+	if settings["existing_cluster_id"] != nil && settings["new_cluster"] != nil {
+		return invalidParameter(
+			"Only one of existing_cluster_id and new_cluster can be set.")
+	}
+	if id, ok := settings["existing_cluster_id"].(string); ok {
+		if _, err := s.cluster(id); err != nil {
+			return err
+		}
+	}
+	tasks := 0
+	for _, field := range taskFields {
+		if settings[field] != nil {
+			tasks++
+		}
+	}
+	if tasks > 1 {
+		return invalidParameter("Only one task can be set.")
+	}
+	return nil
+}
+
+// newRun starts a run of the given settings.
+func (s *Server) newRun(
+	jobID, number int64,
+	runType string,
+	trigger databricks.TriggerType,
+	settings, params map[string]interface{},
+	maxConcurrentRuns int64,
+) (*run, error) {
+	if err := s.validateSettings(settings); err != nil {
+		return nil, err
+	}
+	r := &run{
+		id:          s.id(),
+		jobID:       jobID,
+		number:      number,
+		runType:     runType,
+		trigger:     trigger,
+		creator:     s.userName,
+		task:        map[string]interface{}{},
+		clusterSpec: map[string]interface{}{},
+		params:      params,
+		state:       databricks.LifeCycleStatePending,
+		since:       s.now(),
+		start:       s.millis(),
+	}
+	r.name, _ = settings["name"].(string)
+	if r.name == "" {
+		r.name = "Untitled"
+	}
+	for _, field := range taskFields {
+		if settings[field] != nil {
+			r.task[field] = settings[field]
+		}
+	}
+	if len(r.task) == 0 {
+		return nil, invalidParameter(
+			"One of notebook_task, spark_jar_task, spark_python_task or " +
+				"spark_submit_task must be set.")
+	}
+	for _, field := range clusterFields {
+		if settings[field] != nil {
+			r.clusterSpec[field] = settings[field]
+		}
+	}
+	if id, ok := settings["existing_cluster_id"].(string); ok {
+		r.clusterID = id
+	} else if settings["new_cluster"] != nil {
+		r.clusterID = fmt.Sprintf("%s-job%d-run%d",
+			s.now().UTC().Format("0102-150405"), jobID, r.id)
+	} else {
+		return nil, invalidParameter(
+			"One of existing_cluster_id and new_cluster must be set.")
+	}
+
+	active := int64(0)
+	for _, other := range s.runs {
+		if other.jobID == jobID && !other.terminal() {
+			active++
+		}
+	}
+	if active >= maxConcurrentRuns {
+		r.state = databricks.LifeCycleStateSkipped
+		r.message = fmt.Sprintf(
+			"Skipping this run because the limit of %d maximum concurrent "+
+				"runs has been reached.", maxConcurrentRuns)
+	}
+	s.runs[r.id] = r
+	return r, nil
+}
+
+func (s *Server) runInfo(r *run) map[string]interface{} {
+	state := map[string]interface{}{
+		"life_cycle_state": r.state,
+		"state_message":    r.message,
+	}
+	if r.result != "" {
+		state["result_state"] = r.result
+	}
+	info := map[string]interface{}{
+		"job_id":                  r.jobID,
+		"run_id":                  r.id,
+		"number_in_job":           r.number,
+		"original_attempt_run_id": r.id,
+		"creator_user_name":       r.creator,
+		"run_name":                r.name,
+		"run_type":                r.runType,
+		"trigger":                 r.trigger,
+		"state":                   state,
+		"task":                    r.task,
+		"cluster_spec":            r.clusterSpec,
+		"start_time":              r.start,
+		"setup_duration":          r.setup,
+		"execution_duration":      r.execution,
+		"cleanup_duration":        r.cleanup,
+		"run_page_url": fmt.Sprintf(
+			"%s/#job/%d/run/%d", s.URL, r.jobID, r.number),
+	}
+	if r.params != nil {
+		info["overriding_parameters"] = r.params
+	}
+	if r.state != databricks.LifeCycleStatePending && r.state != databricks.LifeCycleStateSkipped {
+		instance := map[string]string{"cluster_id": r.clusterID}
+		if c, ok := s.clusters[r.clusterID]; ok {
+			instance["spark_context_id"] = fmt.Sprint(c.sparkContextID)
+		}
+		info["cluster_instance"] = instance
+	}
+	return info
+}
+
+func (s *Server) jobInfo(j *job) map[string]interface{} {
+	return map[string]interface{}{
+		"job_id":            j.id,
+		"creator_user_name": j.creator,
+		"settings":          j.settings,
+		"created_time":      j.created,
+	}
+}
+
+func (s *Server) jobsCreate(r *http.Request) (interface{}, error) {
+	var settings map[string]interface{}
+	if err := decode(r, &settings); err != nil {
+		return nil, err
+	}
+	settings = compact(settings)
+	if err := s.validateSettings(settings); err != nil {
+		return nil, err
+	}
+	if settings["name"] == nil || settings["name"] == "" {
+		settings["name"] = "Untitled"
+	}
+	j := &job{
+		id:       s.id(),
+		settings: settings,
+		creator:  s.userName,
+		created:  s.millis(),
+	}
+	s.jobs[j.id] = j
+	return map[string]int64{"job_id": j.id}, nil
+}
+
+func (s *Server) jobsDelete(r *http.Request) (interface{}, error) {
+	req := struct {
+		JobID int64 `json:"job_id"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if _, err := s.job(req.JobID); err != nil {
+		return nil, err
+	}
+	delete(s.jobs, req.JobID)
+	for id, run := range s.runs {
+		if run.jobID == req.JobID {
+			delete(s.runs, id)
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) jobsGet(r *http.Request) (interface{}, error) {
+	id, err := queryInt64(r, "job_id", 0)
+	if err != nil {
+		return nil, err
+	}
+	j, err := s.job(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.jobInfo(j), nil
+}
+
+func (s *Server) jobsList(r *http.Request) (interface{}, error) {
+	offset, err := queryInt64(r, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := queryInt64(r, "limit", defaultJobsLimit)
+	if err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = defaultJobsLimit
+	}
+	if offset < 0 || limit < 0 || limit > maxJobsLimit {
+		return nil, invalidParameter(
+			"The limit (%d) must be between 1 and %d, and the offset (%d) "+
+				"must not be negative.", limit, maxJobsLimit, offset)
+	}
+
+	ids := make([]int64, 0, len(s.jobs))
+	for id := range s.jobs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	total := int64(len(ids))
+	start, end := offset, offset+limit
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+	jobs := []map[string]interface{}{}
+	for _, id := range ids[start:end] {
+		jobs = append(jobs, s.jobInfo(s.jobs[id]))
+	}
+	// Like the API, an empty list has no jobs field.
+	if len(jobs) == 0 {
+		return map[string]interface{}{"has_more": false}, nil
+	}
+	return map[string]interface{}{
+		"jobs":     jobs,
+		"has_more": end < total,
+	}, nil
+}
+
+func (s *Server) jobsReset(r *http.Request) (interface{}, error) {
+	req := struct {
+		JobID       int64                  `json:"job_id"`
+		NewSettings map[string]interface{} `json:"new_settings"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	j, err := s.job(req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if req.NewSettings == nil {
+		return nil, missingField("new_settings")
+	}
+	settings := compact(req.NewSettings)
+	if err := s.validateSettings(settings); err != nil {
+		return nil, err
+	}
+	if settings["name"] == nil || settings["name"] == "" {
+		settings["name"] = "Untitled"
+	}
+	j.settings = settings
+	return nil, nil
+}
+
+func (s *Server) jobsRunNow(r *http.Request) (interface{}, error) {
+	var req map[string]interface{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	req = compact(req)
+	if req["job_id"] == nil {
+		return nil, missingField("job_id")
+	}
+	j, err := s.job(jsonInt64(req["job_id"]))
+	if err != nil {
+		return nil, err
+	}
+	delete(req, "job_id")
+	var params map[string]interface{}
+	if len(req) > 0 {
+		params = req
+	}
+	maxConcurrentRuns := int64(defaultMaxConcurrentRuns)
+	if n, ok := j.settings["max_concurrent_runs"]; ok {
+		maxConcurrentRuns = jsonInt64(n)
+	}
+	run, err := s.newRun(
+		j.id, j.runs+1, "JOB_RUN", databricks.TriggerOneTime,
+		j.settings, params, maxConcurrentRuns,
+	)
+	if err != nil {
+		return nil, err
+	}
+	j.runs++
+	return map[string]int64{
+		"run_id":        run.id,
+		"number_in_job": run.number,
+	}, nil
+}
+
+func (s *Server) jobsRunsSubmit(r *http.Request) (interface{}, error) {
+	var settings map[string]interface{}
+	if err := decode(r, &settings); err != nil {
+		return nil, err
+	}
+	settings = compact(settings)
+	if name, ok := settings["run_name"]; ok {
+		settings["name"] = name
+	}
+	run, err := s.newRun(
+		s.id(), 1, "SUBMIT_RUN", databricks.TriggerOneTime, settings, nil, 1)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int64{"run_id": run.id}, nil
+}
+
+func (s *Server) jobsRunsList(r *http.Request) (interface{}, error) {
+	jobID, err := queryInt64(r, "job_id", 0)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := queryInt64(r, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := queryInt64(r, "limit", defaultRunsLimit)
+	if err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = defaultRunsLimit
+	}
+	if offset < 0 || limit < 0 || limit > maxRunsLimit {
+		return nil, invalidParameter(
+			"The limit (%d) must be between 1 and %d, and the offset (%d) "+
+				"must not be negative.", limit, maxRunsLimit, offset)
+	}
+	activeOnly := queryBool(r, "active_only")
+	completedOnly := queryBool(r, "completed_only")
+	if activeOnly && completedOnly {
+		return nil, invalidParameter(
+			"Only one of active_only and completed_only can be set.")
+	}
+	runType := r.URL.Query().Get("run_type")
+
+	var runs []*run
+	for _, run := range s.runs {
+		if jobID != 0 && run.jobID != jobID ||
+			activeOnly && run.terminal() ||
+			completedOnly && !run.terminal() ||
+			runType != "" && run.runType != runType {
+			continue
+		}
+		runs = append(runs, run)
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].id > runs[j].id })
+
+	total := int64(len(runs))
+	start, end := offset, offset+limit
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+	infos := []map[string]interface{}{}
+	for _, run := range runs[start:end] {
+		infos = append(infos, s.runInfo(run))
+	}
+	return map[string]interface{}{
+		"runs":     infos,
+		"has_more": end < total,
+	}, nil
+}
+
+func (s *Server) jobsRunsGet(r *http.Request) (interface{}, error) {
+	id, err := queryInt64(r, "run_id", 0)
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.run(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.runInfo(run), nil
+}
+
+func (s *Server) jobsRunsGetOutput(r *http.Request) (interface{}, error) {
+	id, err := queryInt64(r, "run_id", 0)
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.run(id)
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]interface{}{"metadata": s.runInfo(run)}
+	switch run.result {
+	case databricks.ResultStateSuccess:
+		if run.task["notebook_task"] != nil {
+			res["notebook_output"] = map[string]interface{}{
+				"result":    run.output,
+				"truncated": false,
+			}
+		}
+	case databricks.ResultStateFailed:
+		res["error"] = run.message
+	}
+	return res, nil
+}
+
+func (s *Server) jobsRunsExport(r *http.Request) (interface{}, error) {
+	id, err := queryInt64(r, "run_id", 0)
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.run(id)
+	if err != nil {
+		return nil, err
+	}
+	task, ok := run.task["notebook_task"].(map[string]interface{})
+	if !ok {
+		return nil, invalidParameter(
+			"Run %d is not a notebook run, only notebook runs can be exported.",
+			id,
+		)
+	}
+	views := []map[string]string{}
+	switch export := r.URL.Query().Get("views_to_export"); export {
+	case "", "CODE", "ALL":
+		name := path.Base(fmt.Sprint(task["notebook_path"]))
+		views = append(views, map[string]string{
+			"content": fmt.Sprintf(
+				"<html><head><title>%s</title></head><body></body></html>",
+				name,
+			),
+			"name": name,
+			"type": "NOTEBOOK",
+		})
+	case "DASHBOARDS":
+	default:
+		return nil, invalidParameter("Unknown views_to_export: %s.", export)
+	}
+	return map[string]interface{}{"views": views}, nil
+}
+
+func (s *Server) jobsRunsCancel(r *http.Request) (interface{}, error) {
+	req := struct {
+		RunID int64 `json:"run_id"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	run, err := s.run(req.RunID)
+	if err != nil {
+		return nil, err
+	}
+	if run.terminal() || run.canceled {
+		return nil, nil
+	}
+	run.state = databricks.LifeCycleStateTerminating
+	run.canceled = true
+	run.message = "Run cancelled."
+	run.since = s.now()
+	return nil, nil
+}
+
+func (s *Server) jobsRunsDelete(r *http.Request) (interface{}, error) {
+	req := struct {
+		RunID int64 `json:"run_id"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	run, err := s.run(req.RunID)
+	if err != nil {
+		return nil, err
+	}
+	if !run.terminal() {
+		return nil, invalidState(
+			"Run %d is still active and can not be deleted.", req.RunID)
+	}
+	delete(s.runs, req.RunID)
+	return nil, nil
+}
@@ -0,0 +1,209 @@
+package databrickstest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/medivo/databricks-go"
+)
+
+func testJob(t *testing.T, client *databricks.Client, clusterID string) int64 {
+	t.Helper()
+	id, err := client.Jobs().Create(context.Background(), &databricks.JobCreateRequest{
+		ExistingClusterID: &clusterID,
+		NotebookTask:      &databricks.NotebookTask{NotebookPath: "/Shared/etl"},
+		Name:              "etl",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func expectRunState(
+	t *testing.T,
+	client *databricks.Client,
+	runID int64,
+	lifeCycleState databricks.LifeCycleState,
+	resultState databricks.ResultState,
+) *databricks.JobRunGetResponse {
+	t.Helper()
+	run, err := client.Jobs().RunsGet(context.Background(), runID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.State.LifeCycleState != lifeCycleState || run.State.ResultState != resultState {
+		t.Fatalf("Expected run state %s %s, got %+v",
+			lifeCycleState, resultState, run.State)
+	}
+	return run
+}
+
+func Test_Jobs_RunNow(t *testing.T) {
+	t.Parallel()
+	server, client := testClient(t, WithClock(fixedClock()))
+	jobs := client.Jobs()
+	ctx := context.Background()
+
+	_, err := jobs.Create(ctx, &databricks.JobCreateRequest{
+		ExistingClusterID: stringPtr("missing"),
+	})
+	expectCode(t, err, databricks.CodeInvalidParameterValue)
+
+	clusterID := testCluster(t, client)
+	jobID := testJob(t, client, clusterID)
+	job, err := jobs.Get(ctx, jobID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Settings.Name == nil || *job.Settings.Name != "etl" {
+		t.Fatalf("Unexpected job: %+v", job)
+	}
+	_, err = jobs.Get(ctx, jobID+100)
+	expectCode(t, err, databricks.CodeInvalidParameterValue)
+
+	runID, number, err := jobs.RunNow(ctx, &databricks.JobRunNowSettings{JobID: jobID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if number != 1 {
+		t.Fatalf("Expected the first run in the job, got %d", number)
+	}
+	if err := server.SetRunOutput(runID, "42"); err != nil {
+		t.Fatal(err)
+	}
+	expectRunState(t, client, runID, databricks.LifeCycleStatePending, "")
+
+	// The second run is skipped, as only one run may be active at a time.
+	skippedID, _, err := jobs.RunNow(ctx, &databricks.JobRunNowSettings{JobID: jobID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectRunState(t, client, skippedID, databricks.LifeCycleStateSkipped, "")
+
+	server.Advance(DefaultStateDelay)
+	run := expectRunState(t, client, runID, databricks.LifeCycleStateRunning, "")
+	if run.ClusterInstance.ClusterID != clusterID {
+		t.Fatalf("Unexpected cluster instance: %+v", run.ClusterInstance)
+	}
+	err = jobs.RunsDelete(ctx, runID)
+	expectCode(t, err, databricks.CodeInvalidState)
+
+	server.Advance(2 * DefaultStateDelay)
+	expectRunState(t, client, runID, databricks.LifeCycleStateTerminated, databricks.ResultStateSuccess)
+
+	// The client can not decode run metadata in outputs yet, so the output
+	// is read directly.
+	res, err := http.Get(fmt.Sprintf(
+		"%s/api/2.0/jobs/runs/get-output?run_id=%d", server.URL, runID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	output := struct {
+		NotebookOutput databricks.NotebookOutput `json:"notebook_output"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(&output); err != nil {
+		t.Fatal(err)
+	}
+	if output.NotebookOutput.Result != "42" {
+		t.Fatalf("Unexpected output: %+v", output)
+	}
+
+	views, err := jobs.RunsExport(ctx, runID, "CODE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(views) != 1 || views[0].Name != "etl" {
+		t.Fatalf("Unexpected views: %+v", views)
+	}
+
+	if err := jobs.RunsDelete(ctx, runID); err != nil {
+		t.Fatal(err)
+	}
+	_, err = jobs.RunsGet(ctx, runID)
+	expectCode(t, err, databricks.CodeInvalidParameterValue)
+	if err := jobs.Delete(ctx, jobID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_Jobs_RunSubmit(t *testing.T) {
+	t.Parallel()
+	server, client := testClient(t, WithClock(fixedClock()))
+	jobs := client.Jobs()
+	ctx := context.Background()
+
+	clusterID := testCluster(t, client)
+	settings := &databricks.JobSubmitSettings{
+		ExistingClusterID: &clusterID,
+		SparkPythonTask:   &databricks.SparkPythonTask{PythonFile: "dbfs:/etl.py"},
+		RunName:           stringPtr("etl"),
+	}
+	_, err := jobs.RunSubmit(ctx, &databricks.JobSubmitSettings{
+		ExistingClusterID: &clusterID,
+	})
+	expectCode(t, err, databricks.CodeInvalidParameterValue)
+
+	failedID, err := jobs.RunSubmit(ctx, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := server.FailRun(failedID, "ValueError"); err != nil {
+		t.Fatal(err)
+	}
+	canceledID, err := jobs.RunSubmit(ctx, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Advance(DefaultStateDelay)
+	if err := jobs.RunsCancel(ctx, canceledID); err != nil {
+		t.Fatal(err)
+	}
+	expectRunState(t, client, canceledID, databricks.LifeCycleStateTerminating, "")
+
+	server.Advance(3 * DefaultStateDelay)
+	run := expectRunState(t, client, failedID, databricks.LifeCycleStateTerminated, databricks.ResultStateFailed)
+	if run.State.StateMessage != "ValueError" {
+		t.Fatalf("Unexpected state message: %s", run.State.StateMessage)
+	}
+	expectRunState(t, client, canceledID, databricks.LifeCycleStateTerminated, databricks.ResultStateCanceled)
+	if err := server.FailRun(failedID, "too late"); err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func Test_Jobs_ListIter(t *testing.T) {
+	t.Parallel()
+	_, client := testClient(t)
+	jobs := client.Jobs()
+	ctx := context.Background()
+
+	clusterID := testCluster(t, client)
+	for i := 0; i < 12; i++ {
+		testJob(t, client, clusterID)
+	}
+	it := jobs.ListIter(ctx, databricks.IterPageSize(5))
+	list, err := it.Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 12 || it.Pages() != 3 {
+		t.Fatalf("Expected 12 jobs in 3 pages, got %d in %d", len(list), it.Pages())
+	}
+	for i, job := range list {
+		if i > 0 && job.JobID <= list[i-1].JobID {
+			t.Fatalf("Expected jobs in order, got %+v", list)
+		}
+	}
+
+	_, _, err = jobs.ListPage(ctx, 0, 100)
+	expectCode(t, err, databricks.CodeInvalidParameterValue)
+}
@@ -0,0 +1,39 @@
+package databrickstest
+
+import (
+	"path"
+	"strings"
+)
+
+// cleanPath validates and cleans an absolute path of DBFS or the workspace.
+func cleanPath(field, p string) (string, error) {
+	if p == "" {
+		return "", missingField(field)
+	}
+	if !strings.HasPrefix(p, "/") {
+		return "", invalidParameter("Path (%s) must be absolute", p)
+	}
+	return path.Clean(p), nil
+}
+
+// parents returns the ancestors of a clean path, from the root down.
+func parents(p string) []string {
+	var dirs []string
+	for dir := path.Dir(p); dir != p; p, dir = dir, path.Dir(dir) {
+		dirs = append([]string{dir}, dirs...)
+	}
+	return dirs
+}
+
+// isChild returns whether p is a direct child of dir.
+func isChild(dir, p string) bool {
+	return p != dir && path.Dir(p) == dir
+}
+
+// isDescendant returns whether p is below dir.
+func isDescendant(dir, p string) bool {
+	if dir == "/" {
+		return p != "/"
+	}
+	return strings.HasPrefix(p, dir+"/")
+}
@@ -0,0 +1,232 @@
+package databrickstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/medivo/databricks-go"
+)
+
+// mapAttributes are the cluster attributes whose keys may contain dots, so
+// that the rest of a policy path after them is a single key.
+var mapAttributes = map[string]bool{
+	"custom_tags":    true,
+	"spark_conf":     true,
+	"spark_env_vars": true,
+}
+
+// virtualAttributes are policy attributes that are not part of a cluster
+// spec.
+var virtualAttributes = map[string]bool{
+	"cluster_type":  true,
+	"dbus_per_hour": true,
+}
+
+func (s *Server) policiesRoutes() map[string]route {
+	return map[string]route{
+		"policies/clusters/create": {http.MethodPost, s.policiesCreate},
+		"policies/clusters/delete": {http.MethodPost, s.policiesDelete},
+		"policies/clusters/edit":   {http.MethodPost, s.policiesEdit},
+		"policies/clusters/get":    {http.MethodGet, s.policiesGet},
+		"policies/clusters/list":   {http.MethodGet, s.policiesList},
+	}
+}
+
+func (s *Server) policy(id string) (*databricks.Policy, error) {
+	if id == "" {
+		return nil, missingField("policy_id")
+	}
+	p, ok := s.policies[id]
+	if !ok {
+		return nil, notFound("Can't find a cluster policy with id: %s.", id)
+	}
+	return p, nil
+}
+
+// checkPolicy validates a policy of a create or edit request.
+func (s *Server) checkPolicy(p *databricks.Policy) error {
+	if p.Name == "" {
+		return missingField("name")
+	}
+	for id, other := range s.policies {
+		if other.Name == p.Name && id != p.PolicyID {
+			return invalidParameter(
+				"Cluster policy named %s already exists.", p.Name)
+		}
+	}
+	return nil
+}
+
+func (s *Server) policiesCreate(r *http.Request) (interface{}, error) {
+	p := &databricks.Policy{}
+	if err := decode(r, p); err != nil {
+		return nil, err
+	}
+	p.PolicyID = ""
+	if err := s.checkPolicy(p); err != nil {
+		return nil, err
+	}
+	p.PolicyID = fmt.Sprintf("%016X", s.id())
+	p.CreatorUserName = s.userName
+	p.CreatedAtTimestamp = databricks.EpochMillis(s.millis())
+	s.policies[p.PolicyID] = p
+	return map[string]string{"policy_id": p.PolicyID}, nil
+}
+
+func (s *Server) policiesEdit(r *http.Request) (interface{}, error) {
+	p := &databricks.Policy{}
+	if err := decode(r, p); err != nil {
+		return nil, err
+	}
+	previous, err := s.policy(p.PolicyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkPolicy(p); err != nil {
+		return nil, err
+	}
+	p.CreatorUserName = previous.CreatorUserName
+	p.CreatedAtTimestamp = previous.CreatedAtTimestamp
+	s.policies[p.PolicyID] = p
+	return nil, nil
+}
+
+func (s *Server) policiesGet(r *http.Request) (interface{}, error) {
+	return s.policy(r.URL.Query().Get("policy_id"))
+}
+
+func (s *Server) policiesList(r *http.Request) (interface{}, error) {
+	policies := []*databricks.Policy{}
+	for _, p := range s.policies {
+		policies = append(policies, p)
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+	return map[string]interface{}{
+		"policies":    policies,
+		"total_count": len(policies),
+	}, nil
+}
+
+func (s *Server) policiesDelete(r *http.Request) (interface{}, error) {
+	req := struct {
+		PolicyID string `json:"policy_id"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if _, err := s.policy(req.PolicyID); err != nil {
+		return nil, err
+	}
+	delete(s.policies, req.PolicyID)
+	return nil, nil
+}
+
+// applyPolicy sets the fixed values of the policy of a cluster spec, and
+// its default values if the spec applies them, then validates the spec
+// against the policy.
+func (s *Server) applyPolicy(spec map[string]interface{}) error {
+	id, _ := spec["policy_id"].(string)
+	if id == "" {
+		return nil
+	}
+	p, ok := s.policies[id]
+	if !ok {
+		return invalidParameter("Cluster policy %s does not exist.", id)
+	}
+	applyDefaults, _ := spec["apply_policy_default_values"].(bool)
+	for path, rule := range p.Definition {
+		if strings.Contains(path, "*") || virtualAttributes[path] {
+			continue
+		}
+		switch {
+		case rule.Type == databricks.PolicyFixed:
+			setAttribute(spec, path, rule.Value)
+		case rule.DefaultValue != nil && applyDefaults &&
+			!hasAttribute(spec, path):
+			setAttribute(spec, path, rule.DefaultValue)
+		}
+	}
+
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	var clusterSpec databricks.ClusterSpec
+	if err := json.Unmarshal(raw, &clusterSpec); err != nil {
+		return invalidParameter("Invalid cluster spec: %s", err)
+	}
+	violations, err := p.Evaluate(&clusterSpec)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		var messages []string
+		for _, violation := range violations {
+			messages = append(messages, violation.String())
+		}
+		return invalidParameter("Validation failed for policy %s: %s",
+			p.Name, strings.Join(messages, ", "))
+	}
+	return nil
+}
+
+// setAttribute sets the attribute of a cluster spec at a policy path.
+func setAttribute(spec map[string]interface{}, path string, value interface{}) {
+	parts := strings.SplitN(path, ".", 2)
+	if len(parts) == 1 {
+		spec[path] = jsonValue(value)
+		return
+	}
+	child, ok := spec[parts[0]].(map[string]interface{})
+	if !ok {
+		child = map[string]interface{}{}
+		spec[parts[0]] = child
+	}
+	if mapAttributes[parts[0]] {
+		// The values of these attributes are strings.
+		child[parts[1]] = fmt.Sprint(value)
+		return
+	}
+	setAttribute(child, parts[1], value)
+}
+
+// hasAttribute returns whether the attribute of a cluster spec at a policy
+// path is set.
+func hasAttribute(spec map[string]interface{}, path string) bool {
+	parts := strings.SplitN(path, ".", 2)
+	value, ok := spec[parts[0]]
+	if !ok || len(parts) == 1 {
+		return ok
+	}
+	child, ok := value.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	if mapAttributes[parts[0]] {
+		_, ok := child[parts[1]]
+		return ok
+	}
+	return hasAttribute(child, parts[1])
+}
+
+// jsonValue converts the numbers of a policy value to json.Number, as
+// decoded from requests.
+func jsonValue(value interface{}) interface{} {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return value
+	}
+	return decoded
+}
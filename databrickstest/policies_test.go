@@ -0,0 +1,90 @@
+package databrickstest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/medivo/databricks-go"
+)
+
+func Test_Policies(t *testing.T) {
+	t.Parallel()
+	_, client := testClient(t)
+	policies := client.ClusterPolicies()
+	ctx := context.Background()
+
+	policy := &databricks.Policy{
+		Name: "etl",
+		Definition: databricks.PolicyDefinition{
+			"node_type_id": {
+				Type:   databricks.PolicyAllowlist,
+				Values: []interface{}{"i3.xlarge"},
+			},
+			"autotermination_minutes": {Type: databricks.PolicyFixed, Value: 30},
+		},
+	}
+	id, err := policies.Create(ctx, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = policies.Create(ctx, policy)
+	expectCode(t, err, databricks.CodeInvalidParameterValue)
+
+	got, err := policies.Get(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "etl" || got.CreatorUserName != DefaultUserName ||
+		got.Definition["autotermination_minutes"].Value != 30.0 {
+		t.Fatalf("Unexpected policy: %+v", got)
+	}
+	_, err = policies.Get(ctx, "missing")
+	expectCode(t, err, databricks.CodeResourceDoesNotExist)
+
+	// Fixed values are set, and values that the policy rejects fail.
+	clusterID, err := client.Cluster().Create(ctx, &databricks.ClusterCreateRequest{
+		ClusterSpec: databricks.ClusterSpec{
+			ClusterName:  "etl",
+			SparkVersion: "7.3.x-scala2.12",
+			NodeTypeID:   "i3.xlarge",
+			PolicyID:     id,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := client.Cluster().Get(ctx, clusterID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.AutoterminationMinutes == nil || *info.AutoterminationMinutes != 30 {
+		t.Fatalf("Expected the fixed value to be set, got %+v", info.ClusterSpec)
+	}
+	_, err = client.Cluster().Create(ctx, &databricks.ClusterCreateRequest{
+		ClusterSpec: databricks.ClusterSpec{
+			ClusterName:  "etl",
+			SparkVersion: "7.3.x-scala2.12",
+			NodeTypeID:   "p3.16xlarge",
+			PolicyID:     id,
+		},
+	})
+	expectCode(t, err, databricks.CodeInvalidParameterValue)
+
+	got.Description = "ETL clusters"
+	if err := policies.Edit(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	list, err := policies.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Description != "ETL clusters" {
+		t.Fatalf("Unexpected policies: %+v", list)
+	}
+
+	if err := policies.Delete(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	err = policies.Delete(ctx, id)
+	expectCode(t, err, databricks.CodeResourceDoesNotExist)
+}
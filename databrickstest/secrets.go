@@ -0,0 +1,312 @@
+package databrickstest
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/medivo/databricks-go"
+)
+
+// Secret limits of the API.
+const (
+	maxSecretSize      = 128 << 10
+	maxSecretsPerScope = 1000
+)
+
+// validSecretName matches valid scope names and secret keys.
+var validSecretName = regexp.MustCompile(`^[\w.-]{1,128}$`)
+
+// secretPermissions are the valid secret ACL permissions.
+var secretPermissions = map[string]bool{
+	"READ":   true,
+	"WRITE":  true,
+	"MANAGE": true,
+}
+
+type secret struct {
+	value   []byte
+	updated int64
+}
+
+type secretScope struct {
+	secrets map[string]*secret
+	acls    map[string]string
+}
+
+func (s *Server) secretsRoutes() map[string]route {
+	return map[string]route{
+		"secrets/acls/delete":   {http.MethodPost, s.secretsDeleteACL},
+		"secrets/acls/get":      {http.MethodGet, s.secretsGetACL},
+		"secrets/acls/list":     {http.MethodGet, s.secretsListACLs},
+		"secrets/acls/put":      {http.MethodPost, s.secretsPutACL},
+		"secrets/delete":        {http.MethodPost, s.secretsDelete},
+		"secrets/list":          {http.MethodGet, s.secretsList},
+		"secrets/put":           {http.MethodPost, s.secretsPut},
+		"secrets/scopes/create": {http.MethodPost, s.secretsCreateScope},
+		"secrets/scopes/delete": {http.MethodPost, s.secretsDeleteScope},
+		"secrets/scopes/list":   {http.MethodGet, s.secretsListScopes},
+	}
+}
+
+// Secret returns the value of a secret, e.g. to check what the code under
+// test stored.
+func (s *Server) Secret(scope, key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sc, ok := s.secrets[scope]
+	if !ok {
+		return nil, false
+	}
+	sec, ok := sc.secrets[key]
+	if !ok {
+		return nil, false
+	}
+	return append([]byte{}, sec.value...), true
+}
+
+func (s *Server) secretScope(name string) (*secretScope, error) {
+	if name == "" {
+		return nil, missingField("scope")
+	}
+	sc, ok := s.secrets[name]
+	if !ok {
+		return nil, notFound("Scope %s does not exist!", name)
+	}
+	return sc, nil
+}
+
+func (s *Server) secretsCreateScope(r *http.Request) (interface{}, error) {
+	req := struct {
+		Scope                  string `json:"scope"`
+		InitialManagePrincipal string `json:"initial_manage_principal"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Scope == "" {
+		return nil, missingField("scope")
+	}
+	if !validSecretName.MatchString(req.Scope) {
+		return nil, invalidParameter(
+			"Scope name %q must consist of alphanumeric characters, dashes, "+
+				"underscores, and periods, and may not exceed 128 characters.",
+			req.Scope,
+		)
+	}
+	if _, ok := s.secrets[req.Scope]; ok {
+		return nil, alreadyExists("Scope %s already exists!", req.Scope)
+	}
+	acls := map[string]string{s.userName: "MANAGE"}
+	switch req.InitialManagePrincipal {
+	case "":
+	case "users":
+		acls["users"] = "MANAGE"
+	default:
+		return nil, invalidParameter(
+			"The initial manage principal must be 'users', got %q.",
+			req.InitialManagePrincipal,
+		)
+	}
+	s.secrets[req.Scope] = &secretScope{
+		secrets: map[string]*secret{},
+		acls:    acls,
+	}
+	return nil, nil
+}
+
+func (s *Server) secretsDeleteScope(r *http.Request) (interface{}, error) {
+	req := struct {
+		Scope string `json:"scope"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if _, err := s.secretScope(req.Scope); err != nil {
+		return nil, err
+	}
+	delete(s.secrets, req.Scope)
+	return nil, nil
+}
+
+func (s *Server) secretsListScopes(r *http.Request) (interface{}, error) {
+	scopes := []databricks.SecretScope{}
+	for name := range s.secrets {
+		scopes = append(scopes, databricks.SecretScope{
+			Name:        name,
+			BackendType: "DATABRICKS",
+		})
+	}
+	sort.Slice(scopes, func(i, j int) bool {
+		return scopes[i].Name < scopes[j].Name
+	})
+	return map[string][]databricks.SecretScope{"scopes": scopes}, nil
+}
+
+func (s *Server) secretsPut(r *http.Request) (interface{}, error) {
+	req := struct {
+		Scope       string  `json:"scope"`
+		Key         string  `json:"key"`
+		StringValue *string `json:"string_value"`
+		BytesValue  []byte  `json:"bytes_value"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	sc, err := s.secretScope(req.Scope)
+	if err != nil {
+		return nil, err
+	}
+	if req.Key == "" {
+		return nil, missingField("key")
+	}
+	if !validSecretName.MatchString(req.Key) {
+		return nil, invalidParameter(
+			"Secret key %q must consist of alphanumeric characters, dashes, "+
+				"underscores, and periods, and may not exceed 128 characters.",
+			req.Key,
+		)
+	}
+	if req.StringValue != nil && req.BytesValue != nil {
+		return nil, invalidParameter(
+			"Only one of string_value and bytes_value can be set.")
+	}
+	value := req.BytesValue
+	if req.StringValue != nil {
+		value = []byte(*req.StringValue)
+	}
+	if len(value) > maxSecretSize {
+		return nil, invalidParameter(
+			"The secret value exceeds the limit of %d bytes.", maxSecretSize)
+	}
+	if _, ok := sc.secrets[req.Key]; !ok && len(sc.secrets) >= maxSecretsPerScope {
+		return nil, newError(
+			http.StatusBadRequest,
+			databricks.CodeQuotaExceeded,
+			"Scope %s has reached the limit of %d secrets.",
+			req.Scope, maxSecretsPerScope,
+		)
+	}
+	sc.secrets[req.Key] = &secret{value: value, updated: s.millis()}
+	return nil, nil
+}
+
+func (s *Server) secretsDelete(r *http.Request) (interface{}, error) {
+	req := struct {
+		Scope string `json:"scope"`
+		Key   string `json:"key"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	sc, err := s.secretScope(req.Scope)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := sc.secrets[req.Key]; !ok {
+		return nil, notFound(
+			"Secret %s does not exist in scope %s!", req.Key, req.Scope)
+	}
+	delete(sc.secrets, req.Key)
+	return nil, nil
+}
+
+func (s *Server) secretsList(r *http.Request) (interface{}, error) {
+	sc, err := s.secretScope(r.URL.Query().Get("scope"))
+	if err != nil {
+		return nil, err
+	}
+	secrets := []databricks.SecretMetadata{}
+	for key, sec := range sc.secrets {
+		secrets = append(secrets, databricks.SecretMetadata{
+			Key:                  key,
+			LastUpdatedTimestamp: databricks.EpochMillis(sec.updated),
+		})
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Key < secrets[j].Key
+	})
+	return map[string][]databricks.SecretMetadata{"secrets": secrets}, nil
+}
+
+func (s *Server) secretsPutACL(r *http.Request) (interface{}, error) {
+	req := struct {
+		Scope      string `json:"scope"`
+		Principal  string `json:"principal"`
+		Permission string `json:"permission"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	sc, err := s.secretScope(req.Scope)
+	if err != nil {
+		return nil, err
+	}
+	if req.Principal == "" {
+		return nil, missingField("principal")
+	}
+	if !secretPermissions[req.Permission] {
+		return nil, invalidParameter(
+			"Invalid permission %q, must be one of READ, WRITE or MANAGE.",
+			req.Permission,
+		)
+	}
+	sc.acls[req.Principal] = req.Permission
+	return nil, nil
+}
+
+func (s *Server) secretsDeleteACL(r *http.Request) (interface{}, error) {
+	req := struct {
+		Scope     string `json:"scope"`
+		Principal string `json:"principal"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	sc, err := s.secretScope(req.Scope)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := sc.acls[req.Principal]; !ok {
+		return nil, notFound(
+			"Failed to delete ACL for principal %s in scope %s: it does not "+
+				"exist.", req.Principal, req.Scope)
+	}
+	delete(sc.acls, req.Principal)
+	return nil, nil
+}
+
+func (s *Server) secretsGetACL(r *http.Request) (interface{}, error) {
+	q := r.URL.Query()
+	sc, err := s.secretScope(q.Get("scope"))
+	if err != nil {
+		return nil, err
+	}
+	principal := q.Get("principal")
+	permission, ok := sc.acls[principal]
+	if !ok {
+		return nil, notFound(
+			"Failed to get ACL for principal %s in scope %s: it does not exist.",
+			principal, q.Get("scope"),
+		)
+	}
+	return databricks.ACLItem{Principal: principal, Permission: databricks.ACLPermission(permission)}, nil
+}
+
+func (s *Server) secretsListACLs(r *http.Request) (interface{}, error) {
+	sc, err := s.secretScope(r.URL.Query().Get("scope"))
+	if err != nil {
+		return nil, err
+	}
+	items := []databricks.ACLItem{}
+	for principal, permission := range sc.acls {
+		items = append(items, databricks.ACLItem{
+			Principal:  principal,
+			Permission: databricks.ACLPermission(permission),
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Principal < items[j].Principal
+	})
+	return map[string][]databricks.ACLItem{"items": items}, nil
+}
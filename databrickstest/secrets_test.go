@@ -0,0 +1,75 @@
+package databrickstest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/medivo/databricks-go"
+)
+
+func Test_Secrets(t *testing.T) {
+	t.Parallel()
+	server, client := testClient(t)
+	secrets := client.Secrets()
+	ctx := context.Background()
+
+	err := secrets.PutSecret(ctx, "etl", "password", "hunter2")
+	expectCode(t, err, databricks.CodeResourceDoesNotExist)
+
+	if err := secrets.CreateSecretScope(ctx, "etl", ""); err != nil {
+		t.Fatal(err)
+	}
+	err = secrets.CreateSecretScope(ctx, "etl", "")
+	expectCode(t, err, databricks.CodeResourceAlreadyExists)
+	err = secrets.CreateSecretScope(ctx, "bad scope", "")
+	expectCode(t, err, databricks.CodeInvalidParameterValue)
+
+	scopes, err := secrets.ListSecretScopes(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scopes) != 1 || scopes[0].Name != "etl" || scopes[0].BackendType != databricks.ScopeBackendDatabricks {
+		t.Fatalf("Unexpected scopes: %+v", scopes)
+	}
+
+	if err := secrets.PutSecret(ctx, "etl", "password", "hunter2"); err != nil {
+		t.Fatal(err)
+	}
+	if value, ok := server.Secret("etl", "password"); !ok || string(value) != "hunter2" {
+		t.Fatalf("Unexpected secret: %q", value)
+	}
+	if err := secrets.DeleteSecret(ctx, "etl", "password"); err != nil {
+		t.Fatal(err)
+	}
+	err = secrets.DeleteSecret(ctx, "etl", "password")
+	expectCode(t, err, databricks.CodeResourceDoesNotExist)
+
+	if err := secrets.PutSecretACL(ctx, "etl", "data-eng", "READ"); err != nil {
+		t.Fatal(err)
+	}
+	err = secrets.PutSecretACL(ctx, "etl", "data-eng", "OWN")
+	expectCode(t, err, databricks.CodeInvalidParameterValue)
+	permission, err := secrets.GetSecretACL(ctx, "etl", "data-eng")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if permission != "READ" {
+		t.Fatalf("Unexpected permission: %s", permission)
+	}
+	acls, err := secrets.ListSecretACLs(ctx, "etl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(acls) != 2 {
+		t.Fatalf("Expected the creator and data-eng ACLs, got %+v", acls)
+	}
+	if err := secrets.DeleteSecretACL(ctx, "etl", "data-eng"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := secrets.DeleteSecretScope(ctx, "etl"); err != nil {
+		t.Fatal(err)
+	}
+	err = secrets.DeleteSecretScope(ctx, "etl")
+	expectCode(t, err, databricks.CodeResourceDoesNotExist)
+}
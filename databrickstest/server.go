@@ -0,0 +1,386 @@
+// Package databrickstest provides an in-memory fake of a Databricks
+// workspace for testing code that uses a databricks.Client.
+//
+// The fake serves the DBFS, workspace, secrets, groups, token, clusters,
+// cluster policies and jobs APIs over HTTP and keeps their state in memory,
+// so multi-step flows such as DBFS Create, AddBlock and Close or RunNow
+// followed by RunsGet behave as they do against a real workspace. Errors are
+// returned with the same status and error_code as the real API.
+//
+// Clusters and runs move through their transient states (e.g. PENDING to
+// RUNNING) once they have spent the configured state delay in them. The
+// delay is measured on the server's clock, which tests can move forward
+// with Advance instead of sleeping:
+//
+//	server := databrickstest.NewServer()
+//	defer server.Close()
+//	client, _ := server.Client()
+//	clusterID, _ := client.Cluster().Create(ctx, req)
+//	server.Advance(databrickstest.DefaultStateDelay)
+//	info, _ := client.Cluster().Get(ctx, clusterID) // info.State is RUNNING
+package databrickstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/medivo/databricks-go"
+)
+
+// DefaultStateDelay is how long clusters and runs stay in each transient
+// state by default.
+const DefaultStateDelay = 100 * time.Millisecond
+
+// DefaultUserName is the user name of the caller by default.
+const DefaultUserName = "user@example.com"
+
+// Option is used for configuring a Server.
+type Option func(*Server)
+
+// WithToken configures the personal access token the Server requires. By
+// default any request is accepted. Tokens created with the token API are
+// accepted as well until they are revoked.
+func WithToken(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// WithUserName configures the user name of the caller, which is reported as
+// the creator of clusters, jobs and runs.
+func WithUserName(userName string) Option {
+	return func(s *Server) {
+		s.userName = userName
+	}
+}
+
+// WithStateDelay configures how long clusters and runs stay in each
+// transient state. It defaults to DefaultStateDelay.
+func WithStateDelay(delay time.Duration) Option {
+	return func(s *Server) {
+		s.delay = delay
+	}
+}
+
+// WithClock configures the clock of the Server. It defaults to time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.clock = now
+	}
+}
+
+// Server is an in-memory fake of a Databricks workspace.
+type Server struct {
+	// URL is the workspace URL of the Server, for use with
+	// databricks.ClientHost.
+	URL string
+
+	server *httptest.Server
+	routes map[string]route
+
+	token    string
+	userName string
+	delay    time.Duration
+	clock    func() time.Time
+
+	mu       sync.Mutex
+	offset   time.Duration
+	nextID   int64
+	dbfs     *dbfsState
+	ws       *workspaceState
+	secrets  map[string]*secretScope
+	groups   map[string]*group
+	tokens   map[string]*token
+	clusters map[string]*cluster
+	policies map[string]*databricks.Policy
+	jobs     map[int64]*job
+	runs     map[int64]*run
+}
+
+// NewServer starts and returns a new Server. The caller should call Close
+// when finished, to shut it down.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		userName: DefaultUserName,
+		delay:    DefaultStateDelay,
+		clock:    time.Now,
+		dbfs:     newDBFSState(),
+		ws:       newWorkspaceState(),
+		secrets:  map[string]*secretScope{},
+		groups:   newGroups(),
+		tokens:   map[string]*token{},
+		clusters: map[string]*cluster{},
+		policies: map[string]*databricks.Policy{},
+		jobs:     map[int64]*job{},
+		runs:     map[int64]*run{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.groups[adminsGroup].users[s.userName] = true
+	s.groups[usersGroup].users[s.userName] = true
+	s.routes = s.newRoutes()
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts down the Server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a databricks.Client for the Server. It is authenticated
+// with the token configured with WithToken. Additional options are applied
+// after the host and credentials.
+func (s *Server) Client(opts ...databricks.ClientOpt) (*databricks.Client, error) {
+	token := s.token
+	if token == "" {
+		token = "dapi-databrickstest"
+	}
+	return databricks.NewClient("", append([]databricks.ClientOpt{
+		databricks.ClientHost(s.URL),
+		databricks.ClientCredentials(databricks.BearerCredentials(token)),
+	}, opts...)...)
+}
+
+// Advance moves the clock of the Server forward, e.g. by the state delay to
+// let clusters and runs reach their next state.
+func (s *Server) Advance(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offset += d
+	s.step()
+}
+
+// now returns the current time of the Server.
+func (s *Server) now() time.Time {
+	return s.clock().Add(s.offset)
+}
+
+// millis returns the current time of the Server in epoch milliseconds.
+func (s *Server) millis() int64 {
+	return s.now().UnixNano() / int64(time.Millisecond)
+}
+
+// id returns a new identifier, unique across the Server.
+func (s *Server) id() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+// step moves clusters and runs to their next state once they have spent
+// the state delay in their current one.
+func (s *Server) step() {
+	now := s.now()
+	for _, c := range s.clusters {
+		for c.transient() && !now.Before(c.since.Add(s.delay)) {
+			c.since = c.since.Add(s.delay)
+			s.stepCluster(c)
+		}
+	}
+	for _, r := range s.runs {
+		for !r.terminal() && !now.Before(r.since.Add(s.delay)) {
+			r.since = r.since.Add(s.delay)
+			s.stepRun(r)
+		}
+	}
+}
+
+// handler handles an API request. It returns the value to encode as the
+// JSON response, or an error.
+type handler func(r *http.Request) (interface{}, error)
+
+type route struct {
+	method string
+	handle handler
+}
+
+func (s *Server) newRoutes() map[string]route {
+	routes := map[string]route{}
+	for _, endpoints := range []map[string]route{
+		s.dbfsRoutes(),
+		s.workspaceRoutes(),
+		s.secretsRoutes(),
+		s.groupsRoutes(),
+		s.tokenRoutes(),
+		s.clusterRoutes(),
+		s.policiesRoutes(),
+		s.jobsRoutes(),
+	} {
+		for endpoint, r := range endpoints {
+			routes["/api/2.0/"+endpoint] = r
+		}
+	}
+	return routes
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	route, ok := s.routes[r.URL.Path]
+	if !ok {
+		writeError(w, newError(
+			http.StatusNotFound,
+			codeEndpointNotFound,
+			"No API found for '%s %s'", r.Method, r.URL.Path,
+		))
+		return
+	}
+	if r.Method != route.method {
+		writeError(w, newError(
+			http.StatusMethodNotAllowed,
+			codeBadRequest,
+			"Method %s is not supported by %s", r.Method, r.URL.Path,
+		))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.authenticate(r); err != nil {
+		writeError(w, err)
+		return
+	}
+	s.step()
+	res, err := route.handle(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if raw, ok := res.([]byte); ok {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(raw)
+		return
+	}
+	if res == nil {
+		res = struct{}{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func (s *Server) authenticate(r *http.Request) error {
+	if s.token == "" {
+		return nil
+	}
+	value := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if value == s.token {
+		return nil
+	}
+	for _, t := range s.tokens {
+		if t.value == value && (t.expiry < 0 || s.millis() < t.expiry) {
+			return nil
+		}
+	}
+	return newError(
+		http.StatusUnauthorized,
+		databricks.CodeUnauthenticated,
+		"Invalid access token.",
+	)
+}
+
+// Error codes returned by the fake that the client does not define.
+const (
+	codeEndpointNotFound databricks.ErrorCode = "ENDPOINT_NOT_FOUND"
+	codeMalformedRequest databricks.ErrorCode = "MALFORMED_REQUEST"
+	codeBadRequest                            = databricks.CodeBadRequest
+)
+
+// apiError is an error response of the API.
+type apiError struct {
+	status  int
+	code    databricks.ErrorCode
+	message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s: %s", e.code, e.message)
+}
+
+func newError(
+	status int,
+	code databricks.ErrorCode,
+	format string,
+	args ...interface{},
+) error {
+	return &apiError{status, code, fmt.Sprintf(format, args...)}
+}
+
+func notFound(format string, args ...interface{}) error {
+	return newError(
+		http.StatusNotFound, databricks.CodeResourceDoesNotExist, format, args...)
+}
+
+func alreadyExists(format string, args ...interface{}) error {
+	return newError(
+		http.StatusBadRequest, databricks.CodeResourceAlreadyExists, format, args...)
+}
+
+func invalidParameter(format string, args ...interface{}) error {
+	return newError(
+		http.StatusBadRequest, databricks.CodeInvalidParameterValue, format, args...)
+}
+
+func invalidState(format string, args ...interface{}) error {
+	return newError(
+		http.StatusBadRequest, databricks.CodeInvalidState, format, args...)
+}
+
+func missingField(field string) error {
+	return invalidParameter("Missing required field: %s", field)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	apiErr, ok := err.(*apiError)
+	if !ok {
+		apiErr = &apiError{
+			http.StatusInternalServerError,
+			databricks.CodeInternalError,
+			err.Error(),
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(apiErr.status)
+	json.NewEncoder(w).Encode(struct {
+		ErrorCode databricks.ErrorCode `json:"error_code"`
+		Message   string               `json:"message"`
+	}{apiErr.code, apiErr.message})
+}
+
+// decode decodes the JSON body of a request.
+func decode(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return newError(
+			http.StatusBadRequest,
+			codeMalformedRequest,
+			"Invalid JSON given in the body of the request: %s", err,
+		)
+	}
+	return nil
+}
+
+// queryInt64 returns an integer query parameter, or def if it is not set.
+func queryInt64(r *http.Request, key string, def int64) (int64, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return def, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, invalidParameter("Invalid value for %s: %q", key, value)
+	}
+	return n, nil
+}
+
+// queryBool returns a boolean query parameter.
+func queryBool(r *http.Request, key string) bool {
+	value, _ := strconv.ParseBool(r.URL.Query().Get(key))
+	return value
+}
@@ -0,0 +1,90 @@
+package databrickstest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/medivo/databricks-go"
+)
+
+// testClient returns a client for a new Server, which is closed when the
+// test finishes.
+func testClient(t *testing.T, opts ...Option) (*Server, *databricks.Client) {
+	t.Helper()
+	server := NewServer(opts...)
+	t.Cleanup(server.Close)
+	client, err := server.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server, client
+}
+
+// expectCode fails the test unless err is an *APIError with the given
+// error code.
+func expectCode(t *testing.T, err error, code databricks.ErrorCode) {
+	t.Helper()
+	var apiErr *databricks.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected an APIError with code %s, got %v", code, err)
+	}
+	if apiErr.ErrorCode != code {
+		t.Fatalf("Expected error code %s, got %s", code, apiErr.ErrorCode)
+	}
+}
+
+func Test_Server_Token(t *testing.T) {
+	t.Parallel()
+	server, client := testClient(t, WithToken("dapi-secret"))
+	ctx := context.Background()
+
+	if _, err := client.Groups().Groups(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := databricks.NewClient("",
+		databricks.ClientHost(server.URL),
+		databricks.ClientCredentials(databricks.BearerCredentials("dapi-wrong")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = other.Groups().Groups(ctx)
+	expectCode(t, err, databricks.CodeUnauthenticated)
+
+	// Tokens created with the token API are accepted until they expire.
+	value, _, err := client.Token().Create(ctx, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	created, err := databricks.NewClient("",
+		databricks.ClientHost(server.URL),
+		databricks.ClientCredentials(databricks.BearerCredentials(value)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := created.Groups().Groups(ctx); err != nil {
+		t.Fatal(err)
+	}
+	server.Advance(time.Minute)
+	_, err = created.Groups().Groups(ctx)
+	expectCode(t, err, databricks.CodeUnauthenticated)
+}
+
+func Test_Server_UnknownEndpoint(t *testing.T) {
+	t.Parallel()
+	server, _ := testClient(t)
+
+	res, err := http.Get(server.URL + "/api/2.0/unknown/endpoint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNotFound {
+		t.Fatalf("Expected status %d, got %d", http.StatusNotFound, res.StatusCode)
+	}
+}
@@ -0,0 +1,109 @@
+package databrickstest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/medivo/databricks-go"
+)
+
+// maxTokens is the number of tokens a user can have.
+const maxTokens = 600
+
+type token struct {
+	value   string
+	created int64
+	expiry  int64
+	comment string
+}
+
+func (s *Server) tokenRoutes() map[string]route {
+	return map[string]route{
+		"token/create": {http.MethodPost, s.tokenCreate},
+		"token/delete": {http.MethodPost, s.tokenDelete},
+		"token/list":   {http.MethodGet, s.tokenList},
+	}
+}
+
+func (s *Server) tokenInfo(id string) databricks.PublicTokenInfo {
+	t := s.tokens[id]
+	return databricks.PublicTokenInfo{
+		TokenID:      id,
+		CreationTime: databricks.EpochMillis(t.created),
+		ExpiryTime:   databricks.EpochMillis(t.expiry),
+		Comment:      t.comment,
+	}
+}
+
+func (s *Server) tokenCreate(r *http.Request) (interface{}, error) {
+	req := struct {
+		LifetimeSeconds int64  `json:"lifetime_seconds"`
+		Comment         string `json:"comment"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.LifetimeSeconds < 0 {
+		return nil, invalidParameter(
+			"The token lifetime (%d) must be positive.", req.LifetimeSeconds)
+	}
+	if len(s.tokens) >= maxTokens {
+		return nil, newError(
+			http.StatusBadRequest,
+			databricks.CodeQuotaExceeded,
+			"Cannot create more than %d tokens.", maxTokens,
+		)
+	}
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	t := &token{
+		value:   "dapi" + hex.EncodeToString(raw),
+		created: s.millis(),
+		expiry:  -1,
+		comment: req.Comment,
+	}
+	if req.LifetimeSeconds > 0 {
+		t.expiry = t.created + req.LifetimeSeconds*1000
+	}
+	id := strconv.FormatInt(s.id(), 10)
+	s.tokens[id] = t
+	return struct {
+		TokenValue string                     `json:"token_value"`
+		TokenInfo  databricks.PublicTokenInfo `json:"token_info"`
+	}{t.value, s.tokenInfo(id)}, nil
+}
+
+func (s *Server) tokenDelete(r *http.Request) (interface{}, error) {
+	req := struct {
+		TokenID string `json:"token_id"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.TokenID == "" {
+		return nil, missingField("token_id")
+	}
+	if _, ok := s.tokens[req.TokenID]; !ok {
+		return nil, notFound("Token with ID %s does not exist.", req.TokenID)
+	}
+	delete(s.tokens, req.TokenID)
+	return nil, nil
+}
+
+func (s *Server) tokenList(r *http.Request) (interface{}, error) {
+	infos := []databricks.PublicTokenInfo{}
+	for id := range s.tokens {
+		infos = append(infos, s.tokenInfo(id))
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].CreationTime < infos[j].CreationTime ||
+			infos[i].CreationTime == infos[j].CreationTime &&
+				infos[i].TokenID < infos[j].TokenID
+	})
+	return map[string][]databricks.PublicTokenInfo{"token_infos": infos}, nil
+}
@@ -0,0 +1,50 @@
+package databrickstest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/medivo/databricks-go"
+)
+
+func Test_Token(t *testing.T) {
+	t.Parallel()
+	_, client := testClient(t)
+	tokens := client.Token()
+	ctx := context.Background()
+
+	value, info, err := tokens.Create(ctx, 3600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value == "" || info == nil || info.TokenID == "" {
+		t.Fatalf("Unexpected token %q: %+v", value, info)
+	}
+	if info.ExpiryTime != info.CreationTime+3600*1000 {
+		t.Fatalf("Unexpected expiry: %+v", info)
+	}
+	if _, _, err := tokens.Create(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	infos, err := tokens.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 2 || infos[1].ExpiryTime != -1 {
+		t.Fatalf("Unexpected tokens: %+v", infos)
+	}
+
+	if err := tokens.Revoke(ctx, infos); err != nil {
+		t.Fatal(err)
+	}
+	err = tokens.Revoke(ctx, infos[:1])
+	expectCode(t, err, databricks.CodeResourceDoesNotExist)
+	infos, err = tokens.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 0 {
+		t.Fatalf("Expected no tokens, got %+v", infos)
+	}
+}
@@ -0,0 +1,244 @@
+package databrickstest
+
+import (
+	"encoding/base64"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/medivo/databricks-go"
+)
+
+// maxNotebookSize is the size limit of a notebook export.
+const maxNotebookSize = 10 << 20
+
+type workspaceObject struct {
+	objectType databricks.ObjectType
+	language   databricks.Language
+	content    []byte
+}
+
+type workspaceState struct {
+	objects map[string]*workspaceObject
+}
+
+func newWorkspaceState() *workspaceState {
+	return &workspaceState{
+		objects: map[string]*workspaceObject{
+			"/": {objectType: databricks.Directory},
+		},
+	}
+}
+
+func (s *Server) workspaceRoutes() map[string]route {
+	return map[string]route{
+		"workspace/delete":     {http.MethodPost, s.workspaceDelete},
+		"workspace/export":     {http.MethodGet, s.workspaceExport},
+		"workspace/get-status": {http.MethodGet, s.workspaceGetStatus},
+		"workspace/import":     {http.MethodPost, s.workspaceImport},
+		"workspace/list":       {http.MethodGet, s.workspaceList},
+		"workspace/mkdirs":     {http.MethodPost, s.workspaceMkdirs},
+	}
+}
+
+// Notebook returns the contents of a notebook as it was imported, e.g. to
+// check what the code under test uploaded.
+func (s *Server) Notebook(p string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.ws.objects[path.Clean(p)]
+	if !ok || o.objectType != databricks.Notebook {
+		return nil, false
+	}
+	return append([]byte{}, o.content...), true
+}
+
+func (s *Server) workspaceInfo(p string) databricks.ObjectInfo {
+	o := s.ws.objects[p]
+	return databricks.ObjectInfo{
+		ObjectType: o.objectType,
+		Path:       p,
+		Language:   o.language,
+	}
+}
+
+func (s *Server) workspaceObject(p string) (*workspaceObject, error) {
+	o, ok := s.ws.objects[p]
+	if !ok {
+		return nil, notFound("Path (%s) doesn't exist.", p)
+	}
+	return o, nil
+}
+
+func (s *Server) workspaceDelete(r *http.Request) (interface{}, error) {
+	req := struct {
+		Path      string `json:"path"`
+		Recursive bool   `json:"recursive"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	p, err := cleanPath("path", req.Path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.workspaceObject(p); err != nil {
+		return nil, err
+	}
+	if p == "/" {
+		return nil, invalidParameter("Cannot delete the root directory.")
+	}
+	for child := range s.ws.objects {
+		if isDescendant(p, child) {
+			if !req.Recursive {
+				return nil, newError(
+					http.StatusBadRequest,
+					databricks.CodeDirectoryNotEmpty,
+					"Folder (%s) is not empty.", p,
+				)
+			}
+			delete(s.ws.objects, child)
+		}
+	}
+	delete(s.ws.objects, p)
+	return nil, nil
+}
+
+func (s *Server) workspaceExport(r *http.Request) (interface{}, error) {
+	p, err := cleanPath("path", r.URL.Query().Get("path"))
+	if err != nil {
+		return nil, err
+	}
+	o, err := s.workspaceObject(p)
+	if err != nil {
+		return nil, err
+	}
+	if o.objectType != databricks.Notebook {
+		return nil, invalidParameter(
+			"Only notebooks can be exported in the SOURCE format: %s.", p)
+	}
+	if len(o.content) > maxNotebookSize {
+		return nil, newError(
+			http.StatusBadRequest,
+			databricks.CodeMaxNotebookSizeExceeded,
+			"The notebook %s exceeds the export limit of %d bytes.",
+			p, maxNotebookSize,
+		)
+	}
+	content := append([]byte{}, o.content...)
+	if queryBool(r, "direct_download") {
+		return content, nil
+	}
+	return map[string]string{
+		"content": base64.StdEncoding.EncodeToString(content),
+	}, nil
+}
+
+func (s *Server) workspaceGetStatus(r *http.Request) (interface{}, error) {
+	p, err := cleanPath("path", r.URL.Query().Get("path"))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.workspaceObject(p); err != nil {
+		return nil, err
+	}
+	return s.workspaceInfo(p), nil
+}
+
+func (s *Server) workspaceImport(r *http.Request) (interface{}, error) {
+	req := struct {
+		Path      string              `json:"path"`
+		Content   []byte              `json:"content"`
+		Format    string              `json:"format"`
+		Language  databricks.Language `json:"language"`
+		Overwrite bool                `json:"overwrite"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	p, err := cleanPath("path", req.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch req.Format {
+	case "", "SOURCE":
+		switch req.Language {
+		case databricks.Scala, databricks.Python, databricks.SQL, databricks.R:
+		case "":
+			return nil, invalidParameter(
+				"The language must be set when importing in the SOURCE format.")
+		default:
+			return nil, invalidParameter("Unknown language: %s.", req.Language)
+		}
+	case "HTML", "JUPYTER", "DBC":
+	default:
+		return nil, invalidParameter("Unknown import format: %s.", req.Format)
+	}
+	parent, ok := s.ws.objects[path.Dir(p)]
+	if !ok || parent.objectType != databricks.Directory {
+		return nil, notFound("The parent folder (%s) does not exist.", path.Dir(p))
+	}
+	if o, ok := s.ws.objects[p]; ok {
+		if o.objectType != databricks.Notebook || !req.Overwrite {
+			return nil, alreadyExists("Path (%s) already exists.", p)
+		}
+	}
+	s.ws.objects[p] = &workspaceObject{
+		objectType: databricks.Notebook,
+		language:   req.Language,
+		content:    req.Content,
+	}
+	return nil, nil
+}
+
+func (s *Server) workspaceList(r *http.Request) (interface{}, error) {
+	p, err := cleanPath("path", r.URL.Query().Get("path"))
+	if err != nil {
+		return nil, err
+	}
+	o, err := s.workspaceObject(p)
+	if err != nil {
+		return nil, err
+	}
+	objects := []databricks.ObjectInfo{}
+	if o.objectType != databricks.Directory {
+		objects = append(objects, s.workspaceInfo(p))
+	}
+	for child := range s.ws.objects {
+		if o.objectType == databricks.Directory && isChild(p, child) {
+			objects = append(objects, s.workspaceInfo(child))
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Path < objects[j].Path
+	})
+	return map[string][]databricks.ObjectInfo{"objects": objects}, nil
+}
+
+func (s *Server) workspaceMkdirs(r *http.Request) (interface{}, error) {
+	req := struct {
+		Path string `json:"path"`
+	}{}
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	p, err := cleanPath("path", req.Path)
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range append(parents(p), p) {
+		o, ok := s.ws.objects[dir]
+		if !ok {
+			s.ws.objects[dir] = &workspaceObject{objectType: databricks.Directory}
+			continue
+		}
+		if o.objectType != databricks.Directory {
+			return nil, alreadyExists(
+				"Cannot create directory %s, a %s exists at %s.",
+				p, strings.ToLower(string(o.objectType)), dir,
+			)
+		}
+	}
+	return nil, nil
+}
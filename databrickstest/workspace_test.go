@@ -0,0 +1,65 @@
+package databrickstest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/medivo/databricks-go"
+)
+
+func Test_Workspace(t *testing.T) {
+	t.Parallel()
+	server, client := testClient(t)
+	ws := client.Workspace()
+	ctx := context.Background()
+
+	source := []byte("print('hello')")
+	err := ws.Import(ctx, "/Shared/etl/hello", source, databricks.Python, false, "SOURCE")
+	expectCode(t, err, databricks.CodeResourceDoesNotExist)
+
+	if err := ws.Mkdirs(ctx, "/Shared/etl"); err != nil {
+		t.Fatal(err)
+	}
+	err = ws.Import(ctx, "/Shared/etl/hello", source, databricks.Python, false, "SOURCE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ws.Import(ctx, "/Shared/etl/hello", source, databricks.Python, false, "SOURCE")
+	expectCode(t, err, databricks.CodeResourceAlreadyExists)
+	err = ws.Import(ctx, "/Shared/etl/other", source, "", false, "SOURCE")
+	expectCode(t, err, databricks.CodeInvalidParameterValue)
+
+	if content, ok := server.Notebook("/Shared/etl/hello"); !ok || string(content) != string(source) {
+		t.Fatalf("Unexpected notebook: %q", content)
+	}
+	exported, err := ws.Export(ctx, "/Shared/etl/hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(exported) != string(source) {
+		t.Fatalf("Unexpected export: %q", exported)
+	}
+
+	lang, objType, err := ws.GetStatus(ctx, "/Shared/etl/hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lang != databricks.Python || objType != databricks.Notebook {
+		t.Fatalf("Unexpected status: %s %s", lang, objType)
+	}
+	objects, err := ws.List(ctx, "/Shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 1 || objects[0].ObjectType != databricks.Directory {
+		t.Fatalf("Unexpected objects: %+v", objects)
+	}
+
+	err = ws.Delete(ctx, "/Shared", false)
+	expectCode(t, err, databricks.CodeDirectoryNotEmpty)
+	if err := ws.Delete(ctx, "/Shared", true); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = ws.GetStatus(ctx, "/Shared/etl/hello")
+	expectCode(t, err, databricks.CodeResourceDoesNotExist)
+}
@@ -2,7 +2,7 @@ package databricks
 
 // FileInfo is file info for DBFS.
 type FileInfo struct {
-	Path     string
-	IsDir    bool
-	FileSize int64
+	Path     string `json:"path"`
+	IsDir    bool   `json:"is_dir"`
+	FileSize int64  `json:"file_size"`
 }
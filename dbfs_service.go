@@ -6,7 +6,6 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 )
 
@@ -32,27 +31,21 @@ func (s *DBFSService) AddBlock(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"DBFSService.AddBlock",
 		http.MethodPost,
-		s.client.url+"2.0/dbfs/add-block",
+		"2.0/dbfs/add-block",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		body, err := ioutil.ReadAll(res.Body)
-		defer res.Body.Close()
-		if err != nil {
-			return err
-		}
-		return fmt.Errorf(
-			"Failed to return a 2XX response (%d): %s", res.StatusCode, body)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -73,23 +66,21 @@ func (s *DBFSService) Close(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"DBFSService.Close",
 		http.MethodPost,
-		s.client.url+"2.0/dbfs/close",
+		"2.0/dbfs/close",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -119,33 +110,25 @@ func (s *DBFSService) Create(
 		return -1, err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"DBFSService.Create",
 		http.MethodPost,
-		s.client.url+"2.0/dbfs/create",
+		"2.0/dbfs/create",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return -1, err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return -1, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		body, err := ioutil.ReadAll(res.Body)
-		defer res.Body.Close()
-		if err != nil {
-			return -1, err
-		}
-		return -1, fmt.Errorf(
-			"Failed to return a 2XX response (%d): %s", res.StatusCode, body)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
 	createRes := struct {
-		Handle int64
+		Handle int64 `json:"handle"`
 	}{}
 	err = decoder.Decode(&createRes)
 
@@ -173,28 +156,21 @@ func (s *DBFSService) Delete(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"DBFSService.Delete",
 		http.MethodPost,
-		s.client.url+"2.0/dbfs/delete",
+		"2.0/dbfs/delete",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		body, err := ioutil.ReadAll(res.Body)
-		defer res.Body.Close()
-		if err != nil {
-			return err
-		}
-		return fmt.Errorf(
-			"Failed to return a 2XX response (%d): %s", res.StatusCode, body)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -207,31 +183,23 @@ func (s *DBFSService) GetStatus(
 	ctx context.Context,
 	path string,
 ) (bool, int64, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"DBFSService.GetStatus",
 		http.MethodGet,
-		s.client.url+"2.0/dbfs/get-status",
+		"2.0/dbfs/get-status",
 		nil,
 	)
 	if err != nil {
 		return false, -1, err
 	}
-	req = req.WithContext(ctx)
 	q := req.URL.Query()
 	q.Add("path", path)
 	req.URL.RawQuery = q.Encode()
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return false, -1, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		body, err := ioutil.ReadAll(res.Body)
-		defer res.Body.Close()
-		if err != nil {
-			return false, -1, err
-		}
-		return false, -1, fmt.Errorf(
-			"Failed to return a 2XX response (%d): %s", res.StatusCode, body)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
@@ -252,43 +220,34 @@ func (s *DBFSService) List(
 	ctx context.Context,
 	path string,
 ) ([]FileInfo, error) {
-	raw, err := json.Marshal(struct {
-		Path string `json:"path"`
-	}{
-		path,
-	})
-	if err != nil {
-		return []FileInfo{}, err
-	}
-
-	req, err := http.NewRequest(
-		http.MethodPost,
-		s.client.url+"2.0/dbfs/get-status",
-		bytes.NewBuffer(raw),
+	req, err := s.client.newRequest(
+		ctx,
+		"DBFSService.List",
+		http.MethodGet,
+		"2.0/dbfs/list",
+		nil,
 	)
 	if err != nil {
 		return []FileInfo{}, err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	q := req.URL.Query()
+	q.Add("path", path)
+	req.URL.RawQuery = q.Encode()
+	res, err := s.client.do(req)
 	if err != nil {
 		return []FileInfo{}, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []FileInfo{}, fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
 	listRes := struct {
-		Files []FileInfo
+		Files []FileInfo `json:"files"`
 	}{
 		[]FileInfo{},
 	}
 	err = decoder.Decode(&listRes)
 
-	return listRes.Files, nil
+	return listRes.Files, err
 }
 
 // Mkdirs creates the given directory and necessary parent directories if they
@@ -309,23 +268,21 @@ func (s *DBFSService) Mkdirs(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"DBFSService.Mkdirs",
 		http.MethodPost,
-		s.client.url+"2.0/dbfs/mkdirs",
+		"2.0/dbfs/mkdirs",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -351,23 +308,21 @@ func (s *DBFSService) Move(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"DBFSService.Move",
 		http.MethodPost,
-		s.client.url+"2.0/dbfs/move",
+		"2.0/dbfs/move",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -383,7 +338,7 @@ func (s *DBFSService) Put(
 ) error {
 	raw, err := json.Marshal(struct {
 		Path      string `json:"path"`
-		Content   []byte `json:"content"`
+		Contents  []byte `json:"contents"`
 		Overwrite bool   `json:"overwrite"`
 	}{
 		path,
@@ -394,23 +349,21 @@ func (s *DBFSService) Put(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"DBFSService.Put",
 		http.MethodPost,
-		s.client.url+"2.0/dbfs/put",
+		"2.0/dbfs/put",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -427,28 +380,25 @@ func (s *DBFSService) Read(
 	path string,
 	offset, length int64,
 ) (int64, []byte, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"DBFSService.Read",
 		http.MethodGet,
-		s.client.url+"2.0/dbfs/read",
+		"2.0/dbfs/read",
 		nil,
 	)
 	if err != nil {
 		return -1, []byte{}, err
 	}
-	req = req.WithContext(ctx)
 	q := req.URL.Query()
 	q.Add("path", path)
 	q.Add("offset", fmt.Sprintf("%d", offset))
 	q.Add("length", fmt.Sprintf("%d", length))
 	req.URL.RawQuery = q.Encode()
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return -1, []byte{}, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return -1, []byte{}, fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
@@ -0,0 +1,283 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// EnsureAction is what Ensure did to a cluster.
+type EnsureAction string
+
+const (
+	EnsureCreated   EnsureAction = "created"
+	EnsureUpdated   EnsureAction = "updated"
+	EnsureUnchanged EnsureAction = "unchanged"
+)
+
+// EnsureResult is the result of ClusterService.Ensure.
+type EnsureResult struct {
+	ClusterID string
+	Action    EnsureAction
+	// Diff are the attributes that differed between the live and the
+	// desired spec, ordered by path. A created cluster has a diff for every
+	// attribute of the spec.
+	Diff []FieldDiff
+}
+
+// FieldDiff is an attribute of a cluster spec that differs between the
+// live and the desired spec.
+type FieldDiff struct {
+	// Path is the path of the attribute, using the JSON names of a
+	// ClusterSpec joined with dots, e.g. "autoscale.max_workers" or
+	// "custom_tags.team".
+	Path string
+	// Live is the value of the live cluster, or nil if it is not set.
+	Live interface{}
+	// Desired is the value of the desired spec, or nil if it is not set.
+	Desired interface{}
+}
+
+// String returns the path and the change of the diff, e.g.
+// "autoscale.max_workers: 8 -> 10".
+func (d FieldDiff) String() string {
+	value := func(v interface{}) string {
+		if v == nil {
+			return "<unset>"
+		}
+		return fmt.Sprint(v)
+	}
+	return d.Path + ": " + value(d.Live) + " -> " + value(d.Desired)
+}
+
+// EnsureOpt is used for configuring Ensure.
+type EnsureOpt func(*ensureConfig)
+
+type ensureConfig struct {
+	tag string
+}
+
+// EnsureTag identifies the cluster by a custom tag of the spec in addition
+// to its name, so that only a cluster with the same value of the tag is
+// updated. It is used when clusters of the same name are created by
+// different deployments.
+func EnsureTag(key string) EnsureOpt {
+	return func(c *ensureConfig) {
+		c.tag = key
+	}
+}
+
+// userAttributes are the attributes of a cluster spec that the API does
+// not fill in with defaults. A value of the live cluster that is not in
+// the desired spec is removed by Edit, so it is a difference.
+var userAttributes = map[string]bool{
+	"autoscale":        true,
+	"cluster_log_conf": true,
+	"custom_tags":      true,
+	"init_scripts":     true,
+	"spark_conf":       true,
+	"spark_env_vars":   true,
+	"ssh_public_keys":  true,
+}
+
+// requestAttributes are attributes of a create or edit request that are
+// not returned by Get, and are not compared.
+var requestAttributes = map[string]bool{
+	"apply_policy_default_values": true,
+}
+
+// Ensure makes sure that a cluster with the spec exists. The cluster is
+// found by its ClusterName, and by a custom tag if EnsureTag is given. If
+// there is no such cluster it is created, and if the live spec of the
+// cluster differs from the spec, the cluster is edited to match it. Editing
+// a running cluster restarts it, and editing a cluster that is starting or
+// resizing fails.
+//
+// Attributes that the spec does not set, such as the defaults of
+// aws_attributes, are not compared, except for the attributes that the API
+// never sets itself, such as custom_tags and spark_conf, since Edit replaces
+// them. If the spec has a PolicyID, the policy is loaded and the attributes
+// that it fills in, with fixed values or with default values when
+// ApplyPolicyDefaultValues is set, are not compared either unless the spec
+// sets them. Ensure fails if more than one cluster matches.
+func (s *ClusterService) Ensure(
+	ctx context.Context,
+	spec *ClusterSpec,
+	opts ...EnsureOpt,
+) (*EnsureResult, error) {
+	cfg := ensureConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if spec.ClusterName == "" {
+		return nil, fmt.Errorf("Invalid cluster spec: a cluster name is required")
+	}
+	tagValue, ok := spec.CustomTags[cfg.tag]
+	if cfg.tag != "" && !ok {
+		return nil, fmt.Errorf(
+			"Invalid cluster spec: missing custom tag %s", cfg.tag)
+	}
+
+	clusters, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var matches []string
+	for _, cluster := range clusters {
+		if cluster.ClusterName != spec.ClusterName ||
+			cluster.ClusterSource == ClusterJob {
+			continue
+		}
+		if cfg.tag != "" && cluster.CustomTags[cfg.tag] != tagValue {
+			continue
+		}
+		matches = append(matches, cluster.ClusterID)
+	}
+
+	desired, err := specAttributes(spec)
+	if err != nil {
+		return nil, err
+	}
+	switch len(matches) {
+	case 0:
+		clusterID, err := s.Create(ctx, &ClusterCreateRequest{
+			ClusterSpec: *spec,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &EnsureResult{
+			ClusterID: clusterID,
+			Action:    EnsureCreated,
+			Diff:      diffAttributes(map[string]interface{}{}, desired),
+		}, nil
+	case 1:
+	default:
+		return nil, fmt.Errorf("Found %d clusters named %s: %s",
+			len(matches), spec.ClusterName, strings.Join(matches, ", "))
+	}
+
+	clusterID := matches[0]
+	cluster, err := s.Get(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	live, err := specAttributes(&cluster.ClusterSpec)
+	if err != nil {
+		return nil, err
+	}
+	if spec.PolicyID != "" {
+		policies := &ClusterPoliciesService{client: s.client}
+		policy, err := policies.Get(ctx, spec.PolicyID)
+		if err != nil {
+			return nil, err
+		}
+		filled, err := policyAttributes(policy, spec, live)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range filled {
+			if _, ok := desired[path]; !ok {
+				delete(live, path)
+			}
+		}
+	}
+	result := &EnsureResult{
+		ClusterID: clusterID,
+		Action:    EnsureUnchanged,
+		Diff:      diffAttributes(live, desired),
+	}
+	if len(result.Diff) == 0 {
+		return result, nil
+	}
+	err = s.Edit(ctx, &ClusterEditRequest{
+		ClusterID:   clusterID,
+		ClusterSpec: *spec,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Action = EnsureUpdated
+	return result, nil
+}
+
+// specAttributes returns the attributes of a cluster spec by their dotted
+// path, as used by cluster policies.
+func specAttributes(spec *ClusterSpec) (map[string]interface{}, error) {
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+	attrs := map[string]interface{}{}
+	flattenAttributes("", decoded, attrs)
+	for path := range attrs {
+		if requestAttributes[topAttribute(path)] {
+			delete(attrs, path)
+		}
+	}
+	return attrs, nil
+}
+
+// policyAttributes returns the paths of the live attributes that a policy
+// fills in: the attributes of its fixed rules, and the attributes of its
+// rules with a default value if the spec applies them.
+func policyAttributes(
+	policy *Policy,
+	spec *ClusterSpec,
+	live map[string]interface{},
+) ([]string, error) {
+	var paths []string
+	for path, rule := range policy.Definition {
+		if rule.Type != PolicyFixed &&
+			(rule.DefaultValue == nil || !spec.ApplyPolicyDefaultValues) {
+			continue
+		}
+		matches, err := matchAttributes(path, live)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, matches...)
+	}
+	return paths, nil
+}
+
+// diffAttributes returns the attributes that differ between the live and
+// the desired attributes, ordered by path.
+func diffAttributes(live, desired map[string]interface{}) []FieldDiff {
+	var diff []FieldDiff
+	for path, value := range desired {
+		if liveValue, ok := live[path]; !ok || !reflect.DeepEqual(liveValue, value) {
+			diff = append(diff, FieldDiff{
+				Path:    path,
+				Live:    live[path],
+				Desired: value,
+			})
+		}
+	}
+	for path, value := range live {
+		if _, ok := desired[path]; ok || !userAttributes[topAttribute(path)] {
+			continue
+		}
+		diff = append(diff, FieldDiff{Path: path, Live: value})
+	}
+	sort.Slice(diff, func(i, j int) bool {
+		return diff[i].Path < diff[j].Path
+	})
+	return diff
+}
+
+// topAttribute returns the top level attribute of a path, e.g. custom_tags
+// for custom_tags.team.
+func topAttribute(path string) string {
+	if i := strings.Index(path, "."); i >= 0 {
+		return path[:i]
+	}
+	return path
+}
@@ -0,0 +1,51 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_diffAttributes(t *testing.T) {
+	t.Parallel()
+	workers, autotermination := int32(2), int32(120)
+	live := &ClusterSpec{
+		ClusterName:            "etl",
+		SparkVersion:           "13.3.x-scala2.12",
+		NodeTypeID:             "i3.xlarge",
+		DriverNodeTypeID:       "i3.xlarge",
+		NumWorkers:             &workers,
+		AutoterminationMinutes: &autotermination,
+		CustomTags:             map[string]string{"team": "data", "cost": "etl"},
+		AWSAttributes:          &AWSAttributes{ZoneID: "us-west-2a"},
+	}
+	desired := &ClusterSpec{
+		ClusterName:              "etl",
+		SparkVersion:             "14.3.x-scala2.12",
+		NodeTypeID:               "i3.xlarge",
+		NumWorkers:               &workers,
+		CustomTags:               map[string]string{"team": "data"},
+		ApplyPolicyDefaultValues: true,
+	}
+	liveAttrs, err := specAttributes(live)
+	if err != nil {
+		t.Fatal(err)
+	}
+	desiredAttrs, err := specAttributes(desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Defaults of the live cluster, like the driver node type, are not
+	// differences, but custom tags that Edit would remove are.
+	expected := []FieldDiff{
+		{Path: "custom_tags.cost", Live: "etl"},
+		{Path: "spark_version", Live: "13.3.x-scala2.12", Desired: "14.3.x-scala2.12"},
+	}
+	diff := diffAttributes(liveAttrs, desiredAttrs)
+	if !reflect.DeepEqual(diff, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, diff)
+	}
+	if diff[0].String() != "custom_tags.cost: etl -> <unset>" {
+		t.Fatalf("Unexpected diff: %s", diff[0])
+	}
+}
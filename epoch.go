@@ -0,0 +1,129 @@
+package databricks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// EpochMillis is a point in time as sent by the API, in milliseconds since
+// the Unix epoch. The zero value means that the time is not set, and
+// negative values are sentinels such as the -1 expiry time of tokens that do
+// not expire, which are not set either.
+type EpochMillis int64
+
+// NewEpochMillis returns the EpochMillis of a time. The zero time.Time
+// returns the zero EpochMillis.
+func NewEpochMillis(t time.Time) EpochMillis {
+	if t.IsZero() {
+		return 0
+	}
+	return EpochMillis(t.UnixNano() / int64(time.Millisecond))
+}
+
+// Time returns the time in UTC, or the zero time.Time if it is not set.
+func (e EpochMillis) Time() time.Time {
+	if e.IsZero() {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(e)*int64(time.Millisecond)).UTC()
+}
+
+// IsZero returns whether the time is not set.
+func (e EpochMillis) IsZero() bool {
+	return e <= 0
+}
+
+// String returns the time in RFC 3339 format, or an empty string if it is
+// not set.
+func (e EpochMillis) String() string {
+	if e.IsZero() {
+		return ""
+	}
+	return e.Time().Format(time.RFC3339Nano)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e EpochMillis) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(e), 10)), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts a
+// number, a number in a string and null.
+func (e *EpochMillis) UnmarshalJSON(data []byte) error {
+	n, err := unmarshalMillis(data)
+	if err != nil {
+		return fmt.Errorf("Invalid epoch millis %s: %w", data, err)
+	}
+	*e = EpochMillis(n)
+	return nil
+}
+
+// DurationMillis is a duration as sent by the API, in milliseconds.
+type DurationMillis int64
+
+// NewDurationMillis returns the DurationMillis of a duration, truncated to
+// milliseconds.
+func NewDurationMillis(d time.Duration) DurationMillis {
+	return DurationMillis(d / time.Millisecond)
+}
+
+// Duration returns the duration as a time.Duration.
+func (d DurationMillis) Duration() time.Duration {
+	return time.Duration(d) * time.Millisecond
+}
+
+// String returns the duration formatted like a time.Duration.
+func (d DurationMillis) String() string {
+	return d.Duration().String()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (d DurationMillis) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(d), 10)), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts a
+// number, a number in a string and null.
+func (d *DurationMillis) UnmarshalJSON(data []byte) error {
+	n, err := unmarshalMillis(data)
+	if err != nil {
+		return fmt.Errorf("Invalid duration millis %s: %w", data, err)
+	}
+	*d = DurationMillis(n)
+	return nil
+}
+
+// unmarshalMillis decodes a JSON number of milliseconds. Some endpoints
+// send large numbers as strings, and null is decoded as zero.
+func unmarshalMillis(data []byte) (int64, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return 0, nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return 0, err
+		}
+		if s == "" {
+			return 0, nil
+		}
+		data = []byte(s)
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return 0, err
+	}
+	if i, err := n.Int64(); err == nil {
+		return i, nil
+	}
+	// Floats such as 1.5e12 are truncated to whole milliseconds.
+	f, err := n.Float64()
+	if err != nil {
+		return 0, err
+	}
+	return int64(f), nil
+}
@@ -0,0 +1,109 @@
+package databricks
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func Test_EpochMillis(t *testing.T) {
+	t.Parallel()
+	ts := time.Date(2019, 3, 1, 12, 30, 0, 250*int(time.Millisecond), time.UTC)
+	e := NewEpochMillis(ts)
+	if e != 1551443400250 {
+		t.Fatalf("Unexpected epoch millis: %d", e)
+	}
+	if !e.Time().Equal(ts) {
+		t.Fatalf("Unexpected time: %s", e.Time())
+	}
+	if e.String() != "2019-03-01T12:30:00.25Z" {
+		t.Fatalf("Unexpected string: %s", e)
+	}
+	if NewEpochMillis(time.Time{}) != 0 {
+		t.Fatalf("Zero time is not the zero epoch millis")
+	}
+	if !EpochMillis(0).Time().IsZero() {
+		t.Fatalf("Zero epoch millis is not the zero time")
+	}
+	never := PublicTokenInfo{ExpiryTime: -1}
+	if !never.ExpiryTime.Time().IsZero() || never.ExpiryTime.String() != "" {
+		t.Fatalf("Unexpected time of -1: %s", never.ExpiryTime.Time())
+	}
+	if !never.NeverExpires() {
+		t.Fatalf("Expected token with expiry time -1 to never expire")
+	}
+	if (PublicTokenInfo{ExpiryTime: e}).NeverExpires() {
+		t.Fatalf("Expected token with expiry time %s to expire", e)
+	}
+}
+
+func Test_EpochMillis_JSON(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in       string
+		expected EpochMillis
+	}{
+		{`1551443400250`, 1551443400250},
+		{`"1551443400250"`, 1551443400250},
+		{`1.55144340025e12`, 1551443400250},
+		{`null`, 0},
+		{`""`, 0},
+		{`-1`, -1},
+	}
+	for _, test := range tests {
+		var e EpochMillis
+		if err := json.Unmarshal([]byte(test.in), &e); err != nil {
+			t.Fatalf("Unmarshal %s: %v", test.in, err)
+		}
+		if e != test.expected {
+			t.Fatalf("Unmarshal %s: expected %d, got %d", test.in, test.expected, e)
+		}
+	}
+	for _, in := range []string{`"soon"`, `true`, `{}`} {
+		var e EpochMillis
+		if err := json.Unmarshal([]byte(in), &e); err == nil {
+			t.Fatalf("Unmarshal %s succeeded", in)
+		}
+	}
+
+	raw, err := json.Marshal(struct {
+		Start EpochMillis  `json:"start"`
+		End   *EpochMillis `json:"end,omitempty"`
+	}{Start: 1551443400250})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(raw) != `{"start":1551443400250}` {
+		t.Fatalf("Unexpected JSON: %s", raw)
+	}
+}
+
+func Test_DurationMillis(t *testing.T) {
+	t.Parallel()
+	var run Run
+	err := json.Unmarshal([]byte(`{
+		"run_id": 1,
+		"start_time": 1551443400250,
+		"setup_duration": 1500,
+		"execution_duration": 60000,
+		"cleanup_duration": 0
+	}`), &run)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.StartTime.Time().Year() != 2019 {
+		t.Fatalf("Unexpected start time: %s", run.StartTime)
+	}
+	if run.SetupDuration.Duration() != 1500*time.Millisecond {
+		t.Fatalf("Unexpected setup duration: %s", run.SetupDuration)
+	}
+	if run.ExecutionDuration.Duration() != time.Minute {
+		t.Fatalf("Unexpected execution duration: %s", run.ExecutionDuration)
+	}
+	if run.CleanupDuration.Duration() != 0 {
+		t.Fatalf("Unexpected cleanup duration: %s", run.CleanupDuration)
+	}
+	if NewDurationMillis(90*time.Second) != 90000 {
+		t.Fatalf("Unexpected duration millis: %d", NewDurationMillis(90*time.Second))
+	}
+}
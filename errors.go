@@ -0,0 +1,181 @@
+package databricks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// ErrorCode is an error code returned by the Databricks API in the
+// error_code field of an error response.
+type ErrorCode string
+
+const (
+	// CodeResourceDoesNotExist is returned when the requested resource can
+	// not be found.
+	CodeResourceDoesNotExist ErrorCode = "RESOURCE_DOES_NOT_EXIST"
+	// CodeResourceAlreadyExists is returned when creating a resource that
+	// already exists.
+	CodeResourceAlreadyExists ErrorCode = "RESOURCE_ALREADY_EXISTS"
+	// CodeInvalidParameterValue is returned when a request parameter is
+	// invalid.
+	CodeInvalidParameterValue ErrorCode = "INVALID_PARAMETER_VALUE"
+	// CodeInvalidState is returned when the resource is not in a state that
+	// allows the operation.
+	CodeInvalidState ErrorCode = "INVALID_STATE"
+	// CodeMaxReadSizeExceeded is returned by DBFS when a read exceeds 1 MB.
+	CodeMaxReadSizeExceeded ErrorCode = "MAX_READ_SIZE_EXCEEDED"
+	// CodeMaxBlockSizeExceeded is returned by DBFS when a block exceeds 1 MB.
+	CodeMaxBlockSizeExceeded ErrorCode = "MAX_BLOCK_SIZE_EXCEEDED"
+	// CodeMaxNotebookSizeExceeded is returned by the workspace API when an
+	// export exceeds the size limit.
+	CodeMaxNotebookSizeExceeded ErrorCode = "MAX_NOTEBOOK_SIZE_EXCEEDED"
+	// CodeDirectoryNotEmpty is returned when deleting a non-empty directory
+	// without the recursive flag.
+	CodeDirectoryNotEmpty ErrorCode = "DIRECTORY_NOT_EMPTY"
+	// CodeIOError is returned by DBFS for generic IO failures.
+	CodeIOError ErrorCode = "IO_ERROR"
+	// CodeQuotaExceeded is returned when a quota, such as the token quota, has
+	// been exceeded.
+	CodeQuotaExceeded ErrorCode = "QUOTA_EXCEEDED"
+	// CodePermissionDenied is returned when the caller lacks permission.
+	CodePermissionDenied ErrorCode = "PERMISSION_DENIED"
+	// CodeUnauthenticated is returned when the request is not authenticated.
+	CodeUnauthenticated ErrorCode = "UNAUTHENTICATED"
+	// CodeRequestLimitExceeded is returned when the request is rate limited.
+	CodeRequestLimitExceeded ErrorCode = "REQUEST_LIMIT_EXCEEDED"
+	// CodeTemporarilyUnavailable is returned when the service is temporarily
+	// unavailable.
+	CodeTemporarilyUnavailable ErrorCode = "TEMPORARILY_UNAVAILABLE"
+	// CodeInternalError is returned when the service failed unexpectedly.
+	CodeInternalError ErrorCode = "INTERNAL_ERROR"
+	// CodeBadRequest is returned for malformed requests.
+	CodeBadRequest ErrorCode = "BAD_REQUEST"
+)
+
+// Sentinel errors that an *APIError matches with errors.Is based on its
+// ErrorCode.
+var (
+	ErrResourceDoesNotExist    = errors.New("Resource does not exist")
+	ErrResourceAlreadyExists   = errors.New("Resource already exists")
+	ErrInvalidParameterValue   = errors.New("Invalid parameter value")
+	ErrInvalidState            = errors.New("Invalid state")
+	ErrMaxReadSizeExceeded     = errors.New("Max read size exceeded")
+	ErrDirectoryNotEmpty       = errors.New("Directory not empty")
+	ErrQuotaExceeded           = errors.New("Quota exceeded")
+	ErrPermissionDenied        = errors.New("Permission denied")
+	ErrUnauthenticated         = errors.New("Unauthenticated")
+	ErrRequestLimitExceeded    = errors.New("Request limit exceeded")
+	ErrTemporarilyUnavailable  = errors.New("Temporarily unavailable")
+	ErrMaxBlockSizeExceeded    = errors.New("Max block size exceeded")
+	ErrMaxNotebookSizeExceeded = errors.New("Max notebook size exceeded")
+)
+
+var errorCodeSentinels = map[ErrorCode]error{
+	CodeResourceDoesNotExist:    ErrResourceDoesNotExist,
+	CodeResourceAlreadyExists:   ErrResourceAlreadyExists,
+	CodeInvalidParameterValue:   ErrInvalidParameterValue,
+	CodeInvalidState:            ErrInvalidState,
+	CodeMaxReadSizeExceeded:     ErrMaxReadSizeExceeded,
+	CodeDirectoryNotEmpty:       ErrDirectoryNotEmpty,
+	CodeQuotaExceeded:           ErrQuotaExceeded,
+	CodePermissionDenied:        ErrPermissionDenied,
+	CodeUnauthenticated:         ErrUnauthenticated,
+	CodeRequestLimitExceeded:    ErrRequestLimitExceeded,
+	CodeTemporarilyUnavailable:  ErrTemporarilyUnavailable,
+	CodeMaxBlockSizeExceeded:    ErrMaxBlockSizeExceeded,
+	CodeMaxNotebookSizeExceeded: ErrMaxNotebookSizeExceeded,
+}
+
+// statusSentinels are used when a response does not carry an error code.
+var statusSentinels = map[int]error{
+	http.StatusNotFound:           ErrResourceDoesNotExist,
+	http.StatusConflict:           ErrResourceAlreadyExists,
+	http.StatusUnauthorized:       ErrUnauthenticated,
+	http.StatusForbidden:          ErrPermissionDenied,
+	http.StatusTooManyRequests:    ErrRequestLimitExceeded,
+	http.StatusServiceUnavailable: ErrTemporarilyUnavailable,
+}
+
+// APIError is returned when the Databricks API responds with a non 2XX
+// status code.
+type APIError struct {
+	StatusCode int       `json:"-"`
+	ErrorCode  ErrorCode `json:"error_code"`
+	Message    string    `json:"message"`
+	Method     string    `json:"-"`
+	Path       string    `json:"-"`
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.ErrorCode != "" {
+		msg = fmt.Sprintf("%s: %s", e.ErrorCode, msg)
+	}
+	if e.Path != "" {
+		return fmt.Sprintf(
+			"Failed to return a 2XX response from %s %s (%d): %s",
+			e.Method, e.Path, e.StatusCode, msg,
+		)
+	}
+	return fmt.Sprintf(
+		"Failed to return a 2XX response (%d): %s", e.StatusCode, msg)
+}
+
+// Is allows an APIError to be matched against the sentinel errors with
+// errors.Is.
+func (e *APIError) Is(target error) bool {
+	if sentinel, ok := errorCodeSentinels[e.ErrorCode]; ok {
+		return sentinel == target
+	}
+	if e.ErrorCode != "" {
+		return false
+	}
+	return statusSentinels[e.StatusCode] == target
+}
+
+// checkResponse returns an *APIError if the response does not have a 2XX
+// status code. The response body is consumed and closed when an error is
+// returned.
+func checkResponse(res *http.Response) error {
+	if res.StatusCode >= 200 && res.StatusCode <= 299 {
+		return nil
+	}
+	defer res.Body.Close()
+
+	apiErr := &APIError{StatusCode: res.StatusCode}
+	if res.Request != nil && res.Request.URL != nil {
+		apiErr.Method = res.Request.Method
+		apiErr.Path = res.Request.URL.Path
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return apiErr
+	}
+	body = bytes.TrimSpace(body)
+
+	errRes := struct {
+		ErrorCode ErrorCode `json:"error_code"`
+		Message   string    `json:"message"`
+		Error     string    `json:"error"`
+	}{}
+	if err := json.Unmarshal(body, &errRes); err != nil {
+		apiErr.Message = string(body)
+		return apiErr
+	}
+	apiErr.ErrorCode = errRes.ErrorCode
+	apiErr.Message = errRes.Message
+	if apiErr.Message == "" {
+		apiErr.Message = errRes.Error
+	}
+
+	return apiErr
+}
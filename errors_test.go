@@ -0,0 +1,96 @@
+package databricks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func Test_APIError_Is(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		err    *APIError
+		target error
+		want   bool
+	}{
+		{
+			&APIError{StatusCode: 404, ErrorCode: CodeResourceDoesNotExist},
+			ErrResourceDoesNotExist,
+			true,
+		},
+		{
+			&APIError{StatusCode: 400, ErrorCode: CodeResourceAlreadyExists},
+			ErrResourceAlreadyExists,
+			true,
+		},
+		{
+			&APIError{StatusCode: 400, ErrorCode: CodeResourceAlreadyExists},
+			ErrResourceDoesNotExist,
+			false,
+		},
+		{
+			&APIError{StatusCode: 404},
+			ErrResourceDoesNotExist,
+			true,
+		},
+		{
+			&APIError{StatusCode: 400, ErrorCode: "SOMETHING_NEW"},
+			ErrInvalidParameterValue,
+			false,
+		},
+	}
+	for _, test := range tests {
+		if got := errors.Is(test.err, test.target); got != test.want {
+			t.Errorf(
+				"errors.Is(%v, %v) = %v, want %v",
+				test.err, test.target, got, test.want,
+			)
+		}
+	}
+}
+
+func Test_checkResponse(t *testing.T) {
+	t.Parallel()
+	dbfs := successDBFSHelper(
+		t,
+		[]byte(`{"error_code":"RESOURCE_ALREADY_EXISTS","message":"A file or directory already exists at the input path dbfs:/tmp/a."}`),
+		http.StatusBadRequest,
+	)
+
+	_, err := dbfs.Create(context.Background(), "/tmp/a", false)
+	if !errors.Is(err, ErrResourceAlreadyExists) {
+		t.Fatalf("Expected ErrResourceAlreadyExists, got: %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected an *APIError, got: %T", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Unexpected status code: %d", apiErr.StatusCode)
+	}
+	if apiErr.Message == "" {
+		t.Fatalf("Expected message to be parsed")
+	}
+
+	// Legacy error bodies
+	groups := successGroupsHelper(
+		t,
+		[]byte(`{"error":"Group not found"}`),
+		http.StatusNotFound,
+	)
+	err = groups.Delete(context.Background(), "missing")
+	if !errors.Is(err, ErrResourceDoesNotExist) {
+		t.Fatalf("Expected ErrResourceDoesNotExist, got: %v", err)
+	}
+	if !errors.As(err, &apiErr) || apiErr.Message != "Group not found" {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Non JSON bodies
+	cluster := non200ClusterHelper(t)
+	err = cluster.Start(context.Background(), "cluster")
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 418 {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
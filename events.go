@@ -0,0 +1,175 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DefaultEventPollInterval is the interval at which WatchEvents polls for
+// new events.
+const DefaultEventPollInterval = 10 * time.Second
+
+// watchEventsLimit is the number of latest events that WatchEvents reads to
+// find where to start from.
+const watchEventsLimit = 50
+
+// ClientEventPollInterval configures the interval at which
+// ClusterService.WatchEvents polls for new events. It defaults to
+// DefaultEventPollInterval.
+func ClientEventPollInterval(interval time.Duration) ClientOpt {
+	return func(c *Client) error {
+		if interval <= 0 {
+			return fmt.Errorf("Invalid event poll interval: %s", interval)
+		}
+		c.eventPollInterval = interval
+		return nil
+	}
+}
+
+// WatchEvents polls the events of a cluster and delivers the events that
+// occur after it returns on the returned channel, oldest first. If types
+// are given, only events of those types are delivered:
+//
+//	events, errs := client.Cluster().WatchEvents(ctx, clusterID,
+//		databricks.ClusterEventResizing,
+//		databricks.ClusterEventDriverNotResponding,
+//		databricks.ClusterEventTerminating,
+//	)
+//	for event := range events {
+//		log.Printf("%s at %s", event.Type, event.Timestamp)
+//	}
+//	err := <-errs
+//
+// Watching stops when the context is done or a poll fails. The error
+// channel then receives the error, which is the error of the context if it
+// is done, and both channels are closed.
+func (s *ClusterService) WatchEvents(
+	ctx context.Context,
+	clusterID string,
+	types ...ClusterEventType,
+) (<-chan ClusterEvent, <-chan error) {
+	events := make(chan ClusterEvent)
+	errs := make(chan error, 1)
+	interval := s.client.eventPollInterval
+	if interval <= 0 {
+		interval = DefaultEventPollInterval
+	}
+	// The latest events are read before returning, so that exactly the
+	// events that occur from now on are delivered.
+	cursor, err := s.latestEvents(ctx, clusterID, types)
+	if err != nil {
+		close(events)
+		errs <- err
+		close(errs)
+		return events, errs
+	}
+	go func() {
+		defer close(errs)
+		err := s.pollEvents(ctx, clusterID, types, interval, cursor, events)
+		close(events)
+		errs <- err
+	}()
+	return events, errs
+}
+
+// latestEvents returns a cursor at the latest events of a cluster.
+func (s *ClusterService) latestEvents(
+	ctx context.Context,
+	clusterID string,
+	types []ClusterEventType,
+) (*eventCursor, error) {
+	desc := Desc
+	latest, err := s.Events(ctx, &ClusterEventRequest{
+		ClusterID:  clusterID,
+		Order:      &desc,
+		EventTypes: types,
+		Limit:      watchEventsLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	cursor := &eventCursor{}
+	for _, event := range latest.Events {
+		cursor.add(event)
+	}
+	return cursor, nil
+}
+
+// pollEvents delivers the events after the cursor until the context is
+// done or a poll fails.
+func (s *ClusterService) pollEvents(
+	ctx context.Context,
+	clusterID string,
+	types []ClusterEventType,
+	interval time.Duration,
+	cursor *eventCursor,
+	events chan<- ClusterEvent,
+) error {
+	asc := Asc
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		eventReq := &ClusterEventRequest{
+			ClusterID:  clusterID,
+			Order:      &asc,
+			EventTypes: types,
+		}
+		if !cursor.since.IsZero() {
+			since := cursor.since
+			eventReq.StartTime = &since
+		}
+		it := s.EventsIter(ctx, eventReq)
+		for it.Next() {
+			event := it.Item()
+			if !cursor.add(event) {
+				continue
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if err := it.Err(); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+	}
+}
+
+// eventCursor tracks the latest events that were seen. As the start time
+// of an event request is inclusive, the events at the latest timestamp are
+// returned again by the next poll, so they are remembered.
+type eventCursor struct {
+	since EpochMillis
+	seen  map[string]bool
+}
+
+// add records an event and returns whether it was not seen before.
+func (c *eventCursor) add(event ClusterEvent) bool {
+	if event.Timestamp < c.since {
+		return false
+	}
+	key, err := json.Marshal(event)
+	if err != nil {
+		key = []byte(fmt.Sprintf("%+v", event))
+	}
+	if event.Timestamp > c.since || c.seen == nil {
+		c.since = event.Timestamp
+		c.seen = map[string]bool{}
+	}
+	if c.seen[string(key)] {
+		return false
+	}
+	c.seen[string(key)] = true
+	return true
+}
@@ -0,0 +1,70 @@
+package databricks
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_ClusterEventResponse_RoundTrip(t *testing.T) {
+	t.Parallel()
+	var eventRes ClusterEventResponse
+	expectRoundTrip(t, readPayload(t, "clusters_events.json"), &eventRes)
+
+	if len(eventRes.Events) != 4 || eventRes.NextPage == nil ||
+		eventRes.NextPage.Offset != 4 || *eventRes.NextPage.Order != Desc {
+		t.Fatalf("Unexpected events: %+v", eventRes)
+	}
+	terminating, notResponding, resizing, edited := eventRes.Events[0],
+		eventRes.Events[1], eventRes.Events[2], eventRes.Events[3]
+	if terminating.Details.Reason.Code != TerminationInactivity {
+		t.Fatalf("Unexpected reason: %+v", terminating.Details.Reason)
+	}
+	if notResponding.Type != ClusterEventDriverNotResponding ||
+		notResponding.Details.DriverStateMessage == "" {
+		t.Fatalf("Unexpected event: %+v", notResponding)
+	}
+	if resizing.Details.Cause != ResizeAutoscale || resizing.Details.TargetNumWorkers != 6 {
+		t.Fatalf("Unexpected event: %+v", resizing)
+	}
+	details := edited.Details
+	if details.PreviousAttributes.SparkVersion != "12.2.x-scala2.12" ||
+		*details.Attributes.AutoterminationMinutes != 60 ||
+		details.Attributes.ClusterSource != UI ||
+		*details.PreviousClusterSize.NumWorkers != 2 ||
+		details.ClusterSize.Autoscale.Max != 8 {
+		t.Fatalf("Unexpected details: %+v", details)
+	}
+}
+
+func Test_eventCursor(t *testing.T) {
+	t.Parallel()
+	var cursor eventCursor
+	first := ClusterEvent{Timestamp: 10, Type: ClusterEventResizing}
+	second := ClusterEvent{Timestamp: 10, Type: ClusterEventTerminating}
+	if !cursor.add(first) || !cursor.add(second) {
+		t.Fatalf("Expected new events at the same time to be added")
+	}
+	if cursor.add(first) || cursor.add(ClusterEvent{Timestamp: 9}) {
+		t.Fatalf("Expected seen and older events to be skipped")
+	}
+	if !cursor.add(ClusterEvent{Timestamp: 11}) || cursor.since != 11 {
+		t.Fatalf("Expected the cursor to move to a newer event")
+	}
+	if cursor.add(second) {
+		t.Fatalf("Expected events before the cursor to be skipped")
+	}
+}
+
+func Test_ClientEventPollInterval(t *testing.T) {
+	t.Parallel()
+	if _, err := NewClient("test-account", ClientEventPollInterval(0)); err == nil {
+		t.Fatalf("Expected an invalid interval to fail")
+	}
+	client, err := NewClient("test-account", ClientEventPollInterval(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.eventPollInterval != time.Second {
+		t.Fatalf("Unexpected interval: %s", client.eventPollInterval)
+	}
+}
@@ -10,14 +10,23 @@ import (
 	"github.com/medivo/databricks-go"
 )
 
-var account = flag.String("account", "", "Databricks account")
+var (
+	account = flag.String("account", "", "Databricks account, uses .netrc")
+	host    = flag.String("host", "", "Databricks workspace URL, logs in with the browser")
+)
 
 func main() {
 	flag.Parse()
-	client, err := databricks.NewClient(
-		*account,
-		databricks.ClientHTTPClient(databricks.NetrcHTTPClient),
-	)
+	opts := []databricks.ClientOpt{
+		databricks.ClientCredentials(databricks.NetrcCredentials()),
+	}
+	if *host != "" {
+		opts = []databricks.ClientOpt{
+			databricks.ClientHost(*host),
+			databricks.ClientCredentials(databricks.BrowserCredentials()),
+		}
+	}
+	client, err := databricks.NewClient(*account, opts...)
 	if err != nil {
 		log.Fatalln(err)
 	}
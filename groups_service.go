@@ -33,23 +33,21 @@ func (s *GroupsService) AddMember(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"GroupsService.AddMember",
 		http.MethodPost,
-		s.client.url+"2.0/groups/add-member",
+		"2.0/groups/add-member",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 
@@ -70,23 +68,21 @@ func (s *GroupsService) Create(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"GroupsService.Create",
 		http.MethodPost,
-		s.client.url+"2.0/groups/create",
+		"2.0/groups/create",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -98,31 +94,28 @@ func (s *GroupsService) Members(
 	ctx context.Context,
 	groupName string,
 ) ([]PrincipalName, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"GroupsService.Members",
 		http.MethodGet,
-		s.client.url+"2.0/groups/list-members",
+		"2.0/groups/list-members",
 		nil,
 	)
 	if err != nil {
 		return []PrincipalName{}, err
 	}
-	req = req.WithContext(ctx)
 	q := req.URL.Query()
 	q.Add("group_name", groupName)
 	req.URL.RawQuery = q.Encode()
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return []PrincipalName{}, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []PrincipalName{}, fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
 	membersRes := struct {
-		Members []PrincipalName
+		Members []PrincipalName `json:"members"`
 	}{[]PrincipalName{}}
 	err = decoder.Decode(&membersRes)
 
@@ -133,23 +126,20 @@ func (s *GroupsService) Members(
 func (s *GroupsService) Groups(
 	ctx context.Context,
 ) ([]string, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"GroupsService.Groups",
 		http.MethodGet,
-		s.client.url+"2.0/groups/list",
+		"2.0/groups/list",
 		nil,
 	)
 	if err != nil {
 		return []string{}, err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return []string{}, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []string{}, fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
@@ -166,7 +156,7 @@ func (s *GroupsService) UserParents(
 	ctx context.Context,
 	userName string,
 ) ([]string, error) {
-	return s.parents(ctx, "", userName)
+	return s.parents(ctx, "GroupsService.UserParents", "", userName)
 }
 
 // GroupParents returns all of the Parent groups of a group.
@@ -174,26 +164,30 @@ func (s *GroupsService) GroupParents(
 	ctx context.Context,
 	groupName string,
 ) ([]string, error) {
-	return s.parents(ctx, groupName, "")
+	return s.parents(ctx, "GroupsService.GroupParents", groupName, "")
 }
 
+// parents returns the parent groups of a group or a user. The method is
+// the name of the public method, as reported to middleware.
 func (s *GroupsService) parents(
 	ctx context.Context,
+	method string,
 	groupName, userName string,
 ) ([]string, error) {
 	if len(groupName) > 0 && len(userName) > 0 {
 		return []string{}, fmt.Errorf(
 			"Must specify either group_name OR user_name")
 	}
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		method,
 		http.MethodGet,
-		s.client.url+"2.0/groups/list-parents",
+		"2.0/groups/list-parents",
 		nil,
 	)
 	if err != nil {
 		return []string{}, err
 	}
-	req = req.WithContext(ctx)
 	q := req.URL.Query()
 	if len(groupName) > 0 {
 		q.Add("group_name", groupName)
@@ -203,14 +197,10 @@ func (s *GroupsService) parents(
 	}
 	req.URL.RawQuery = q.Encode()
 
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return []string{}, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []string{}, fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
@@ -235,7 +225,7 @@ func (s *GroupsService) RemoveUser(
 	if err != nil {
 		return err
 	}
-	return s.remove(ctx, raw)
+	return s.remove(ctx, "GroupsService.RemoveUser", raw)
 }
 
 // RemoveGroup removes a Group from a group.
@@ -251,30 +241,31 @@ func (s *GroupsService) RemoveGroup(
 	if err != nil {
 		return err
 	}
-	return s.remove(ctx, raw)
+	return s.remove(ctx, "GroupsService.RemoveGroup", raw)
 }
 
+// remove removes a member from a group. The method is the name of the
+// public method, as reported to middleware.
 func (s *GroupsService) remove(
 	ctx context.Context,
+	method string,
 	raw []byte,
 ) error {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		method,
 		http.MethodPost,
-		s.client.url+"2.0/groups/remove-member",
+		"2.0/groups/remove-member",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -292,23 +283,21 @@ func (s *GroupsService) Delete(
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"GroupsService.Delete",
 		http.MethodPost,
-		s.client.url+"2.0/groups/delete",
+		"2.0/groups/delete",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -0,0 +1,155 @@
+package databricks
+
+// InstancePoolState is the state of an instance pool.
+type InstancePoolState string
+
+const (
+	InstancePoolActive  InstancePoolState = "ACTIVE"
+	InstancePoolStopped InstancePoolState = "STOPPED"
+	InstancePoolDeleted InstancePoolState = "DELETED"
+)
+
+// InstancePoolSpec is the specification of an instance pool, as sent when a
+// pool is created or edited and as returned when it is described.
+type InstancePoolSpec struct {
+	InstancePoolName string `json:"instance_pool_name"`
+	// MinIdleInstances is the number of idle instances that the pool keeps
+	// ready for clusters, and that are not terminated when they are idle.
+	MinIdleInstances int32 `json:"min_idle_instances,omitempty"`
+	// MaxCapacity is the maximum number of instances of the pool, both used
+	// and idle. 0 means no limit.
+	MaxCapacity int32 `json:"max_capacity,omitempty"`
+	// NodeTypeID is the node type of the instances. It cannot be changed
+	// when the pool is edited.
+	NodeTypeID string `json:"node_type_id"`
+	// CustomTags are added to the instances of the pool, and to the
+	// clusters that use them.
+	CustomTags map[string]string `json:"custom_tags,omitempty"`
+	// IdleInstanceAutoterminationMinutes is the number of minutes after
+	// which the idle instances above MinIdleInstances are terminated. 0
+	// terminates them as soon as they are idle, and nil uses the default of
+	// the API.
+	IdleInstanceAutoterminationMinutes *int32    `json:"idle_instance_autotermination_minutes,omitempty"`
+	EnableElasticDisk                  bool      `json:"enable_elastic_disk,omitempty"`
+	DiskSpec                           *DiskSpec `json:"disk_spec,omitempty"`
+	// PreloadedSparkVersions are the Spark versions that are installed on
+	// the idle instances, so that clusters with those versions start
+	// faster. At most one version can be preloaded.
+	PreloadedSparkVersions []string                   `json:"preloaded_spark_versions,omitempty"`
+	AWSAttributes          *InstancePoolAWSAttributes `json:"aws_attributes,omitempty"`
+}
+
+// InstancePoolEditRequest is an Edit request for an instance pool. Only the
+// name, the capacity, the idle instances and the custom tags of a pool can
+// be changed, and the rest of its spec must be sent unchanged.
+type InstancePoolEditRequest struct {
+	InstancePoolID string `json:"instance_pool_id"`
+	InstancePoolSpec
+}
+
+// InstancePoolAWSAttributes are the AWS attributes of the instances of a
+// pool.
+type InstancePoolAWSAttributes struct {
+	Availability AWSAvailability `json:"availability,omitempty"`
+	ZoneID       string          `json:"zone_id,omitempty"`
+	// SpotBidPricePercent is the maximum price of spot instances, as a
+	// percentage of the price of on-demand instances.
+	SpotBidPricePercent *int32 `json:"spot_bid_price_percent,omitempty"`
+}
+
+// DiskSpec are the disks attached to each instance of a pool.
+type DiskSpec struct {
+	DiskType  *DiskType `json:"disk_type,omitempty"`
+	DiskCount int32     `json:"disk_count,omitempty"`
+	// DiskSize is the size of each disk, in GiB.
+	DiskSize int32 `json:"disk_size,omitempty"`
+}
+
+// DiskType is the type of the disks of a DiskSpec.
+type DiskType struct {
+	EBSVolumeType       EBSVolumeType `json:"ebs_volume_type,omitempty"`
+	AzureDiskVolumeType string        `json:"azure_disk_volume_type,omitempty"`
+}
+
+// InstancePool is an instance pool, as returned by Get and List.
+type InstancePool struct {
+	InstancePoolSpec
+	InstancePoolID string              `json:"instance_pool_id"`
+	DefaultTags    map[string]string   `json:"default_tags,omitempty"`
+	State          InstancePoolState   `json:"state,omitempty"`
+	Stats          *InstancePoolStats  `json:"stats,omitempty"`
+	Status         *InstancePoolStatus `json:"status,omitempty"`
+}
+
+// InstancePoolStats are the number of instances of a pool, by whether they
+// are used by a cluster and whether they are still being acquired.
+type InstancePoolStats struct {
+	UsedCount        int32 `json:"used_count"`
+	IdleCount        int32 `json:"idle_count"`
+	PendingUsedCount int32 `json:"pending_used_count"`
+	PendingIdleCount int32 `json:"pending_idle_count"`
+}
+
+// InstancePoolStatus is the status of the instances that a pool is
+// acquiring.
+type InstancePoolStatus struct {
+	PendingInstanceErrors []PendingInstanceError `json:"pending_instance_errors,omitempty"`
+}
+
+// PendingInstanceError is an error acquiring an instance for a pool.
+type PendingInstanceError struct {
+	InstanceID string `json:"instance_id"`
+	Message    string `json:"message"`
+}
+
+// InstancePoolUtilization summarizes how much of an instance pool is in
+// use.
+type InstancePoolUtilization struct {
+	// Used are the instances used by clusters, including the ones being
+	// acquired for them.
+	Used int32
+	// Idle are the instances ready for clusters, including the ones being
+	// acquired for the pool.
+	Idle int32
+	// Pending are the instances being acquired, used or idle.
+	Pending int32
+	// Total are all of the instances of the pool.
+	Total int32
+	// Capacity is the maximum number of instances of the pool, or 0 if it
+	// is not limited.
+	Capacity int32
+	// Available is the number of instances that clusters can still get
+	// from the pool, idle or not yet acquired, or -1 if the capacity is not
+	// limited.
+	Available int32
+	// UsedRatio is the ratio of Used to Capacity, or to Total if the
+	// capacity is not limited. It is 0 for an empty pool.
+	UsedRatio float64
+}
+
+// Utilization summarizes the stats of the pool. It is zero, except for the
+// capacity, if the pool has no stats.
+func (p *InstancePool) Utilization() InstancePoolUtilization {
+	u := InstancePoolUtilization{Capacity: p.MaxCapacity}
+	if p.Stats != nil {
+		u.Used = p.Stats.UsedCount + p.Stats.PendingUsedCount
+		u.Idle = p.Stats.IdleCount + p.Stats.PendingIdleCount
+		u.Pending = p.Stats.PendingUsedCount + p.Stats.PendingIdleCount
+	}
+	u.Total = u.Used + u.Idle
+
+	limit := u.Total
+	if u.Capacity > 0 {
+		limit = u.Capacity
+		u.Available = u.Capacity - u.Used
+		if u.Available < 0 {
+			u.Available = 0
+		}
+	} else {
+		u.Available = -1
+	}
+	if limit > 0 {
+		u.UsedRatio = float64(u.Used) / float64(limit)
+	}
+	return u
+}
@@ -0,0 +1,175 @@
+package databricks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// InstancePoolsService is a service for interacting with the instance
+// pools.
+type InstancePoolsService struct {
+	client Client
+}
+
+// Create creates an instance pool and returns its ID. The pool starts
+// acquiring MinIdleInstances instances right away.
+func (s *InstancePoolsService) Create(
+	ctx context.Context,
+	spec *InstancePoolSpec,
+) (string, error) {
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := s.client.newRequest(
+		ctx,
+		"InstancePoolsService.Create",
+		http.MethodPost,
+		"2.0/instance-pools/create",
+		bytes.NewBuffer(raw),
+	)
+	if err != nil {
+		return "", err
+	}
+	res, err := s.client.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	decoder := json.NewDecoder(res.Body)
+
+	createRes := struct {
+		InstancePoolID string `json:"instance_pool_id"`
+	}{}
+	err = decoder.Decode(&createRes)
+
+	return createRes.InstancePoolID, err
+}
+
+// Edit modifies the configuration of an existing instance pool.
+func (s *InstancePoolsService) Edit(
+	ctx context.Context,
+	editReq *InstancePoolEditRequest,
+) error {
+	raw, err := json.Marshal(editReq)
+	if err != nil {
+		return err
+	}
+
+	req, err := s.client.newRequest(
+		ctx,
+		"InstancePoolsService.Edit",
+		http.MethodPost,
+		"2.0/instance-pools/edit",
+		bytes.NewBuffer(raw),
+	)
+	if err != nil {
+		return err
+	}
+	res, err := s.client.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return nil
+}
+
+// Get retrieves the information and stats of an instance pool given its
+// identifier.
+func (s *InstancePoolsService) Get(
+	ctx context.Context,
+	instancePoolID string,
+) (*InstancePool, error) {
+	req, err := s.client.newRequest(
+		ctx,
+		"InstancePoolsService.Get",
+		http.MethodGet,
+		"2.0/instance-pools/get",
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	q.Add("instance_pool_id", instancePoolID)
+	req.URL.RawQuery = q.Encode()
+	res, err := s.client.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	decoder := json.NewDecoder(res.Body)
+
+	var pool InstancePool
+	err = decoder.Decode(&pool)
+
+	return &pool, err
+}
+
+// List returns the information and stats of all of the instance pools.
+func (s *InstancePoolsService) List(
+	ctx context.Context,
+) ([]InstancePool, error) {
+	req, err := s.client.newRequest(
+		ctx,
+		"InstancePoolsService.List",
+		http.MethodGet,
+		"2.0/instance-pools/list",
+		nil,
+	)
+	if err != nil {
+		return []InstancePool{}, err
+	}
+	res, err := s.client.do(req)
+	if err != nil {
+		return []InstancePool{}, err
+	}
+	defer res.Body.Close()
+	decoder := json.NewDecoder(res.Body)
+
+	listRes := struct {
+		InstancePools []InstancePool `json:"instance_pools"`
+	}{[]InstancePool{}}
+	err = decoder.Decode(&listRes)
+
+	return listRes.InstancePools, err
+}
+
+// Delete permanently deletes an instance pool. Its idle instances are
+// terminated, and clusters that use it keep their instances but can no
+// longer get new ones from it.
+func (s *InstancePoolsService) Delete(
+	ctx context.Context,
+	instancePoolID string,
+) error {
+	raw, err := json.Marshal(struct {
+		InstancePoolID string `json:"instance_pool_id"`
+	}{
+		instancePoolID,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := s.client.newRequest(
+		ctx,
+		"InstancePoolsService.Delete",
+		http.MethodPost,
+		"2.0/instance-pools/delete",
+		bytes.NewBuffer(raw),
+	)
+	if err != nil {
+		return err
+	}
+	res, err := s.client.do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package databricks
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+)
+
+func badTransportInstancePoolsHelper(t *testing.T) *InstancePoolsService {
+	badTransportClient, err := NewClient(
+		"test-account",
+		ClientHTTPClient(BadTransportHTTPClient),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if badTransportClient == nil {
+		t.Fatalf("NewClient returned nil")
+	}
+	pools := badTransportClient.InstancePools()
+	if pools == nil {
+		t.Fatalf("InstancePools returned nil")
+	}
+	return pools
+}
+
+func non200InstancePoolsHelper(t *testing.T) *InstancePoolsService {
+	non200Client, err := NewClient(
+		"test-account",
+		ClientHTTPClient(Non200HTTPClient),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if non200Client == nil {
+		t.Fatalf("NewClient returned nil")
+	}
+	pools := non200Client.InstancePools()
+	if pools == nil {
+		t.Fatalf("InstancePools returned nil")
+	}
+	return pools
+}
+
+func successInstancePoolsHelper(
+	t *testing.T,
+	res []byte,
+	code int,
+) *InstancePoolsService {
+	successClient, err := NewClient(
+		"test-account",
+		ClientHTTPClient(injectedHTTPClient(
+			http.Response{
+				StatusCode: code,
+				Body: nopCloser{
+					bytes.NewBuffer(res),
+				},
+			},
+		)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if successClient == nil {
+		t.Fatalf("NewClient returned nil")
+	}
+	pools := successClient.InstancePools()
+	if pools == nil {
+		t.Fatalf("InstancePools returned nil")
+	}
+	return pools
+}
+
+func Test_InstancePoolsService_Create(t *testing.T) {
+	t.Parallel()
+	pools := successInstancePoolsHelper(t,
+		[]byte(`{"instance_pool_id": "0101-120000-brick1-pool-ABCD1234"}`),
+		http.StatusOK)
+
+	ctx := context.Background()
+	spec := &InstancePoolSpec{
+		InstancePoolName:       "warm",
+		NodeTypeID:             "i3.xlarge",
+		MinIdleInstances:       2,
+		MaxCapacity:            10,
+		PreloadedSparkVersions: []string{"13.3.x-scala2.12"},
+	}
+	poolID, err := pools.Create(ctx, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if poolID != "0101-120000-brick1-pool-ABCD1234" {
+		t.Fatalf("Unexpected pool ID %q", poolID)
+	}
+
+	// Non 200 test
+	pools = non200InstancePoolsHelper(t)
+
+	_, err = pools.Create(ctx, spec)
+	if err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+
+	// Transport error test
+	pools = badTransportInstancePoolsHelper(t)
+
+	_, err = pools.Create(ctx, spec)
+	if err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+}
+
+func Test_InstancePoolsService_Edit(t *testing.T) {
+	t.Parallel()
+	pools := successInstancePoolsHelper(t, []byte(`{}`), http.StatusOK)
+
+	ctx := context.Background()
+	editReq := &InstancePoolEditRequest{
+		InstancePoolID: "0101-120000-brick1-pool-ABCD1234",
+		InstancePoolSpec: InstancePoolSpec{
+			InstancePoolName: "warm",
+			NodeTypeID:       "i3.xlarge",
+			MaxCapacity:      20,
+		},
+	}
+	if err := pools.Edit(ctx, editReq); err != nil {
+		t.Fatal(err)
+	}
+
+	// Non 200 test
+	pools = non200InstancePoolsHelper(t)
+
+	if err := pools.Edit(ctx, editReq); err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+
+	// Transport error test
+	pools = badTransportInstancePoolsHelper(t)
+
+	if err := pools.Edit(ctx, editReq); err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+}
+
+func Test_InstancePoolsService_Get(t *testing.T) {
+	t.Parallel()
+	pools := successInstancePoolsHelper(t,
+		readPayload(t, "instance_pools_get.json"), http.StatusOK)
+
+	ctx := context.Background()
+	pool, err := pools.Get(ctx, "0101-120000-brick1-pool-ABCD1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.State != InstancePoolActive || pool.Stats == nil ||
+		pool.Stats.UsedCount != 3 {
+		t.Fatalf("Unexpected pool: %+v", pool)
+	}
+
+	// Non 200 test
+	pools = non200InstancePoolsHelper(t)
+
+	_, err = pools.Get(ctx, "0101-120000-brick1-pool-ABCD1234")
+	if err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+
+	// Transport error test
+	pools = badTransportInstancePoolsHelper(t)
+
+	_, err = pools.Get(ctx, "0101-120000-brick1-pool-ABCD1234")
+	if err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+}
+
+func Test_InstancePoolsService_List(t *testing.T) {
+	t.Parallel()
+	pools := successInstancePoolsHelper(t, []byte(`{
+		"instance_pools": [
+			{"instance_pool_id": "pool-a", "instance_pool_name": "a", "node_type_id": "i3.xlarge"},
+			{"instance_pool_id": "pool-b", "instance_pool_name": "b", "node_type_id": "i3.xlarge"}
+		]
+	}`), http.StatusOK)
+
+	ctx := context.Background()
+	list, err := pools.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[1].InstancePoolID != "pool-b" {
+		t.Fatalf("Unexpected pools: %+v", list)
+	}
+
+	// Non 200 test
+	pools = non200InstancePoolsHelper(t)
+
+	_, err = pools.List(ctx)
+	if err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+
+	// Transport error test
+	pools = badTransportInstancePoolsHelper(t)
+
+	_, err = pools.List(ctx)
+	if err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+}
+
+func Test_InstancePoolsService_Delete(t *testing.T) {
+	t.Parallel()
+	pools := successInstancePoolsHelper(t, []byte(`{}`), http.StatusOK)
+
+	ctx := context.Background()
+	if err := pools.Delete(ctx, "pool-a"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Non 200 test
+	pools = non200InstancePoolsHelper(t)
+
+	if err := pools.Delete(ctx, "pool-a"); err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+
+	// Transport error test
+	pools = badTransportInstancePoolsHelper(t)
+
+	if err := pools.Delete(ctx, "pool-a"); err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+}
@@ -0,0 +1,79 @@
+package databricks
+
+import (
+	"testing"
+)
+
+func Test_InstancePool_RoundTrip(t *testing.T) {
+	t.Parallel()
+	var pool InstancePool
+	expectRoundTrip(t, readPayload(t, "instance_pools_get.json"), &pool)
+
+	if pool.IdleInstanceAutoterminationMinutes == nil ||
+		*pool.IdleInstanceAutoterminationMinutes != 0 {
+		t.Fatalf("Expected idle instances to terminate immediately, got %v",
+			pool.IdleInstanceAutoterminationMinutes)
+	}
+	if pool.AWSAttributes.Availability != Spot ||
+		pool.DiskSpec.DiskType.EBSVolumeType != SSD ||
+		len(pool.Status.PendingInstanceErrors) != 1 {
+		t.Fatalf("Unexpected pool: %+v", pool)
+	}
+}
+
+func Test_InstancePool_Utilization(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		pool     InstancePool
+		expected InstancePoolUtilization
+	}{
+		{
+			name: "no stats",
+			pool: InstancePool{InstancePoolSpec: InstancePoolSpec{MaxCapacity: 10}},
+			expected: InstancePoolUtilization{
+				Capacity:  10,
+				Available: 10,
+			},
+		},
+		{
+			name: "limited",
+			pool: InstancePool{
+				InstancePoolSpec: InstancePoolSpec{MaxCapacity: 10},
+				Stats: &InstancePoolStats{
+					UsedCount:        3,
+					IdleCount:        2,
+					PendingUsedCount: 1,
+					PendingIdleCount: 1,
+				},
+			},
+			expected: InstancePoolUtilization{
+				Used:      4,
+				Idle:      3,
+				Pending:   2,
+				Total:     7,
+				Capacity:  10,
+				Available: 6,
+				UsedRatio: 0.4,
+			},
+		},
+		{
+			name: "unlimited",
+			pool: InstancePool{
+				Stats: &InstancePoolStats{UsedCount: 3, IdleCount: 1},
+			},
+			expected: InstancePoolUtilization{
+				Used:      3,
+				Idle:      1,
+				Total:     4,
+				Available: -1,
+				UsedRatio: 0.75,
+			},
+		},
+	}
+	for _, test := range tests {
+		if got := test.pool.Utilization(); got != test.expected {
+			t.Fatalf("%s: expected %+v, got %+v", test.name, test.expected, got)
+		}
+	}
+}
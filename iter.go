@@ -0,0 +1,189 @@
+package databricks
+
+import "context"
+
+// PageFunc fetches the next page of a paginated list. It returns the items
+// of the page and whether there are more pages after it.
+type PageFunc[T any] func(ctx context.Context) ([]T, bool, error)
+
+// Iterator iterates over the items of a paginated list, fetching the pages
+// lazily as they are needed:
+//
+//	it := client.Jobs().RunsIter(ctx, &databricks.JobRunListRequest{JobID: 7})
+//	for it.Next() {
+//		run := it.Item()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// An Iterator stops with the error of its context once the context is done.
+// It is not safe for concurrent use.
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch PageFunc[T]
+	page  []T
+	item  T
+	more  bool
+	pages int
+	err   error
+}
+
+// NewIterator returns an Iterator over the pages fetched by fetch, which is
+// called with ctx until it reports that there are no more pages. It is
+// useful for returning iterators from mocks.
+func NewIterator[T any](ctx context.Context, fetch PageFunc[T]) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, fetch: fetch, more: true}
+}
+
+// Next advances the iterator to the next item, fetching the next page if
+// needed. It returns false when there are no more items or an error
+// occurred, which is then returned by Err.
+func (it *Iterator[T]) Next() bool {
+	var zero T
+	it.item = zero
+	if it.err != nil {
+		return false
+	}
+	for len(it.page) == 0 {
+		if !it.more {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		page, more, err := it.fetch(it.ctx)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.pages++
+		it.page, it.more = page, more
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+	it.item, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Item returns the current item. It is only valid after Next returned true.
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns the error that stopped the iterator, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Pages returns the number of pages fetched so far.
+func (it *Iterator[T]) Pages() int {
+	return it.pages
+}
+
+// Collect returns the remaining items of the iterator. On error, the items
+// collected so far are returned along with it.
+func (it *Iterator[T]) Collect() ([]T, error) {
+	items := []T{}
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// IterOpt is used for configuring an iterator.
+type IterOpt func(*iterConfig)
+
+type iterConfig struct {
+	pageSize int
+}
+
+// IterPageSize configures the number of items fetched per page. It
+// overrides the limit of the list request, if any. By default the page
+// size of the list request or of the API is used.
+func IterPageSize(n int) IterOpt {
+	return func(c *iterConfig) {
+		c.pageSize = n
+	}
+}
+
+func newIterConfig(opts []IterOpt) iterConfig {
+	var cfg iterConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// ListIter iterates over all jobs, a page at a time.
+func (s *JobsService) ListIter(
+	ctx context.Context,
+	opts ...IterOpt,
+) *Iterator[Job] {
+	return s.listIter(ctx, "JobsService.ListPage", opts)
+}
+
+// listIter iterates over all jobs, reporting each page to middleware as a
+// call of method.
+func (s *JobsService) listIter(
+	ctx context.Context,
+	method string,
+	opts []IterOpt,
+) *Iterator[Job] {
+	cfg := newIterConfig(opts)
+	offset := 0
+	return NewIterator(ctx, func(ctx context.Context) ([]Job, bool, error) {
+		jobs, more, err := s.listPage(ctx, method, offset, cfg.pageSize)
+		offset += len(jobs)
+		// An empty page would never end.
+		return jobs, more && len(jobs) > 0, err
+	})
+}
+
+// RunsIter iterates over the runs matching a list request, a page at a
+// time, starting at its offset.
+func (s *JobsService) RunsIter(
+	ctx context.Context,
+	runListReq *JobRunListRequest,
+	opts ...IterOpt,
+) *Iterator[Run] {
+	cfg := newIterConfig(opts)
+	listReq := *runListReq
+	if cfg.pageSize > 0 {
+		listReq.Limit = cfg.pageSize
+	}
+	return NewIterator(ctx, func(ctx context.Context) ([]Run, bool, error) {
+		runs, more, err := s.RunsList(ctx, &listReq)
+		listReq.Offset += len(runs)
+		return runs, more && len(runs) > 0, err
+	})
+}
+
+// EventsIter iterates over the events matching an event request, a page at
+// a time, by following the next page of each response.
+func (s *ClusterService) EventsIter(
+	ctx context.Context,
+	eventReq *ClusterEventRequest,
+	opts ...IterOpt,
+) *Iterator[ClusterEvent] {
+	cfg := newIterConfig(opts)
+	next := *eventReq
+	if cfg.pageSize > 0 {
+		next.Limit = int64(cfg.pageSize)
+	}
+	return NewIterator(ctx, func(ctx context.Context) ([]ClusterEvent, bool, error) {
+		eventRes, err := s.Events(ctx, &next)
+		if err != nil {
+			return nil, false, err
+		}
+		if eventRes.NextPage == nil || len(eventRes.Events) == 0 {
+			return eventRes.Events, false, nil
+		}
+		next = *eventRes.NextPage
+		return eventRes.Events, true, nil
+	})
+}
@@ -0,0 +1,186 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// pageServer serves a list of n items from an endpoint, paginated with the
+// offset and limit query parameters, and records the queries.
+func pageServer(
+	t *testing.T,
+	endpoint string,
+	field string,
+	n int,
+) (*Client, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/"+endpoint {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			mu.Lock()
+			queries = append(queries, r.URL.RawQuery)
+			mu.Unlock()
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			if limit == 0 {
+				limit = 20
+			}
+			items := []map[string]int{}
+			for i := offset; i < n && i < offset+limit; i++ {
+				items = append(items, map[string]int{"job_id": i + 1})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				field:      items,
+				"has_more": offset+limit < n,
+			})
+		},
+	))
+	t.Cleanup(server.Close)
+	client, err := NewClient("", ClientHost(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string{}, queries...)
+	}
+}
+
+func Test_JobsService_RunsIter(t *testing.T) {
+	t.Parallel()
+	client, queries := pageServer(t, "2.0/jobs/runs/list", "runs", 7)
+	completed := true
+	it := client.Jobs().RunsIter(context.Background(), &JobRunListRequest{
+		JobID:        5,
+		CompleteOnly: &completed,
+		Limit:        20,
+	}, IterPageSize(3))
+	runs, err := it.Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 7 || it.Pages() != 3 || runs[6].JobID != 7 {
+		t.Fatalf("Expected 7 runs in 3 pages, got %d in %d: %+v",
+			len(runs), it.Pages(), runs)
+	}
+	expected := []string{
+		"completed_only=true&job_id=5&limit=3",
+		"completed_only=true&job_id=5&limit=3&offset=3",
+		"completed_only=true&job_id=5&limit=3&offset=6",
+	}
+	got := queries()
+	if len(got) != len(expected) {
+		t.Fatalf("Unexpected queries: %v", got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("Expected query %s, got %s", expected[i], got[i])
+		}
+	}
+}
+
+func Test_JobsService_ListIter(t *testing.T) {
+	t.Parallel()
+	client, queries := pageServer(t, "2.0/jobs/list", "jobs", 45)
+	jobs, err := client.Jobs().List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 45 || len(queries()) != 3 {
+		t.Fatalf("Expected 45 jobs in 3 pages, got %d in %d",
+			len(jobs), len(queries()))
+	}
+
+	it := client.Jobs().ListIter(context.Background(), IterPageSize(50))
+	jobs, err = it.Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 45 || it.Pages() != 1 {
+		t.Fatalf("Expected 45 jobs in 1 page, got %d in %d",
+			len(jobs), it.Pages())
+	}
+}
+
+func Test_ClusterService_EventsIter(t *testing.T) {
+	t.Parallel()
+	client, polls := sequenceServer(t, "2.0/clusters/events",
+		map[string]interface{}{
+			"events": []map[string]interface{}{
+				{"cluster_id": "a", "type": "RUNNING", "timestamp": 3},
+				{"cluster_id": "a", "type": "CREATING", "timestamp": 2},
+			},
+			"next_page":   map[string]interface{}{"cluster_id": "a", "offset": 2, "limit": 2},
+			"total_count": 3,
+		},
+		map[string]interface{}{
+			"events": []map[string]interface{}{
+				{"cluster_id": "a", "type": "STARTING", "timestamp": 1},
+			},
+			"total_count": 3,
+		},
+	)
+	events, err := client.Cluster().EventsIter(context.Background(),
+		&ClusterEventRequest{ClusterID: "a"}, IterPageSize(2)).Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 3 || *polls != 1 {
+		t.Fatalf("Expected 3 events in 2 pages, got %+v", events)
+	}
+	if events[2].Type != ClusterEventStarting || events[2].Timestamp != 1 {
+		t.Fatalf("Unexpected event: %+v", events[2])
+	}
+}
+
+func Test_Iterator_Cancel(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	fetches := 0
+	it := NewIterator(ctx, func(ctx context.Context) ([]int, bool, error) {
+		fetches++
+		return []int{1, 2}, true, nil
+	})
+	if !it.Next() || it.Item() != 1 {
+		t.Fatalf("Expected the first item")
+	}
+	cancel()
+	if it.Next() {
+		t.Fatalf("Expected the iterator to stop")
+	}
+	if !errors.Is(it.Err(), context.Canceled) || fetches != 1 {
+		t.Fatalf("Unexpected error after %d fetches: %v", fetches, it.Err())
+	}
+}
+
+func Test_Iterator_Error(t *testing.T) {
+	t.Parallel()
+	fail := errors.New("fail")
+	fetches := 0
+	it := NewIterator(context.Background(), func(ctx context.Context) ([]int, bool, error) {
+		fetches++
+		if fetches > 1 {
+			return nil, false, fail
+		}
+		return []int{1, 2}, true, nil
+	})
+	items, err := it.Collect()
+	if !errors.Is(err, fail) || len(items) != 2 {
+		t.Fatalf("Unexpected result: %v %v", items, err)
+	}
+	if it.Next() || fetches != 2 {
+		t.Fatalf("Expected the iterator to stay stopped")
+	}
+}
@@ -1,7 +1,5 @@
 package databricks
 
-import "time"
-
 // View is a view of a job.
 type View struct {
 	Content string `json:"content"`
@@ -9,11 +7,112 @@ type View struct {
 	Type    string `json:"type"`
 }
 
+// LifeCycleState is the life cycle state of a run.
+type LifeCycleState string
+
+const (
+	// LifeCycleStatePending is a run that has been triggered but whose
+	// cluster and execution context are being prepared.
+	LifeCycleStatePending LifeCycleState = "PENDING"
+	// LifeCycleStateRunning is a run that is being executed.
+	LifeCycleStateRunning LifeCycleState = "RUNNING"
+	// LifeCycleStateTerminating is a run that has completed, and whose
+	// cluster and execution context are being cleaned up.
+	LifeCycleStateTerminating LifeCycleState = "TERMINATING"
+	// LifeCycleStateTerminated is a run that has completed, and whose
+	// cluster and execution context have been cleaned up.
+	LifeCycleStateTerminated LifeCycleState = "TERMINATED"
+	// LifeCycleStateSkipped is a run that was skipped, because a previous
+	// run of the same job was already active.
+	LifeCycleStateSkipped LifeCycleState = "SKIPPED"
+	// LifeCycleStateInternalError is a run that failed because of an
+	// exceptional state in the Jobs service, such as a network failure.
+	LifeCycleStateInternalError LifeCycleState = "INTERNAL_ERROR"
+	// LifeCycleStateBlocked is a run that is blocked on an upstream
+	// dependency.
+	LifeCycleStateBlocked LifeCycleState = "BLOCKED"
+	// LifeCycleStateWaitingForRetry is a run that failed and is waiting to
+	// be retried.
+	LifeCycleStateWaitingForRetry LifeCycleState = "WAITING_FOR_RETRY"
+	// LifeCycleStateQueued is a run that is queued because the job reached
+	// its concurrency limit.
+	LifeCycleStateQueued LifeCycleState = "QUEUED"
+)
+
+// IsTerminal returns whether a run in the state has finished and will not
+// change state anymore.
+func (s LifeCycleState) IsTerminal() bool {
+	switch s {
+	case LifeCycleStateTerminated,
+		LifeCycleStateSkipped,
+		LifeCycleStateInternalError:
+		return true
+	}
+	return false
+}
+
+// ResultState is the result of a finished run.
+type ResultState string
+
+const (
+	// ResultStateSuccess is a run that completed successfully.
+	ResultStateSuccess ResultState = "SUCCESS"
+	// ResultStateFailed is a run that completed with an error.
+	ResultStateFailed ResultState = "FAILED"
+	// ResultStateTimedOut is a run that was stopped after reaching its
+	// timeout.
+	ResultStateTimedOut ResultState = "TIMEDOUT"
+	// ResultStateCanceled is a run that was canceled at the request of a
+	// user.
+	ResultStateCanceled ResultState = "CANCELED"
+	// ResultStateMaximumConcurrentRunsReached is a run that was skipped
+	// because the job reached its concurrency limit.
+	ResultStateMaximumConcurrentRunsReached ResultState = "MAXIMUM_CONCURRENT_RUNS_REACHED"
+	// ResultStateExcluded is a run that was skipped because its condition
+	// was not met.
+	ResultStateExcluded ResultState = "EXCLUDED"
+	// ResultStateSuccessWithFailures is a run that completed, but some of
+	// its tasks failed.
+	ResultStateSuccessWithFailures ResultState = "SUCCESS_WITH_FAILURES"
+	// ResultStateUpstreamFailed is a run that was not executed because an
+	// upstream dependency failed.
+	ResultStateUpstreamFailed ResultState = "UPSTREAM_FAILED"
+	// ResultStateUpstreamCanceled is a run that was not executed because
+	// an upstream dependency was canceled.
+	ResultStateUpstreamCanceled ResultState = "UPSTREAM_CANCELED"
+)
+
+// IsSuccess returns whether the run completed successfully. Runs that
+// completed with failed tasks are not successful.
+func (s ResultState) IsSuccess() bool {
+	return s == ResultStateSuccess
+}
+
+// TriggerType is the type of trigger that started a run.
+type TriggerType string
+
+const (
+	// TriggerPeriodic is a run started by a cron schedule.
+	TriggerPeriodic TriggerType = "PERIODIC"
+	// TriggerOneTime is a run started by a run-now or runs-submit request.
+	TriggerOneTime TriggerType = "ONE_TIME"
+	// TriggerRetry is a run started as a retry of a failed run.
+	TriggerRetry TriggerType = "RETRY"
+	// TriggerRunJobTask is a run started by a run job task.
+	TriggerRunJobTask TriggerType = "RUN_JOB_TASK"
+	// TriggerFileArrival is a run started by the arrival of a file.
+	TriggerFileArrival TriggerType = "FILE_ARRIVAL"
+	// TriggerTable is a run started by the update of a table.
+	TriggerTable TriggerType = "TABLE"
+	// TriggerContinuous is a run of a continuous job.
+	TriggerContinuous TriggerType = "CONTINUOUS"
+)
+
 // RunState is a job run state.
 type RunState struct {
-	LifeCycleState string `json:"life_cycle_state"` // TODO(daniel) make this an enum
-	ResultState    string `json:"result_state"`     // TODO(daniel) make this an enum
-	StateMessage   string `json:"state_message"`
+	LifeCycleState LifeCycleState `json:"life_cycle_state"`
+	ResultState    ResultState    `json:"result_state"`
+	StateMessage   string         `json:"state_message"`
 }
 
 // JobTask is a job task.
@@ -33,14 +132,14 @@ type JobRunGetResponse struct {
 	State                RunState        `json:"state"`
 	Schedule             *CronSchedule   `json:"schedule"`
 	Task                 JobTask         `json:"task"`
-	ClusterSpec          ClusterSpec     `json:"cluster_spec"`
+	ClusterSpec          RunClusterSpec  `json:"cluster_spec"`
 	ClusterInstance      ClusterInstance `json:"cluster_instance"`
 	OverridingParameters RunParameters   `json:"overriding_parameters"`
-	StartTime            int64           `json:"start_time"`
-	SetupDuration        int64           `json:"setup_duration"`
-	ExecutionDuration    int64           `json:"execution_duration"`
-	CleanupDuration      int64           `json:"cleanup_duration"`
-	Trigger              string          `json:"trigger"` // TODO(daniel) enum this?
+	StartTime            EpochMillis     `json:"start_time"`
+	SetupDuration        DurationMillis  `json:"setup_duration"`
+	ExecutionDuration    DurationMillis  `json:"execution_duration"`
+	CleanupDuration      DurationMillis  `json:"cleanup_duration"`
+	Trigger              TriggerType     `json:"trigger"`
 	CreatorUserName      string          `json:"creator_user_name"`
 	RunPageurl           *string         `json:"run_pageurl"`
 }
@@ -48,7 +147,7 @@ type JobRunGetResponse struct {
 // JobRunListRequest is used to request Run information.
 type JobRunListRequest struct {
 	ActiveOnly   *bool `json:"active_only,omitempty"`
-	CompleteOnly *bool `json:"complete_only,omitempty"`
+	CompleteOnly *bool `json:"completed_only,omitempty"`
 	JobID        int64 `json:"job_id"`
 	Offset       int   `json:"offset"`
 	Limit        int   `json:"limit"`
@@ -81,7 +180,7 @@ type JobGetResponse struct {
 	JobID           int64       `json:"job_id"`
 	CreatorUserName string      `json:"creator_user_name"`
 	Settings        JobSettings `json:"settings"`
-	CreatedTime     int64       `json:"created_time"`
+	CreatedTime     EpochMillis `json:"created_time"`
 }
 
 // JobEmailNotifications is set of email addresses that will be notified when runs of this job begin or complete as well as when this job is deleted.
@@ -125,21 +224,7 @@ type CronSchedule struct {
 
 // NewCluster is settings for a new Cluster.
 type NewCluster struct {
-	NumWorkers             *int32           `json:"num_workers"`
-	Autoscale              *Autoscale       `json:"autoscale"`
-	ClusterName            string           `json:"cluster_name"`
-	SparkVersion           string           `json:"spark_version"`
-	SparkConf              *S3StorageInfo   `json:"spark_conf"`
-	AWSAttributes          AWSAttributes    `json:"aws_attributes"`
-	NodeTypeID             string           `json:"node_type_id"`
-	DriverNodeTypeID       string           `json:"driver_node_type_id"`
-	SSHPublicKeys          []string         `json:"ssh_public_keys"`
-	CustomTags             []ClusterTag     `json:"custom_tags"`
-	ClusterLogConf         ClusterLogConf   `json:"cluster_log_conf"`
-	InitScripts            []InitScriptInfo `json:"init_scripts"`
-	SparkEnvVars           SparkEnvPair     `json:"spark_env_vars"`
-	AutoterminationMinutes *int32           `json:"autotermination_minutes"`
-	EnableElasticDisk      bool             `json:"enable_elastic_disk"`
+	ClusterSpec
 }
 
 // NotebookOutput is the output of a Notebook.
@@ -201,14 +286,15 @@ type Job struct {
 	JobID           int64       `json:"job_id"`
 	CreatorUserName string      `json:"creator_user_name"`
 	Settings        JobSettings `json:"settings"`
-	CreatedTime     time.Time   `json:"created_time"`
+	CreatedTime     EpochMillis `json:"created_time"`
 }
 
-// ClusterSpec is a Cluster specification.
-type ClusterSpec struct {
-	ExistingClusterID *string     `json:"existing_cluster_id"`
-	NewCluster        *NewCluster `json:"new_cluster"`
-	Libraries         []Library
+// RunClusterSpec is the cluster that a run ran on, and the libraries that
+// were installed on it.
+type RunClusterSpec struct {
+	ExistingClusterID *string     `json:"existing_cluster_id,omitempty"`
+	NewCluster        *NewCluster `json:"new_cluster,omitempty"`
+	Libraries         []Library   `json:"libraries,omitempty"`
 }
 
 // RunParameters are parameters for this run. Only one of jar_params,
@@ -232,15 +318,15 @@ type Run struct {
 	CreatorUserName      string       `json:"creator_user_name"`
 	NumberInJob          int64        `json:"number_in_job"`
 	OriginalAttemptRunid int64        `json:"original_attempt_runid"`
-	State                string       `json:"state"` // TODO(daniel)
+	State                RunState     `json:"state"`
 	Schedule             CronSchedule `json:"schedule"`
 	// Task                 JobTask         `json:"task"` // TODO(daniel)
-	ClusterSpec          ClusterSpec     `json:"cluster_spec"`
+	ClusterSpec          RunClusterSpec  `json:"cluster_spec"`
 	ClusterInstance      ClusterInstance `json:"cluster_instance"`
 	OverridingParameters RunParameters   `json:"overriding_parameters"`
-	StartTime            time.Time       `json:"start_time"`
-	SetupDuration        int64           `json:"setup_duration"`
-	ExecutionDuration    int64           `json:"execution_duration"`
-	CleanupDuration      int64           `json:"cleanup_duration"`
-	//Trigger              TriggerType     `json:"trigger"` // TODO(daniel)
+	StartTime            EpochMillis     `json:"start_time"`
+	SetupDuration        DurationMillis  `json:"setup_duration"`
+	ExecutionDuration    DurationMillis  `json:"execution_duration"`
+	CleanupDuration      DurationMillis  `json:"cleanup_duration"`
+	Trigger              TriggerType     `json:"trigger"`
 }
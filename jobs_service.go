@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 )
 
 // JobsService is a service for interacting with the DBFS.
@@ -23,23 +24,20 @@ func (s *JobsService) Create(
 		return int64(-1), err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"JobsService.Create",
 		http.MethodPost,
-		s.client.url+"2.0/jobs/create",
+		"2.0/jobs/create",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return int64(-1), err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return int64(-1), err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return int64(-1), fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
@@ -55,32 +53,59 @@ func (s *JobsService) Create(
 func (s *JobsService) List(
 	ctx context.Context,
 ) ([]Job, error) {
-	req, err := http.NewRequest(
-		http.MethodPost,
-		s.client.url+"2.0/jobs/list",
+	return s.listIter(ctx, "JobsService.List", nil).Collect()
+}
+
+// ListPage lists a page of the jobs, starting at offset. A limit of 0 uses
+// the page size of the API. It also returns whether there are more jobs
+// after the page.
+func (s *JobsService) ListPage(
+	ctx context.Context,
+	offset int,
+	limit int,
+) ([]Job, bool, error) {
+	return s.listPage(ctx, "JobsService.ListPage", offset, limit)
+}
+
+// listPage lists a page of the jobs. The method is the name of the public
+// method, as reported to middleware.
+func (s *JobsService) listPage(
+	ctx context.Context,
+	method string,
+	offset int,
+	limit int,
+) ([]Job, bool, error) {
+	req, err := s.client.newRequest(
+		ctx,
+		method,
+		http.MethodGet,
+		"2.0/jobs/list",
 		nil,
 	)
 	if err != nil {
-		return []Job{}, err
+		return []Job{}, false, err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
-	if err != nil {
-		return []Job{}, err
+	q := req.URL.Query()
+	if offset > 0 {
+		q.Set("offset", strconv.Itoa(offset))
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []Job{}, fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	req.URL.RawQuery = q.Encode()
+	res, err := s.client.do(req)
+	if err != nil {
+		return []Job{}, false, err
 	}
 	defer res.Body.Close()
-	decoder := json.NewDecoder(res.Body)
-
 	listRes := struct {
-		Jobs []Job
+		Jobs    []Job `json:"jobs"`
+		HasMore bool  `json:"has_more"`
 	}{}
+	decoder := json.NewDecoder(res.Body)
 	err = decoder.Decode(&listRes)
 
-	return listRes.Jobs, err
+	return listRes.Jobs, listRes.HasMore, err
 }
 
 // Delete removes a job.
@@ -89,30 +114,28 @@ func (s *JobsService) Delete(
 	jobID int64,
 ) error {
 	raw, err := json.Marshal(struct {
-		JobID int64
+		JobID int64 `json:"job_id"`
 	}{
 		jobID,
 	})
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"JobsService.Delete",
 		http.MethodPost,
-		s.client.url+"2.0/jobs/delete",
+		"2.0/jobs/delete",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -122,26 +145,23 @@ func (s *JobsService) Get(
 	ctx context.Context,
 	jobID int64,
 ) (*JobGetResponse, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"JobsService.Get",
 		http.MethodGet,
-		s.client.url+"2.0/jobs/get",
+		"2.0/jobs/get",
 		nil,
 	)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
 	q := req.URL.Query()
 	q.Add("job_id", fmt.Sprintf("%d", jobID))
 	req.URL.RawQuery = q.Encode()
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return nil, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return nil, fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
@@ -159,8 +179,8 @@ func (s *JobsService) Reset(
 	settings JobSettings,
 ) error {
 	raw, err := json.Marshal(struct {
-		JobID       int64
-		NewSettings JobSettings
+		JobID       int64       `json:"job_id"`
+		NewSettings JobSettings `json:"new_settings"`
 	}{
 		jobID,
 		settings,
@@ -169,23 +189,21 @@ func (s *JobsService) Reset(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"JobsService.Reset",
 		http.MethodPost,
-		s.client.url+"2.0/jobs/reset",
+		"2.0/jobs/reset",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -202,24 +220,21 @@ func (s *JobsService) RunNow(
 		return int64(-1), int64(-1), err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"JobsService.RunNow",
 		http.MethodPost,
-		s.client.url+"2.0/jobs/run-now",
+		"2.0/jobs/run-now",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return int64(-1), int64(-1), err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return int64(-1), int64(-1), err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return int64(-1), int64(-1), fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
-	defer req.Body.Close()
+	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
 	runRes := struct {
@@ -246,24 +261,21 @@ func (s *JobsService) RunSubmit(
 		return int64(-1), err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"JobsService.RunSubmit",
 		http.MethodPost,
-		s.client.url+"2.0/jobs/runs/submit",
+		"2.0/jobs/runs/submit",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return int64(-1), err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return int64(-1), err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return int64(-1), fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
-	defer req.Body.Close()
+	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
 	runRes := struct {
@@ -285,33 +297,37 @@ func (s *JobsService) RunsList(
 			"Can only request active only OR complete only")
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"JobsService.RunsList",
 		http.MethodGet,
-		s.client.url+"2.0/jobs/runs/list",
+		"2.0/jobs/runs/list",
 		nil,
 	)
 	if err != nil {
 		return []Run{}, false, err
 	}
-	req = req.WithContext(ctx)
-	req.URL.Query().Add("job_id", fmt.Sprintf("%d", runListReq.JobID))
-	req.URL.Query().Add("offset", fmt.Sprintf("%d", runListReq.Offset))
-	req.URL.Query().Add("limit", fmt.Sprintf("%d", runListReq.Limit))
+	q := req.URL.Query()
+	if runListReq.JobID != 0 {
+		q.Set("job_id", strconv.FormatInt(runListReq.JobID, 10))
+	}
+	if runListReq.Offset > 0 {
+		q.Set("offset", strconv.Itoa(runListReq.Offset))
+	}
+	if runListReq.Limit > 0 {
+		q.Set("limit", strconv.Itoa(runListReq.Limit))
+	}
 	if runListReq.ActiveOnly != nil {
-		req.URL.Query().Add(
-			"active_only", fmt.Sprintf("%#v", runListReq.ActiveOnly))
-	} else {
-		req.URL.Query().Add(
-			"complete_only", fmt.Sprintf("%#v", runListReq.CompleteOnly))
+		q.Set("active_only", strconv.FormatBool(*runListReq.ActiveOnly))
 	}
-	res, err := s.client.client.Do(req)
+	if runListReq.CompleteOnly != nil {
+		q.Set("completed_only", strconv.FormatBool(*runListReq.CompleteOnly))
+	}
+	req.URL.RawQuery = q.Encode()
+	res, err := s.client.do(req)
 	if err != nil {
 		return []Run{}, false, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []Run{}, false, fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
@@ -329,26 +345,23 @@ func (s *JobsService) RunsGet(
 	ctx context.Context,
 	runID int64,
 ) (*JobRunGetResponse, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"JobsService.RunsGet",
 		http.MethodGet,
-		s.client.url+"2.0/jobs/runs/get",
+		"2.0/jobs/runs/get",
 		nil,
 	)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
 	q := req.URL.Query()
 	q.Add("run_id", fmt.Sprintf("%d", runID))
 	req.URL.RawQuery = q.Encode()
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return nil, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return nil, fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
@@ -364,32 +377,29 @@ func (s *JobsService) RunsExport(
 	runID int64,
 	viewToExport string,
 ) ([]View, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"JobsService.RunsExport",
 		http.MethodGet,
-		s.client.url+"2.0/jobs/runs/export",
+		"2.0/jobs/runs/export",
 		nil,
 	)
 	if err != nil {
 		return []View{}, err
 	}
-	req = req.WithContext(ctx)
 	q := req.URL.Query()
 	q.Add("run_id", fmt.Sprintf("%d", runID))
 	q.Add("views_to_export", viewToExport)
 	req.URL.RawQuery = q.Encode()
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return []View{}, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []View{}, fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
 	exportRes := struct {
-		Views []View
+		Views []View `json:"views"`
 	}{[]View{}}
 	err = decoder.Decode(&exportRes)
 
@@ -405,7 +415,7 @@ func (s *JobsService) RunsCancel(
 	runID int64,
 ) error {
 	raw, err := json.Marshal(struct {
-		RunID int64
+		RunID int64 `json:"run_id"`
 	}{
 		runID,
 	})
@@ -413,23 +423,21 @@ func (s *JobsService) RunsCancel(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"JobsService.RunsCancel",
 		http.MethodPost,
-		s.client.url+"2.0/jobs/runs/cancel",
+		"2.0/jobs/runs/cancel",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -448,42 +456,37 @@ func (s *JobsService) RunsGetOutput(
 	ctx context.Context,
 	runID int64,
 ) (string, *Run, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"JobsService.RunsGetOutput",
 		http.MethodGet,
-		s.client.url+"2.0/jobs/runs/get-output",
+		"2.0/jobs/runs/get-output",
 		nil,
 	)
 	if err != nil {
 		return "", nil, err
 	}
-	req = req.WithContext(ctx)
 	q := req.URL.Query()
 	q.Add("run_id", fmt.Sprintf("%d", runID))
 	req.URL.RawQuery = q.Encode()
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return "", nil, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return "", nil, fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
 	outputRes := struct {
-		NotebookOutput *struct {
-			Result string
-		}
-		Error    *string
-		Metadata *Run
+		NotebookOutput *NotebookOutput `json:"notebook_output"`
+		Error          *string         `json:"error"`
+		Metadata       *Run            `json:"metadata"`
 	}{}
 	err = decoder.Decode(&outputRes)
 	if err != nil {
 		return "", nil, err
 	}
 	if outputRes.Error != nil {
-		return "", nil, fmt.Errorf(*outputRes.Error)
+		return "", nil, fmt.Errorf("Run failed: %s", *outputRes.Error)
 	}
 	if outputRes.NotebookOutput == nil {
 		return "", nil, fmt.Errorf("No output received")
@@ -498,7 +501,7 @@ func (s *JobsService) RunsDelete(
 	runID int64,
 ) error {
 	raw, err := json.Marshal(struct {
-		RunID int64
+		RunID int64 `json:"run_id"`
 	}{
 		runID,
 	})
@@ -506,23 +509,21 @@ func (s *JobsService) RunsDelete(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"JobsService.RunsDelete",
 		http.MethodPost,
-		s.client.url+"2.0/jobs/runs/delete",
+		"2.0/jobs/runs/delete",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -444,14 +444,11 @@ func Test_JobsService_RunsCancel(t *testing.T) {
 func Test_JobsService_RunsGetOutput(t *testing.T) {
 	t.Parallel()
 	run := &Run{}
-	type nbOutput struct {
-		Result string
-	}
 	res, err := json.Marshal(struct {
-		NotebookOutput *nbOutput
-		Error          *string
-		Metadata       *Run
-	}{&nbOutput{"output"}, nil, run})
+		NotebookOutput *NotebookOutput `json:"notebook_output"`
+		Error          *string         `json:"error"`
+		Metadata       *Run            `json:"metadata"`
+	}{&NotebookOutput{"output"}, nil, run})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -524,3 +521,64 @@ func Test_JobsService_RunsDelete(t *testing.T) {
 		t.Fatalf("Expected error to not be nil")
 	}
 }
+
+func Test_LifeCycleState_IsTerminal(t *testing.T) {
+	t.Parallel()
+	terminal := map[LifeCycleState]bool{
+		LifeCycleStatePending:         false,
+		LifeCycleStateRunning:         false,
+		LifeCycleStateTerminating:     false,
+		LifeCycleStateTerminated:      true,
+		LifeCycleStateSkipped:         true,
+		LifeCycleStateInternalError:   true,
+		LifeCycleStateBlocked:         false,
+		LifeCycleStateWaitingForRetry: false,
+		LifeCycleStateQueued:          false,
+	}
+	for state, expected := range terminal {
+		if state.IsTerminal() != expected {
+			t.Fatalf("Expected %s terminal to be %t", state, expected)
+		}
+	}
+}
+
+func Test_ResultState_IsSuccess(t *testing.T) {
+	t.Parallel()
+	if !ResultStateSuccess.IsSuccess() {
+		t.Fatalf("Expected %s to be a success", ResultStateSuccess)
+	}
+	for _, state := range []ResultState{
+		ResultStateFailed,
+		ResultStateTimedOut,
+		ResultStateCanceled,
+		ResultStateSuccessWithFailures,
+		"",
+	} {
+		if state.IsSuccess() {
+			t.Fatalf("Expected %q not to be a success", state)
+		}
+	}
+}
+
+func Test_Run_Decode(t *testing.T) {
+	t.Parallel()
+	var run Run
+	err := json.Unmarshal([]byte(`{
+		"run_id": 3,
+		"state": {
+			"life_cycle_state": "TERMINATED",
+			"result_state": "SUCCESS",
+			"state_message": ""
+		},
+		"trigger": "PERIODIC"
+	}`), &run)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !run.State.LifeCycleState.IsTerminal() || !run.State.ResultState.IsSuccess() {
+		t.Fatalf("Unexpected state: %+v", run.State)
+	}
+	if run.Trigger != TriggerPeriodic {
+		t.Fatalf("Unexpected trigger: %s", run.Trigger)
+	}
+}
@@ -29,12 +29,56 @@ type Library struct {
 	Cran  *RCranLibrary      `json:"cran"`
 }
 
+// LibraryStatus is the install status of a library on a cluster.
+type LibraryStatus string
+
+const (
+	// LibraryStatusPending is a library that has not been installed yet.
+	LibraryStatusPending LibraryStatus = "PENDING"
+	// LibraryStatusResolving is a library whose metadata is being
+	// retrieved from its repository.
+	LibraryStatusResolving LibraryStatus = "RESOLVING"
+	// LibraryStatusInstalling is a library that is being installed.
+	LibraryStatusInstalling LibraryStatus = "INSTALLING"
+	// LibraryStatusInstalled is a library that was installed.
+	LibraryStatusInstalled LibraryStatus = "INSTALLED"
+	// LibraryStatusSkipped is a library that was not installed because it
+	// is not compatible with the Databricks Runtime of the cluster.
+	LibraryStatusSkipped LibraryStatus = "SKIPPED"
+	// LibraryStatusFailed is a library that failed to install.
+	LibraryStatusFailed LibraryStatus = "FAILED"
+	// LibraryStatusUninstallOnRestart is a library that is uninstalled when
+	// the cluster restarts.
+	LibraryStatusUninstallOnRestart LibraryStatus = "UNINSTALL_ON_RESTART"
+	// LibraryStatusRestored is a library that was installed before the
+	// cluster restarted and is being reinstalled.
+	LibraryStatusRestored LibraryStatus = "RESTORED"
+)
+
+// IsFailed returns whether the library failed to install.
+func (s LibraryStatus) IsFailed() bool {
+	return s == LibraryStatusFailed
+}
+
+// IsTerminal returns whether the library is done installing or
+// uninstalling, successfully or not.
+func (s LibraryStatus) IsTerminal() bool {
+	switch s {
+	case LibraryStatusInstalled,
+		LibraryStatusSkipped,
+		LibraryStatusFailed,
+		LibraryStatusUninstallOnRestart:
+		return true
+	}
+	return false
+}
+
 // LibraryFullStatus is the status of the library on a specific cluster.
 type LibraryFullStatus struct {
-	Library                 Library  `json:"library"`
-	Status                  string   `json:"status"` // TODO(daniel)
-	Messages                []string `json:"messages"`
-	IsLibraryForAllClusters bool     `json:"is_library_for_all_clusters"`
+	Library                 Library       `json:"library"`
+	Status                  LibraryStatus `json:"status"`
+	Messages                []string      `json:"messages"`
+	IsLibraryForAllClusters bool          `json:"is_library_for_all_clusters"`
 }
 
 // ClusterLibraryStatuses contains the statuses for a Cluster library.
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 )
 
@@ -22,28 +21,25 @@ type LibrariesService struct {
 func (s *LibrariesService) AllClusterStatuses(
 	ctx context.Context,
 ) ([]ClusterLibraryStatuses, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"LibrariesService.AllClusterStatuses",
 		http.MethodGet,
-		s.client.url+"2.0/libraries/all-cluster-statuses",
+		"2.0/libraries/all-cluster-statuses",
 		nil,
 	)
 	if err != nil {
 		return []ClusterLibraryStatuses{}, err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return []ClusterLibraryStatuses{}, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []ClusterLibraryStatuses{}, fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
 	statusRes := struct {
-		Statuses []ClusterLibraryStatuses
+		Statuses []ClusterLibraryStatuses `json:"statuses"`
 	}{[]ClusterLibraryStatuses{}}
 	err = decoder.Decode(&statusRes)
 
@@ -60,31 +56,28 @@ func (s *LibrariesService) ClusterStatus(
 	ctx context.Context,
 	clusterID string,
 ) ([]LibraryFullStatus, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"LibrariesService.ClusterStatus",
 		http.MethodGet,
-		s.client.url+"2.0/libraries/cluster-status",
+		"2.0/libraries/cluster-status",
 		nil,
 	)
 	if err != nil {
 		return []LibraryFullStatus{}, err
 	}
-	req = req.WithContext(ctx)
 	q := req.URL.Query()
 	q.Add("cluster_id", clusterID)
 	req.URL.RawQuery = q.Encode()
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return []LibraryFullStatus{}, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []LibraryFullStatus{}, fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
 	statusRes := struct {
-		Statuses []LibraryFullStatus
+		Statuses []LibraryFullStatus `json:"library_statuses"`
 	}{[]LibraryFullStatus{}}
 	err = decoder.Decode(&statusRes)
 
@@ -119,8 +112,8 @@ func (s *LibrariesService) Install(
 	libraries []Library,
 ) error {
 	raw, err := json.Marshal(struct {
-		ClusterID string
-		Libraries []Library
+		ClusterID string    `json:"cluster_id"`
+		Libraries []Library `json:"libraries"`
 	}{
 		clusterID,
 		libraries,
@@ -128,24 +121,21 @@ func (s *LibrariesService) Install(
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"LibrariesService.Install",
 		http.MethodPost,
-		s.client.url+"2.0/libraries/install",
+		"2.0/libraries/install",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	req.URL.Query().Add("cluster_id", clusterID)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -159,8 +149,8 @@ func (s *LibrariesService) Uninstall(
 	libraries []Library,
 ) error {
 	raw, err := json.Marshal(struct {
-		ClusterID string
-		Libraries []Library
+		ClusterID string    `json:"cluster_id"`
+		Libraries []Library `json:"libraries"`
 	}{
 		clusterID,
 		libraries,
@@ -168,24 +158,21 @@ func (s *LibrariesService) Uninstall(
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"LibrariesService.Uninstall",
 		http.MethodPost,
-		s.client.url+"2.0/libraries/uninstall",
+		"2.0/libraries/uninstall",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	req.URL.Query().Add("cluster_id", clusterID)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -118,7 +118,7 @@ func Test_LibrariesService_AllClusterStatuses(t *testing.T) {
 func Test_LibrariesService_ClusterStatus(t *testing.T) {
 	t.Parallel()
 	res, err := json.Marshal(struct {
-		Statuses []LibraryFullStatus
+		Statuses []LibraryFullStatus `json:"library_statuses"`
 	}{
 		[]LibraryFullStatus{
 			LibraryFullStatus{},
@@ -211,3 +211,24 @@ func Test_LibrariesService_Uninstall(t *testing.T) {
 		t.Fatalf("Expected error to not be nil")
 	}
 }
+
+func Test_LibraryStatus(t *testing.T) {
+	t.Parallel()
+	if !LibraryStatusFailed.IsFailed() || LibraryStatusInstalled.IsFailed() {
+		t.Fatalf("Unexpected IsFailed")
+	}
+	for status, expected := range map[LibraryStatus]bool{
+		LibraryStatusPending:            false,
+		LibraryStatusResolving:          false,
+		LibraryStatusInstalling:         false,
+		LibraryStatusInstalled:          true,
+		LibraryStatusSkipped:            true,
+		LibraryStatusFailed:             true,
+		LibraryStatusUninstallOnRestart: true,
+		LibraryStatusRestored:           false,
+	} {
+		if status.IsTerminal() != expected {
+			t.Fatalf("Expected %s terminal to be %t", status, expected)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package databricks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// DefaultDebugLogMaxBody is the default number of bytes of a body logged by
+// a DebugLogger.
+const DefaultDebugLogMaxBody = 2048
+
+// redactedFields are JSON fields that carry credentials or secret values,
+// they are redacted in the bodies of every endpoint. Fields are matched with
+// fieldKey, so both string_value and StringValue are redacted.
+var redactedFields = map[string]bool{
+	"stringvalue": true,
+	"bytesvalue":  true,
+	"tokenvalue":  true,
+}
+
+// redactedHeaders are headers that carry credentials.
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// DebugLogger is a Middleware that logs every API call at the debug level,
+// with the method, path, status, latency and truncated request and response
+// bodies. Credentials are redacted: Authorization headers, secret values
+// (string_value and bytes_value), tokens (token_value) and the base64 block
+// data of DBFS.
+type DebugLogger struct {
+	// Logger is the logger to log to. It defaults to slog.Default().
+	Logger *slog.Logger
+	// MaxBody is the maximum number of bytes of a body that is logged. It
+	// defaults to DefaultDebugLogMaxBody, a negative value disables the
+	// logging of bodies.
+	MaxBody int
+}
+
+// Before implements the Middleware interface.
+func (l *DebugLogger) Before(ctx context.Context, call *Call) context.Context {
+	return ctx
+}
+
+// After implements the Middleware interface.
+func (l *DebugLogger) After(ctx context.Context, call *Call) {
+	logger := l.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", call.Operation),
+		slog.String("method", call.Request.Method),
+		slog.String("path", call.Request.URL.Path),
+		slog.Duration("latency", call.Latency),
+		slog.Int("attempts", call.Attempts),
+	}
+	if call.Request.URL.RawQuery != "" {
+		attrs = append(attrs, slog.String("query", call.Request.URL.RawQuery))
+	}
+	if headers := redactHeaders(call.Request.Header); len(headers) > 0 {
+		attrs = append(attrs, slog.Any("headers", headers))
+	}
+	if l.maxBody() >= 0 && call.Request.GetBody != nil {
+		if body, err := call.Request.GetBody(); err == nil {
+			raw, err := ioutil.ReadAll(body)
+			body.Close()
+			if err == nil && len(raw) > 0 {
+				attrs = append(attrs, slog.String(
+					"request_body", l.formatBody(call.Endpoint, raw)))
+			}
+		}
+	}
+
+	var apiErr *APIError
+	switch {
+	case call.Response != nil:
+		attrs = append(attrs, slog.Int("status", call.Response.StatusCode))
+		if l.maxBody() >= 0 {
+			raw, err := ioutil.ReadAll(call.Response.Body)
+			call.Response.Body.Close()
+			// Replace the body that was read so the service can decode it.
+			call.Response.Body = ioutil.NopCloser(bytes.NewReader(raw))
+			if err == nil && len(raw) > 0 {
+				attrs = append(attrs, slog.String(
+					"response_body", l.formatBody(call.Endpoint, raw)))
+			}
+		}
+	case errors.As(call.Err, &apiErr):
+		attrs = append(attrs,
+			slog.Int("status", apiErr.StatusCode),
+			slog.String("error_code", string(apiErr.ErrorCode)),
+			slog.String("error", apiErr.Message),
+		)
+	case call.Err != nil:
+		attrs = append(attrs, slog.String("error", call.Err.Error()))
+	}
+
+	logger.LogAttrs(ctx, slog.LevelDebug, "Databricks API call", attrs...)
+}
+
+func (l *DebugLogger) maxBody() int {
+	if l.MaxBody == 0 {
+		return DefaultDebugLogMaxBody
+	}
+	return l.MaxBody
+}
+
+// formatBody returns a body with its secrets redacted, truncated to
+// MaxBody bytes.
+func (l *DebugLogger) formatBody(endpoint string, raw []byte) string {
+	var body string
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err == nil {
+		redacted, err := json.Marshal(redactJSON(endpoint, "", value))
+		if err != nil {
+			return fmt.Sprintf("<%d bytes>", len(raw))
+		}
+		body = string(redacted)
+	} else {
+		body = string(raw)
+	}
+	if max := l.maxBody(); len(body) > max {
+		return fmt.Sprintf("%s... (%d bytes truncated)", body[:max], len(body)-max)
+	}
+	return body
+}
+
+// redactJSON returns a decoded JSON value with the values of secret fields
+// replaced.
+func redactJSON(endpoint, key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for k, field := range v {
+			redacted[k] = redactJSON(endpoint, k, field)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = redactJSON(endpoint, key, item)
+		}
+		return redacted
+	case string:
+		field := fieldKey(key)
+		if redactedFields[field] {
+			return redact(v)
+		}
+		// DBFS file contents are sent as base64 data, e.g. in add-block,
+		// put and read.
+		if apiFamily(endpoint) == FamilyDBFS &&
+			(field == "data" || field == "contents") {
+			return fmt.Sprintf("*** (%d base64 bytes)", len(v))
+		}
+	}
+	return value
+}
+
+// fieldKey normalizes a JSON field name for matching.
+func fieldKey(key string) string {
+	return strings.ToLower(strings.Replace(key, "_", "", -1))
+}
+
+// redactHeaders returns the headers of a request as a map, with the values
+// of headers that carry credentials redacted.
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		if redactedHeaders[http.CanonicalHeaderKey(name)] {
+			headers[name] = redact(strings.Join(values, ", "))
+		} else {
+			headers[name] = strings.Join(values, ", ")
+		}
+	}
+	return headers
+}
@@ -0,0 +1,114 @@
+package databricks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func debugLoggerHelper(t *testing.T, maxBody int) (*Client, *bytes.Buffer) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/2.0/token/create":
+				fmt.Fprint(w, `{"token_value":"dapi-secret-token","token_info":{"token_id":"abc"}}`)
+			case "/api/2.0/dbfs/read":
+				fmt.Fprint(w, `{"bytes_read":11,"data":"c2VjcmV0IGRhdGE="}`)
+			case "/api/2.0/clusters/get":
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error_code":"INVALID_PARAMETER_VALUE","message":"Cluster abc does not exist"}`)
+			default:
+				fmt.Fprint(w, `{}`)
+			}
+		},
+	))
+	t.Cleanup(server.Close)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(
+		&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client, err := NewClient(
+		"",
+		ClientHost(server.URL),
+		ClientMiddleware(
+			MiddlewareFuncs{
+				BeforeFunc: func(ctx context.Context, call *Call) context.Context {
+					call.Request.Header.Set("Authorization", "Bearer dapi-header")
+					return ctx
+				},
+			},
+			&DebugLogger{Logger: logger, MaxBody: maxBody},
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, &buf
+}
+
+func Test_DebugLogger(t *testing.T) {
+	t.Parallel()
+	client, buf := debugLoggerHelper(t, 0)
+	ctx := context.Background()
+
+	if err := client.Secrets().PutSecret(ctx, "scope", "key", "hunter2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Token().Create(ctx, 60); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.DBFS().AddBlock(ctx, 1, []byte("secret data")); err != nil {
+		t.Fatal(err)
+	}
+	_, data, err := client.DBFS().Read(ctx, "/file", 0, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "secret data" {
+		t.Fatalf("Expected the response body to be kept, got %q", data)
+	}
+	if _, err := client.Cluster().Get(ctx, "abc"); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	logs := buf.String()
+	for _, secret := range []string{
+		"hunter2",
+		"dapi-secret-token",
+		"dapi-header",
+		"c2VjcmV0IGRhdGE=",
+	} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("Expected %q to be redacted:\n%s", secret, logs)
+		}
+	}
+	for _, expected := range []string{
+		"operation=secrets.put",
+		"operation=token.create",
+		"operation=dbfs.add-block",
+		"status=200",
+		"latency=",
+		"error_code=INVALID_PARAMETER_VALUE",
+		`token_id\":\"abc\"`,
+	} {
+		if !strings.Contains(logs, expected) {
+			t.Errorf("Expected %q to be logged:\n%s", expected, logs)
+		}
+	}
+}
+
+func Test_DebugLogger_Truncate(t *testing.T) {
+	t.Parallel()
+	client, buf := debugLoggerHelper(t, 10)
+	if _, _, err := client.Token().Create(context.Background(), 60); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "bytes truncated") {
+		t.Fatalf("Expected the body to be truncated:\n%s", buf.String())
+	}
+}
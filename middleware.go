@@ -0,0 +1,159 @@
+package databricks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Call is an API call made by a service method. It is passed to the hooks
+// of every Middleware of the Client.
+type Call struct {
+	// Method is the service method that made the call, e.g.
+	// "JobsService.RunsGet".
+	Method string
+	// Endpoint is the API endpoint of the call, e.g. "2.0/jobs/runs/get".
+	// Endpoints with an object ID in their path are reported without it,
+	// e.g. "2.0/permissions/clusters".
+	Endpoint string
+	// Operation is the endpoint as a dotted name without the API version,
+	// e.g. "jobs.runs.get".
+	Operation string
+	// Request is the request of the call. Before hooks may modify it, e.g.
+	// to add headers.
+	Request *http.Request
+
+	// The following fields are set before the After hooks are called.
+
+	// Response is the response of the last attempt. It is nil if the call
+	// failed, in which case Err is set.
+	Response *http.Response
+	// Err is the error of the call. An API error response is reported as an
+	// *APIError.
+	Err error
+	// Latency is the duration of the call, including retries and rate
+	// limit waits.
+	Latency time.Duration
+	// Attempts is the number of attempts made.
+	Attempts int
+	// Throttles is the number of attempts rejected with a 429 response.
+	Throttles int
+}
+
+// Middleware adds behavior such as logging, tracing or extra headers to the
+// API calls of a Client.
+type Middleware interface {
+	// Before is called before a call is sent. The returned context is used
+	// for the call and passed to After.
+	Before(ctx context.Context, call *Call) context.Context
+	// After is called once a call has completed, after all of its retries.
+	After(ctx context.Context, call *Call)
+}
+
+// MiddlewareFuncs is a Middleware of optional functions.
+type MiddlewareFuncs struct {
+	BeforeFunc func(ctx context.Context, call *Call) context.Context
+	AfterFunc  func(ctx context.Context, call *Call)
+}
+
+// Before implements the Middleware interface.
+func (m MiddlewareFuncs) Before(ctx context.Context, call *Call) context.Context {
+	if m.BeforeFunc == nil {
+		return ctx
+	}
+	return m.BeforeFunc(ctx, call)
+}
+
+// After implements the Middleware interface.
+func (m MiddlewareFuncs) After(ctx context.Context, call *Call) {
+	if m.AfterFunc != nil {
+		m.AfterFunc(ctx, call)
+	}
+}
+
+// ClientMiddleware adds Middleware to the Client. Before hooks are called in
+// the order the Middleware were added and After hooks in reverse order, so
+// the first Middleware wraps all the others.
+func ClientMiddleware(middleware ...Middleware) ClientOpt {
+	return func(c *Client) error {
+		c.middleware = append(c.middleware, middleware...)
+		return nil
+	}
+}
+
+type serviceMethodKey struct{}
+
+type endpointKey struct{}
+
+// newRequest returns a request to an API endpoint, such as
+// 2.0/clusters/get, made by a service method. Requests must be sent with
+// do.
+func (c *Client) newRequest(
+	ctx context.Context,
+	serviceMethod string,
+	method string,
+	endpoint string,
+	body io.Reader,
+) (*http.Request, error) {
+	req, err := http.NewRequest(method, c.url+endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, serviceMethodKey{}, serviceMethod)
+	return req.WithContext(ctx), nil
+}
+
+// withEndpoint sets the endpoint that a request is reported with to the
+// Middleware, for endpoints with an object ID in their path.
+func withEndpoint(req *http.Request, endpoint string) *http.Request {
+	ctx := context.WithValue(req.Context(), endpointKey{}, endpoint)
+	return req.WithContext(ctx)
+}
+
+// do sends a request through the Client's Middleware, rate limits and
+// RetryPolicy. An API error response is returned as an *APIError.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	endpoint := strings.TrimPrefix(req.URL.Path, "/api/")
+	if reported, ok := req.Context().Value(endpointKey{}).(string); ok {
+		endpoint = reported
+	}
+	method, _ := req.Context().Value(serviceMethodKey{}).(string)
+	call := &Call{
+		Method:    method,
+		Endpoint:  endpoint,
+		Operation: endpointOperation(endpoint),
+		Request:   req,
+	}
+
+	ctx := req.Context()
+	for _, m := range c.middleware {
+		ctx = m.Before(ctx, call)
+	}
+	call.Request = call.Request.WithContext(ctx)
+
+	start := time.Now()
+	call.Response, call.Err = c.send(call)
+	if call.Err == nil {
+		if err := checkResponse(call.Response); err != nil {
+			call.Response, call.Err = nil, err
+		}
+	}
+	call.Latency = time.Since(start)
+
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		c.middleware[i].After(ctx, call)
+	}
+	return call.Response, call.Err
+}
+
+// endpointOperation returns the operation name of an endpoint, e.g.
+// jobs.runs.get for 2.0/jobs/runs/get.
+func endpointOperation(endpoint string) string {
+	parts := strings.Split(endpoint, "/")
+	if len(parts) > 1 {
+		parts = parts[1:]
+	}
+	return strings.Join(parts, ".")
+}
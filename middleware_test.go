@@ -0,0 +1,199 @@
+package databricks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type middlewareKey struct{}
+
+func Test_ClientMiddleware(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("User-Agent") != "my-app/1.0" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if r.URL.Path == "/api/2.0/jobs/runs/get" {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error_code":"RESOURCE_DOES_NOT_EXIST","message":"Run 1 does not exist."}`)
+				return
+			}
+			fmt.Fprint(w, `{}`)
+		},
+	))
+	t.Cleanup(server.Close)
+
+	var order []string
+	var calls []*Call
+	client, err := NewClient(
+		"",
+		ClientHost(server.URL),
+		ClientMiddleware(
+			MiddlewareFuncs{
+				BeforeFunc: func(ctx context.Context, call *Call) context.Context {
+					order = append(order, "before 1")
+					call.Request.Header.Set("User-Agent", "my-app/1.0")
+					return context.WithValue(ctx, middlewareKey{}, call.Operation)
+				},
+				AfterFunc: func(ctx context.Context, call *Call) {
+					order = append(order, "after 1")
+					calls = append(calls, call)
+				},
+			},
+			MiddlewareFuncs{
+				BeforeFunc: func(ctx context.Context, call *Call) context.Context {
+					order = append(order, "before 2")
+					return ctx
+				},
+				AfterFunc: func(ctx context.Context, call *Call) {
+					order = append(order, "after 2")
+					if ctx.Value(middlewareKey{}) != call.Operation {
+						t.Error("Expected the context of Before to be passed to After")
+					}
+				},
+			},
+		),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if err := client.DBFS().AddBlock(ctx, 1, []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Jobs().RunsGet(ctx, 1)
+	if !errors.Is(err, ErrResourceDoesNotExist) {
+		t.Fatalf("Expected ErrResourceDoesNotExist, got: %v", err)
+	}
+
+	expected := []string{"before 1", "before 2", "after 2", "after 1"}
+	if fmt.Sprint(order[:4]) != fmt.Sprint(expected) {
+		t.Fatalf("Unexpected hook order: %v", order)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 calls, got %d", len(calls))
+	}
+	if calls[0].Method != "DBFSService.AddBlock" ||
+		calls[0].Endpoint != "2.0/dbfs/add-block" ||
+		calls[0].Operation != "dbfs.add-block" ||
+		calls[0].Attempts != 1 ||
+		calls[0].Response == nil ||
+		calls[0].Latency <= 0 {
+		t.Fatalf("Unexpected call: %+v", calls[0])
+	}
+	var apiErr *APIError
+	if calls[1].Method != "JobsService.RunsGet" ||
+		calls[1].Operation != "jobs.runs.get" ||
+		!errors.As(calls[1].Err, &apiErr) ||
+		apiErr.ErrorCode != CodeResourceDoesNotExist {
+		t.Fatalf("Unexpected call: %+v", calls[1])
+	}
+}
+
+func Test_ClientMiddleware_Context(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{}`)
+		},
+	))
+	t.Cleanup(server.Close)
+	client, err := NewClient("", ClientHost(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := client.DBFS().AddBlock(ctx, 1, []byte("data")); err == nil {
+		t.Fatal("Expected AddBlock to use its context")
+	}
+}
+
+func Test_ClientMiddleware_Retries(t *testing.T) {
+	t.Parallel()
+	tripper := &sequenceTripper{codes: []int{429, 503, 200}}
+	var call *Call
+	client, err := NewClient(
+		"test-account",
+		ClientHTTPClient(&http.Client{Transport: tripper}),
+		ClientRetryPolicy(testRetryPolicy),
+		ClientMiddleware(MiddlewareFuncs{
+			AfterFunc: func(ctx context.Context, c *Call) {
+				call = c
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.Cluster().Start(context.Background(), "abc"); err != nil {
+		t.Fatal(err)
+	}
+	if call.Attempts != 3 || call.Throttles != 1 {
+		t.Fatalf(
+			"Expected 3 attempts and 1 throttle, got %d and %d",
+			call.Attempts, call.Throttles,
+		)
+	}
+}
+
+func Test_ClientMiddleware_Methods(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{}`)
+		},
+	))
+	t.Cleanup(server.Close)
+	var methods []string
+	client, err := NewClient(
+		"",
+		ClientHost(server.URL),
+		ClientMiddleware(MiddlewareFuncs{
+			AfterFunc: func(ctx context.Context, call *Call) {
+				methods = append(methods, call.Method)
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Methods that share a helper are reported by their own name.
+	ctx := context.Background()
+	groups := client.Groups()
+	if _, err := groups.UserParents(ctx, "foo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := groups.GroupParents(ctx, "foo"); err != nil {
+		t.Fatal(err)
+	}
+	if err := groups.RemoveUser(ctx, "foo"); err != nil {
+		t.Fatal(err)
+	}
+	if err := groups.RemoveGroup(ctx, "foo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Jobs().List(ctx); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"GroupsService.UserParents",
+		"GroupsService.GroupParents",
+		"GroupsService.RemoveUser",
+		"GroupsService.RemoveGroup",
+		"JobsService.List",
+	}
+	if fmt.Sprint(methods) != fmt.Sprint(expected) {
+		t.Fatalf("Expected methods %v, got %v", expected, methods)
+	}
+}
@@ -0,0 +1,190 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryLeeway is how long before its expiry a token is refreshed, so
+// that a token is never sent just as it expires.
+const tokenExpiryLeeway = time.Minute
+
+// OAuthToken is an OAuth access token issued by the workspace.
+type OAuthToken struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// valid returns if the token can be used at the given time.
+func (t *OAuthToken) valid(now time.Time) bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	if t.Expiry.IsZero() {
+		return true
+	}
+	return now.Add(tokenExpiryLeeway).Before(t.Expiry)
+}
+
+// TokenSource returns OAuth tokens. Implementations must be safe for
+// concurrent use.
+type TokenSource interface {
+	Token(ctx context.Context) (*OAuthToken, error)
+}
+
+// OAuthM2MCredentials is a TokenSource for OAuth machine-to-machine
+// authentication of a service principal with the client credentials grant.
+// Tokens are cached and refreshed shortly before they expire.
+type OAuthM2MCredentials struct {
+	host         string
+	clientID     string
+	clientSecret string
+	scopes       []string
+	httpClient   *http.Client
+	now          func() time.Time
+
+	mu    sync.Mutex
+	token *OAuthToken
+}
+
+// NewOAuthM2MCredentials returns OAuthM2MCredentials for a workspace host and
+// a service principal's OAuth client id and secret. Tokens are requested
+// with the all-apis scope unless other scopes are given.
+func NewOAuthM2MCredentials(
+	host, clientID, clientSecret string,
+	scopes ...string,
+) (*OAuthM2MCredentials, error) {
+	normalized, err := normalizeHost(host)
+	if err != nil {
+		return nil, err
+	}
+	if clientID == "" || clientSecret == "" {
+		return nil, fmt.Errorf("OAuth M2M requires a client id and secret")
+	}
+	if len(scopes) == 0 {
+		scopes = []string{"all-apis"}
+	}
+	return &OAuthM2MCredentials{
+		host:         normalized,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		scopes:       scopes,
+		httpClient:   http.DefaultClient,
+		now:          time.Now,
+	}, nil
+}
+
+// Token implements the TokenSource interface.
+func (c *OAuthM2MCredentials) Token(ctx context.Context) (*OAuthToken, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token.valid(c.now()) {
+		return c.token, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("scope", strings.Join(c.scopes, " "))
+	token, err := requestToken(
+		ctx, c.httpClient, c.host, form, c.clientID, c.clientSecret, c.now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.token = token
+
+	return token, nil
+}
+
+func (c *OAuthM2MCredentials) setTransport(base http.RoundTripper) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.httpClient = &http.Client{Transport: base}
+}
+
+// requestToken requests a token from the workspace OIDC token endpoint.
+func requestToken(
+	ctx context.Context,
+	client *http.Client,
+	host string,
+	form url.Values,
+	clientID, clientSecret string,
+	now func() time.Time,
+) (*OAuthToken, error) {
+	req, err := http.NewRequest(
+		http.MethodPost,
+		host+"/oidc/v1/token",
+		strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if clientSecret != "" {
+		req.SetBasicAuth(
+			url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+	}
+	issued := now()
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenRes := struct {
+		AccessToken      string `json:"access_token"`
+		TokenType        string `json:"token_type"`
+		RefreshToken     string `json:"refresh_token"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+	if err := json.Unmarshal(body, &tokenRes); err != nil &&
+		res.StatusCode >= 200 && res.StatusCode <= 299 {
+		return nil, fmt.Errorf("Failed to decode OAuth token response: %s", err)
+	}
+	if res.StatusCode >= 300 || res.StatusCode <= 199 ||
+		tokenRes.Error != "" {
+		return nil, fmt.Errorf(
+			"Failed to get an OAuth token (%d): %s %s",
+			res.StatusCode, tokenRes.Error, tokenRes.ErrorDescription,
+		)
+	}
+	if tokenRes.AccessToken == "" {
+		return nil, fmt.Errorf("OAuth token response has no access token")
+	}
+
+	token := &OAuthToken{
+		AccessToken:  tokenRes.AccessToken,
+		TokenType:    tokenRes.TokenType,
+		RefreshToken: tokenRes.RefreshToken,
+	}
+	if token.TokenType == "" {
+		token.TokenType = "Bearer"
+	}
+	if tokenRes.ExpiresIn > 0 {
+		token.Expiry = issued.Add(time.Duration(tokenRes.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// NewTokenSourceHTTPClient returns an http.Client that authenticates every
+// request with a token from the TokenSource.
+func NewTokenSourceHTTPClient(source TokenSource) *http.Client {
+	return newCredentialsHTTPClient(TokenSourceCredentials("oauth", source))
+}
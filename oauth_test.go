@@ -0,0 +1,131 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeOIDCServer is a workspace that issues client credentials tokens and
+// checks them on the clusters/list endpoint.
+func fakeOIDCServer(t *testing.T, expiresIn int) (*httptest.Server, *int32) {
+	var issued int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oidc/v1/token", func(w http.ResponseWriter, r *http.Request) {
+		clientID, secret, ok := r.BasicAuth()
+		if !ok || clientID != "sp-id" || secret != "sp-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"invalid_client","error_description":"Client authentication failed"}`)
+			return
+		}
+		if r.FormValue("grant_type") != "client_credentials" ||
+			r.FormValue("scope") != "all-apis" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"invalid_request"}`)
+			return
+		}
+		n := atomic.AddInt32(&issued, 1)
+		fmt.Fprintf(
+			w,
+			`{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`,
+			n, expiresIn,
+		)
+	})
+	mux.HandleFunc("/api/2.0/clusters/list", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"clusters":[]}`)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &issued
+}
+
+func Test_OAuthM2MCredentials(t *testing.T) {
+	t.Parallel()
+	server, issued := fakeOIDCServer(t, 3600)
+
+	creds, err := NewOAuthM2MCredentials(server.URL, "sp-id", "sp-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	creds.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := creds.Token(ctx)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if token.AccessToken != "token-1" {
+				t.Errorf("Unexpected token: %q", token.AccessToken)
+			}
+		}()
+	}
+	wg.Wait()
+	if atomic.LoadInt32(issued) != 1 {
+		t.Fatalf("Expected the token to be cached, issued %d", *issued)
+	}
+
+	// Tokens are refreshed before they expire.
+	now = now.Add(3600*time.Second - tokenExpiryLeeway/2)
+	token, err := creds.Token(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "token-2" {
+		t.Fatalf("Expected a refreshed token, got %q", token.AccessToken)
+	}
+
+	creds, err = NewOAuthM2MCredentials(server.URL, "sp-id", "wrong")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := creds.Token(ctx); err == nil {
+		t.Fatalf("Expected an error for invalid credentials")
+	}
+
+	if _, err := NewOAuthM2MCredentials(server.URL, "", ""); err == nil {
+		t.Fatalf("Expected an error without a client id")
+	}
+}
+
+func Test_OAuthM2M_Client(t *testing.T) {
+	t.Parallel()
+	server, _ := fakeOIDCServer(t, 3600)
+
+	cfg, err := LoadConfig(
+		envHelper(map[string]string{
+			EnvHost:         server.URL,
+			EnvClientID:     "sp-id",
+			EnvClientSecret: "sp-secret",
+			EnvConfigFile:   "/nonexistent/.databrickscfg",
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.AuthType != AuthTypeOAuthM2M {
+		t.Fatalf("Expected oauth-m2m auth, got %q", cfg.AuthType)
+	}
+	client, err := NewClientFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Cluster().List(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,398 @@
+package databricks
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// DefaultU2MClientID is the public OAuth client used by the Databricks CLI,
+// which is registered with a http://localhost:8020 redirect URL.
+const DefaultU2MClientID = "databricks-cli"
+
+// DefaultTokenCacheFile is the default location of the OAuth token cache,
+// shared with the Databricks CLI.
+const DefaultTokenCacheFile = "~/.databricks/token-cache.json"
+
+// OAuthU2MCredentials is a TokenSource for OAuth user-to-machine
+// authentication. The first time a token is needed the user logs in with
+// their browser using the authorization code flow with PKCE. The resulting
+// refresh token is persisted in a TokenCache, so following runs refresh
+// their access token without another login.
+type OAuthU2MCredentials struct {
+	host        string
+	clientID    string
+	scopes      []string
+	listenAddr  string
+	cache       TokenCache
+	openBrowser func(string) error
+	httpClient  *http.Client
+	now         func() time.Time
+
+	mu    sync.Mutex
+	token *OAuthToken
+}
+
+// OAuthU2MOpt is used for configuring OAuthU2MCredentials.
+type OAuthU2MOpt func(*OAuthU2MCredentials) error
+
+// OAuthU2MClientID configures the OAuth client id. It defaults to
+// DefaultU2MClientID.
+func OAuthU2MClientID(clientID string) OAuthU2MOpt {
+	return func(c *OAuthU2MCredentials) error {
+		c.clientID = clientID
+		return nil
+	}
+}
+
+// OAuthU2MListenAddr configures the local address the callback listener
+// binds to. It defaults to localhost:8020.
+func OAuthU2MListenAddr(addr string) OAuthU2MOpt {
+	return func(c *OAuthU2MCredentials) error {
+		c.listenAddr = addr
+		return nil
+	}
+}
+
+// OAuthU2MTokenCache configures the TokenCache. It defaults to a
+// FileTokenCache at DefaultTokenCacheFile.
+func OAuthU2MTokenCache(cache TokenCache) OAuthU2MOpt {
+	return func(c *OAuthU2MCredentials) error {
+		c.cache = cache
+		return nil
+	}
+}
+
+// OAuthU2MBrowser configures the function used to open the authorization
+// URL. By default the system browser is opened.
+func OAuthU2MBrowser(openBrowser func(string) error) OAuthU2MOpt {
+	return func(c *OAuthU2MCredentials) error {
+		c.openBrowser = openBrowser
+		return nil
+	}
+}
+
+// NewOAuthU2MCredentials returns OAuthU2MCredentials for a workspace host.
+func NewOAuthU2MCredentials(
+	host string,
+	opts ...OAuthU2MOpt,
+) (*OAuthU2MCredentials, error) {
+	normalized, err := normalizeHost(host)
+	if err != nil {
+		return nil, err
+	}
+	c := &OAuthU2MCredentials{
+		host:        normalized,
+		clientID:    DefaultU2MClientID,
+		scopes:      []string{"all-apis", "offline_access"},
+		listenAddr:  "localhost:8020",
+		openBrowser: openSystemBrowser,
+		httpClient:  http.DefaultClient,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	if c.cache == nil {
+		path, err := homedir.Expand(DefaultTokenCacheFile)
+		if err != nil {
+			return nil, err
+		}
+		c.cache = &FileTokenCache{Path: path}
+	}
+	return c, nil
+}
+
+// Token implements the TokenSource interface. It returns the cached token,
+// refreshes it, or starts a browser login as a last resort.
+func (c *OAuthU2MCredentials) Token(ctx context.Context) (*OAuthToken, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token.valid(c.now()) {
+		return c.token, nil
+	}
+	if c.token == nil {
+		cached, err := c.cache.Load(c.cacheKey())
+		if err != nil {
+			return nil, err
+		}
+		c.token = cached
+		if c.token.valid(c.now()) {
+			return c.token, nil
+		}
+	}
+	if c.token != nil && c.token.RefreshToken != "" {
+		if token, err := c.refresh(ctx, c.token.RefreshToken); err == nil {
+			return token, nil
+		}
+		// The refresh token expired or was revoked, log in again.
+	}
+	return c.login(ctx)
+}
+
+// Login starts a browser login even if a valid token is cached.
+func (c *OAuthU2MCredentials) Login(ctx context.Context) (*OAuthToken, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.login(ctx)
+}
+
+func (c *OAuthU2MCredentials) setTransport(base http.RoundTripper) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.httpClient = &http.Client{Transport: base}
+}
+
+func (c *OAuthU2MCredentials) cacheKey() string {
+	return c.host
+}
+
+func (c *OAuthU2MCredentials) refresh(
+	ctx context.Context,
+	refreshToken string,
+) (*OAuthToken, error) {
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+	form.Set("client_id", c.clientID)
+	token, err := requestToken(
+		ctx, c.httpClient, c.host, form, c.clientID, "", c.now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	return token, c.store(token)
+}
+
+func (c *OAuthU2MCredentials) store(token *OAuthToken) error {
+	c.token = token
+	return c.cache.Store(c.cacheKey(), token)
+}
+
+// login runs the authorization code flow with PKCE.
+func (c *OAuthU2MCredentials) login(ctx context.Context) (*OAuthToken, error) {
+	verifier, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+	state, err := randomString(16)
+	if err != nil {
+		return nil, err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	listener, err := net.Listen("tcp", c.listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Failed to listen for the OAuth callback on %s: %s",
+			c.listenAddr, err,
+		)
+	}
+	defer listener.Close()
+	redirectURI := fmt.Sprintf(
+		"http://localhost:%d", listener.Addr().(*net.TCPAddr).Port)
+
+	type callback struct {
+		code string
+		err  error
+	}
+	callbacks := make(chan callback, 1)
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			var result callback
+			switch {
+			case q.Get("error") != "":
+				result.err = fmt.Errorf(
+					"OAuth login failed: %s %s",
+					q.Get("error"), q.Get("error_description"),
+				)
+			case q.Get("state") != state:
+				result.err = fmt.Errorf("OAuth login failed: state mismatch")
+			case q.Get("code") == "":
+				result.err = fmt.Errorf("OAuth login failed: no code received")
+			default:
+				result.code = q.Get("code")
+			}
+			if result.err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintln(w, result.err)
+			} else {
+				fmt.Fprintln(w, "Login successful, you can close this window.")
+			}
+			select {
+			case callbacks <- result:
+			default:
+			}
+		}),
+	}
+	go server.Serve(listener)
+	defer server.Close()
+
+	q := url.Values{}
+	q.Set("client_id", c.clientID)
+	q.Set("response_type", "code")
+	q.Set("redirect_uri", redirectURI)
+	q.Set("scope", strings.Join(c.scopes, " "))
+	q.Set("state", state)
+	q.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	q.Set("code_challenge_method", "S256")
+	authorizeURL := c.host + "/oidc/v1/authorize?" + q.Encode()
+	if err := c.openBrowser(authorizeURL); err != nil {
+		return nil, err
+	}
+
+	var result callback
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result = <-callbacks:
+	}
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", result.code)
+	form.Set("redirect_uri", redirectURI)
+	form.Set("code_verifier", verifier)
+	form.Set("client_id", c.clientID)
+	token, err := requestToken(
+		ctx, c.httpClient, c.host, form, c.clientID, "", c.now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return token, c.store(token)
+}
+
+// randomString returns a URL safe random string of n random bytes.
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// openSystemBrowser opens a URL with the system browser. The URL is also
+// printed in case no browser can be opened.
+func openSystemBrowser(u string) error {
+	fmt.Fprintf(os.Stderr, "Open the following URL to log in:\n%s\n", u)
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", u)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", u)
+	default:
+		cmd = exec.Command("xdg-open", u)
+	}
+	// Failing to open a browser isn't fatal since the URL was printed.
+	cmd.Start()
+	return nil
+}
+
+// TokenCache persists OAuth tokens between runs.
+type TokenCache interface {
+	// Load returns the token for a key, or nil if there is none.
+	Load(key string) (*OAuthToken, error)
+	// Store saves the token for a key.
+	Store(key string, token *OAuthToken) error
+}
+
+// FileTokenCache is a TokenCache stored as a JSON file that is only
+// readable by the current user.
+type FileTokenCache struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+type tokenCacheFile struct {
+	Version int                    `json:"version"`
+	Tokens  map[string]*OAuthToken `json:"tokens"`
+}
+
+// Load implements the TokenCache interface.
+func (c *FileTokenCache) Load(key string) (*OAuthToken, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	file, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+	return file.Tokens[key], nil
+}
+
+// Store implements the TokenCache interface.
+func (c *FileTokenCache) Store(key string, token *OAuthToken) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	file, err := c.read()
+	if err != nil {
+		return err
+	}
+	file.Tokens[key] = token
+
+	raw, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0700); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(c.Path), ".token-cache")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.Path)
+}
+
+func (c *FileTokenCache) read() (*tokenCacheFile, error) {
+	file := &tokenCacheFile{Version: 1, Tokens: map[string]*OAuthToken{}}
+	raw, err := ioutil.ReadFile(c.Path)
+	if os.IsNotExist(err) {
+		return file, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, file); err != nil {
+		return nil, fmt.Errorf(
+			"Error parsing token cache at %q: %s", c.Path, err)
+	}
+	if file.Tokens == nil {
+		file.Tokens = map[string]*OAuthToken{}
+	}
+	return file, nil
+}
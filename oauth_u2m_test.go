@@ -0,0 +1,200 @@
+package databricks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeU2MServer is a fake workspace OIDC server for the authorization code
+// flow with PKCE.
+type fakeU2MServer struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	challenges map[string]string
+	logins     int
+	refreshes  int
+}
+
+func newFakeU2MServer(t *testing.T) *fakeU2MServer {
+	s := &fakeU2MServer{challenges: map[string]string{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oidc/v1/authorize", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("client_id") != DefaultU2MClientID ||
+			q.Get("code_challenge_method") != "S256" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		s.logins++
+		code := fmt.Sprintf("code-%d", s.logins)
+		s.challenges[code] = q.Get("code_challenge")
+		s.mu.Unlock()
+
+		redirect, err := url.Parse(q.Get("redirect_uri"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		rq := redirect.Query()
+		rq.Set("code", code)
+		rq.Set("state", q.Get("state"))
+		redirect.RawQuery = rq.Encode()
+		http.Redirect(w, r, redirect.String(), http.StatusFound)
+	})
+	mux.HandleFunc("/oidc/v1/token", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch r.FormValue("grant_type") {
+		case "authorization_code":
+			challenge := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+			expected, ok := s.challenges[r.FormValue("code")]
+			if !ok || expected != base64.RawURLEncoding.EncodeToString(challenge[:]) {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error":"invalid_grant"}`)
+				return
+			}
+			delete(s.challenges, r.FormValue("code"))
+			fmt.Fprintf(w, `{"access_token":"access-%d","refresh_token":"refresh-%d","expires_in":3600}`, s.logins, s.logins)
+		case "refresh_token":
+			if r.FormValue("refresh_token") == "revoked" {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error":"invalid_grant"}`)
+				return
+			}
+			s.refreshes++
+			fmt.Fprintf(w, `{"access_token":"refreshed-%d","expires_in":3600}`, s.refreshes)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// browser follows the authorization URL like a user's browser would.
+func browser(authorizeURL string) error {
+	go func() {
+		res, err := http.Get(authorizeURL)
+		if err == nil {
+			res.Body.Close()
+		}
+	}()
+	return nil
+}
+
+func tokenCacheHelper(t *testing.T) *FileTokenCache {
+	dir, err := ioutil.TempDir("", "token-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return &FileTokenCache{Path: filepath.Join(dir, "nested", "token-cache.json")}
+}
+
+func Test_OAuthU2MCredentials(t *testing.T) {
+	t.Parallel()
+	server := newFakeU2MServer(t)
+	cache := tokenCacheHelper(t)
+
+	creds, err := NewOAuthU2MCredentials(
+		server.URL,
+		OAuthU2MListenAddr("127.0.0.1:0"),
+		OAuthU2MTokenCache(cache),
+		OAuthU2MBrowser(browser),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	token, err := creds.Token(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "access-1" || token.RefreshToken != "refresh-1" {
+		t.Fatalf("Unexpected token: %+v", token)
+	}
+	if fi, err := os.Stat(cache.Path); err != nil || fi.Mode().Perm() != 0600 {
+		t.Fatalf("Expected a private token cache file: %v", err)
+	}
+
+	// A new process refreshes the cached token without logging in.
+	creds, err = NewOAuthU2MCredentials(
+		server.URL,
+		OAuthU2MTokenCache(cache),
+		OAuthU2MBrowser(func(string) error {
+			t.Fatalf("Unexpected login")
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	token, err = creds.Token(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "refreshed-1" || token.RefreshToken != "refresh-1" {
+		t.Fatalf("Unexpected token: %+v", token)
+	}
+
+	// A revoked refresh token falls back to logging in.
+	if err := cache.Store(server.URL, &OAuthToken{
+		AccessToken:  "expired",
+		RefreshToken: "revoked",
+		Expiry:       time.Now().Add(-time.Hour),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	creds, err = NewOAuthU2MCredentials(
+		server.URL,
+		OAuthU2MListenAddr("127.0.0.1:0"),
+		OAuthU2MTokenCache(cache),
+		OAuthU2MBrowser(browser),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err = creds.Token(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "access-2" {
+		t.Fatalf("Unexpected token: %+v", token)
+	}
+}
+
+func Test_OAuthU2MCredentials_Cancel(t *testing.T) {
+	t.Parallel()
+	server := newFakeU2MServer(t)
+	creds, err := NewOAuthU2MCredentials(
+		server.URL,
+		OAuthU2MListenAddr("127.0.0.1:0"),
+		OAuthU2MTokenCache(tokenCacheHelper(t)),
+		OAuthU2MBrowser(func(string) error { return nil }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := creds.Login(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
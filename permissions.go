@@ -0,0 +1,186 @@
+package databricks
+
+import "fmt"
+
+// PermissionObjectType is the type of an object that permissions are
+// granted on, as used in the path of the Permissions API.
+type PermissionObjectType string
+
+const (
+	PermissionObjectClusters        PermissionObjectType = "clusters"
+	PermissionObjectClusterPolicies PermissionObjectType = "cluster-policies"
+	PermissionObjectInstancePools   PermissionObjectType = "instance-pools"
+	PermissionObjectJobs            PermissionObjectType = "jobs"
+	// PermissionObjectNotebooks and PermissionObjectDirectories take the
+	// numeric object ID of a notebook or directory, not its path.
+	PermissionObjectNotebooks   PermissionObjectType = "notebooks"
+	PermissionObjectDirectories PermissionObjectType = "directories"
+	PermissionObjectRepos       PermissionObjectType = "repos"
+)
+
+// PermissionLevel is a level of permission on an object. Each object type
+// supports its own levels, which are returned by
+// PermissionObjectType.Levels.
+type PermissionLevel string
+
+const (
+	// PermissionCanAttachTo allows attaching notebooks to a cluster, or
+	// creating clusters from an instance pool.
+	PermissionCanAttachTo PermissionLevel = "CAN_ATTACH_TO"
+	// PermissionCanRestart allows starting, restarting and terminating a
+	// cluster.
+	PermissionCanRestart PermissionLevel = "CAN_RESTART"
+	// PermissionCanUse allows creating clusters with a cluster policy.
+	PermissionCanUse PermissionLevel = "CAN_USE"
+	// PermissionCanView allows viewing a job and its runs.
+	PermissionCanView PermissionLevel = "CAN_VIEW"
+	// PermissionCanManageRun allows running and cancelling a job.
+	PermissionCanManageRun PermissionLevel = "CAN_MANAGE_RUN"
+	// PermissionIsOwner is the owner of a job, whose identity its runs use.
+	// A job has exactly one owner.
+	PermissionIsOwner PermissionLevel = "IS_OWNER"
+	// PermissionCanRead allows reading a notebook, directory or repo.
+	PermissionCanRead PermissionLevel = "CAN_READ"
+	// PermissionCanRun allows running a notebook, or the notebooks of a
+	// directory or repo.
+	PermissionCanRun PermissionLevel = "CAN_RUN"
+	// PermissionCanEdit allows editing a notebook, directory or repo.
+	PermissionCanEdit PermissionLevel = "CAN_EDIT"
+	// PermissionCanManage allows everything on an object, including
+	// changing its permissions.
+	PermissionCanManage PermissionLevel = "CAN_MANAGE"
+)
+
+// permissionLevels are the permission levels of each object type, from the
+// lowest to the highest.
+var permissionLevels = map[PermissionObjectType][]PermissionLevel{
+	PermissionObjectClusters: {
+		PermissionCanAttachTo, PermissionCanRestart, PermissionCanManage,
+	},
+	PermissionObjectClusterPolicies: {PermissionCanUse},
+	PermissionObjectInstancePools: {
+		PermissionCanAttachTo, PermissionCanManage,
+	},
+	PermissionObjectJobs: {
+		PermissionCanView, PermissionCanManageRun, PermissionIsOwner,
+		PermissionCanManage,
+	},
+	PermissionObjectNotebooks: {
+		PermissionCanRead, PermissionCanRun, PermissionCanEdit,
+		PermissionCanManage,
+	},
+	PermissionObjectDirectories: {
+		PermissionCanRead, PermissionCanRun, PermissionCanEdit,
+		PermissionCanManage,
+	},
+	PermissionObjectRepos: {
+		PermissionCanRead, PermissionCanRun, PermissionCanEdit,
+		PermissionCanManage,
+	},
+}
+
+// Levels returns the permission levels that can be granted on the object
+// type, from the lowest to the highest, or nil if the type is not known.
+func (t PermissionObjectType) Levels() []PermissionLevel {
+	levels, ok := permissionLevels[t]
+	if !ok {
+		return nil
+	}
+	return append([]PermissionLevel{}, levels...)
+}
+
+// ObjectPermissions are the permissions of an object.
+type ObjectPermissions struct {
+	// ObjectID is the path of the object, e.g. "/clusters/0123-456789-abc".
+	ObjectID string `json:"object_id"`
+	// ObjectType is the singular type of the object, e.g. "cluster".
+	ObjectType        string          `json:"object_type"`
+	AccessControlList []AccessControl `json:"access_control_list"`
+}
+
+// Direct returns the permissions that are granted on the object itself,
+// and not inherited from a parent, as requests. They can be changed and
+// passed to PermissionsService.Set to replace the permissions of the
+// object without dropping the grants that are not changed.
+func (p *ObjectPermissions) Direct() []AccessControlRequest {
+	var direct []AccessControlRequest
+	for _, ac := range p.AccessControlList {
+		for _, permission := range ac.AllPermissions {
+			if permission.Inherited {
+				continue
+			}
+			direct = append(direct, AccessControlRequest{
+				UserName:             ac.UserName,
+				GroupName:            ac.GroupName,
+				ServicePrincipalName: ac.ServicePrincipalName,
+				PermissionLevel:      permission.PermissionLevel,
+			})
+		}
+	}
+	return direct
+}
+
+// AccessControl are the permissions of a principal on an object. Exactly
+// one of UserName, GroupName and ServicePrincipalName is set.
+type AccessControl struct {
+	UserName             string       `json:"user_name,omitempty"`
+	GroupName            string       `json:"group_name,omitempty"`
+	ServicePrincipalName string       `json:"service_principal_name,omitempty"`
+	DisplayName          string       `json:"display_name,omitempty"`
+	AllPermissions       []Permission `json:"all_permissions"`
+}
+
+// Permission is a permission of a principal on an object, either granted
+// on the object or inherited from a parent.
+type Permission struct {
+	PermissionLevel PermissionLevel `json:"permission_level"`
+	Inherited       bool            `json:"inherited"`
+	// InheritedFromObject are the paths of the objects that the permission
+	// is inherited from, e.g. "/directories/1234" or "/clusters/".
+	InheritedFromObject []string `json:"inherited_from_object,omitempty"`
+}
+
+// AccessControlRequest grants a permission level to a principal. Exactly one
+// of UserName, GroupName and ServicePrincipalName must be set.
+type AccessControlRequest struct {
+	UserName             string          `json:"user_name,omitempty"`
+	GroupName            string          `json:"group_name,omitempty"`
+	ServicePrincipalName string          `json:"service_principal_name,omitempty"`
+	PermissionLevel      PermissionLevel `json:"permission_level"`
+}
+
+// validate checks that the request has one principal and a permission
+// level of the object type. The levels of unknown object types are not
+// checked.
+func (r AccessControlRequest) validate(objectType PermissionObjectType) error {
+	principals := 0
+	for _, name := range []string{
+		r.UserName, r.GroupName, r.ServicePrincipalName,
+	} {
+		if name != "" {
+			principals++
+		}
+	}
+	if principals != 1 {
+		return fmt.Errorf("Invalid access control request %+v: exactly one "+
+			"of user, group and service principal must be set", r)
+	}
+	levels, ok := permissionLevels[objectType]
+	if !ok {
+		return nil
+	}
+	for _, level := range levels {
+		if r.PermissionLevel == level {
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid permission level %q for %s, expected one of %v",
+		r.PermissionLevel, objectType, levels)
+}
+
+// PermissionLevelDescription is a permission level that can be granted on
+// an object.
+type PermissionLevelDescription struct {
+	PermissionLevel PermissionLevel `json:"permission_level"`
+	Description     string          `json:"description"`
+}
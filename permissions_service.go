@@ -0,0 +1,152 @@
+package databricks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// PermissionsService is a service for interacting with the permissions of
+// clusters, cluster policies, instance pools, jobs, notebooks, directories
+// and repos.
+type PermissionsService struct {
+	client Client
+}
+
+// Get returns the permissions of an object, including the permissions
+// inherited from its parents.
+func (s *PermissionsService) Get(
+	ctx context.Context,
+	objectType PermissionObjectType,
+	objectID string,
+) (*ObjectPermissions, error) {
+	return s.send(ctx, "PermissionsService.Get", http.MethodGet,
+		objectType, objectID, nil)
+}
+
+// Set replaces the permissions granted on an object with the access
+// control list. Inherited permissions are not changed. Use
+// ObjectPermissions.Direct to keep the existing grants. An empty or nil
+// access control list removes all the grants.
+func (s *PermissionsService) Set(
+	ctx context.Context,
+	objectType PermissionObjectType,
+	objectID string,
+	acl []AccessControlRequest,
+) (*ObjectPermissions, error) {
+	return s.send(ctx, "PermissionsService.Set", http.MethodPut,
+		objectType, objectID, acl)
+}
+
+// Update grants the permissions of the access control list on an object,
+// in addition to its existing permissions.
+func (s *PermissionsService) Update(
+	ctx context.Context,
+	objectType PermissionObjectType,
+	objectID string,
+	acl []AccessControlRequest,
+) (*ObjectPermissions, error) {
+	return s.send(ctx, "PermissionsService.Update", http.MethodPatch,
+		objectType, objectID, acl)
+}
+
+// PermissionLevels returns the permission levels that the calling user can
+// grant on an object.
+func (s *PermissionsService) PermissionLevels(
+	ctx context.Context,
+	objectType PermissionObjectType,
+	objectID string,
+) ([]PermissionLevelDescription, error) {
+	req, err := s.client.newRequest(
+		ctx,
+		"PermissionsService.PermissionLevels",
+		http.MethodGet,
+		permissionsEndpoint(objectType)+"/"+url.PathEscape(objectID)+
+			"/permissionLevels",
+		nil,
+	)
+	if err != nil {
+		return []PermissionLevelDescription{}, err
+	}
+	req = withEndpoint(req, permissionsEndpoint(objectType)+"/permissionLevels")
+	res, err := s.client.do(req)
+	if err != nil {
+		return []PermissionLevelDescription{}, err
+	}
+	defer res.Body.Close()
+	decoder := json.NewDecoder(res.Body)
+
+	levelsRes := struct {
+		PermissionLevels []PermissionLevelDescription `json:"permission_levels"`
+	}{[]PermissionLevelDescription{}}
+	err = decoder.Decode(&levelsRes)
+
+	return levelsRes.PermissionLevels, err
+}
+
+// send sends a request to the permissions of an object, and decodes the
+// permissions it returns. Requests other than GET have an access control
+// list, which is empty if acl is nil.
+func (s *PermissionsService) send(
+	ctx context.Context,
+	serviceMethod string,
+	method string,
+	objectType PermissionObjectType,
+	objectID string,
+	acl []AccessControlRequest,
+) (*ObjectPermissions, error) {
+	var body io.Reader
+	if method != http.MethodGet {
+		if acl == nil {
+			acl = []AccessControlRequest{}
+		}
+		for _, ac := range acl {
+			if err := ac.validate(objectType); err != nil {
+				return nil, err
+			}
+		}
+		raw, err := json.Marshal(struct {
+			AccessControlList []AccessControlRequest `json:"access_control_list"`
+		}{
+			acl,
+		})
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewBuffer(raw)
+	}
+
+	req, err := s.client.newRequest(
+		ctx,
+		serviceMethod,
+		method,
+		permissionsEndpoint(objectType)+"/"+url.PathEscape(objectID),
+		body,
+	)
+	if err != nil {
+		return nil, err
+	}
+	req = withEndpoint(req, permissionsEndpoint(objectType))
+	res, err := s.client.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	decoder := json.NewDecoder(res.Body)
+
+	var permissions ObjectPermissions
+	err = decoder.Decode(&permissions)
+
+	return &permissions, err
+}
+
+// permissionsEndpoint returns the endpoint of the permissions of an object
+// type, e.g. 2.0/permissions/clusters. The ID of the object is appended to
+// it, but calls are reported to the Middleware with this endpoint so that
+// they are grouped by object type.
+func permissionsEndpoint(objectType PermissionObjectType) string {
+	return "2.0/permissions/" + string(objectType)
+}
@@ -0,0 +1,170 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// permissionsRequest is a request received by permissionsServer.
+type permissionsRequest struct {
+	method string
+	path   string
+	body   string
+}
+
+// permissionsServer serves a response to every request and records the
+// requests, and the endpoints that the calls are reported with to the
+// Middleware.
+func permissionsServer(
+	t *testing.T,
+	res string,
+) (*PermissionsService, *[]permissionsRequest, *[]string) {
+	t.Helper()
+	var requests []permissionsRequest
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			requests = append(requests, permissionsRequest{
+				method: r.Method,
+				path:   r.URL.EscapedPath(),
+				body:   strings.TrimSpace(string(body)),
+			})
+			w.Write([]byte(res))
+		},
+	))
+	t.Cleanup(server.Close)
+	var endpoints []string
+	client, err := NewClient("", ClientHost(server.URL),
+		ClientMiddleware(MiddlewareFuncs{
+			AfterFunc: func(ctx context.Context, call *Call) {
+				endpoints = append(endpoints, call.Endpoint)
+			},
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client.Permissions(), &requests, &endpoints
+}
+
+func Test_PermissionsService_Get(t *testing.T) {
+	t.Parallel()
+	permissions, requests, endpoints := permissionsServer(t,
+		string(readPayload(t, "permissions_get.json")))
+
+	ctx := context.Background()
+	res, err := permissions.Get(ctx, PermissionObjectClusters, "0412-221636-jolt512")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.AccessControlList) != 3 ||
+		!res.AccessControlList[2].AllPermissions[0].Inherited {
+		t.Fatalf("Unexpected permissions: %+v", res)
+	}
+	req := (*requests)[0]
+	if req.method != http.MethodGet ||
+		req.path != "/api/2.0/permissions/clusters/0412-221636-jolt512" {
+		t.Fatalf("Unexpected request: %+v", req)
+	}
+	if (*endpoints)[0] != "2.0/permissions/clusters" {
+		t.Fatalf("Expected endpoint without the object ID, got %s", (*endpoints)[0])
+	}
+
+	// Non 200 test
+	client, err := NewClient("test-account", ClientHTTPClient(Non200HTTPClient))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Permissions().Get(ctx, PermissionObjectJobs, "1")
+	if err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+
+	// Transport error test
+	client, err = NewClient("test-account", ClientHTTPClient(BadTransportHTTPClient))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Permissions().Get(ctx, PermissionObjectJobs, "1")
+	if err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+}
+
+func Test_PermissionsService_SetUpdate(t *testing.T) {
+	t.Parallel()
+	permissions, requests, _ := permissionsServer(t,
+		string(readPayload(t, "permissions_get.json")))
+
+	ctx := context.Background()
+	acl := []AccessControlRequest{
+		{GroupName: "data-eng", PermissionLevel: PermissionCanRestart},
+	}
+	if _, err := permissions.Set(ctx, PermissionObjectClusters, "0412-221636-jolt512", acl); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := permissions.Update(ctx, PermissionObjectClusters, "0412-221636-jolt512", acl); err != nil {
+		t.Fatal(err)
+	}
+	expectedBody := `{"access_control_list":[{"group_name":"data-eng","permission_level":"CAN_RESTART"}]}`
+	for i, method := range []string{http.MethodPut, http.MethodPatch} {
+		req := (*requests)[i]
+		if req.method != method || req.body != expectedBody {
+			t.Fatalf("Expected %s with %s, got %+v", method, expectedBody, req)
+		}
+	}
+
+	// Invalid requests are rejected before they are sent.
+	invalid := [][]AccessControlRequest{
+		{{GroupName: "data-eng", PermissionLevel: PermissionCanRun}},
+		{{PermissionLevel: PermissionCanManage}},
+		{{UserName: "a@example.com", GroupName: "data-eng", PermissionLevel: PermissionCanManage}},
+	}
+	for _, acl := range invalid {
+		if _, err := permissions.Update(ctx, PermissionObjectClusters, "0412-221636-jolt512", acl); err == nil {
+			t.Fatalf("Expected error for %+v", acl)
+		}
+	}
+	if len(*requests) != 2 {
+		t.Fatalf("Expected invalid requests not to be sent, got %+v", *requests)
+	}
+
+	// A nil list clears the grants.
+	if _, err := permissions.Set(ctx, PermissionObjectClusters, "0412-221636-jolt512", nil); err != nil {
+		t.Fatal(err)
+	}
+	if req := (*requests)[2]; req.body != `{"access_control_list":[]}` {
+		t.Fatalf("Expected an empty access control list, got %+v", req)
+	}
+}
+
+func Test_PermissionsService_PermissionLevels(t *testing.T) {
+	t.Parallel()
+	res, err := json.Marshal(map[string]interface{}{
+		"permission_levels": []PermissionLevelDescription{
+			{PermissionLevel: PermissionCanRead, Description: "Can view"},
+			{PermissionLevel: PermissionCanManage, Description: "Can manage"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	permissions, requests, endpoints := permissionsServer(t, string(res))
+
+	levels, err := permissions.PermissionLevels(context.Background(),
+		PermissionObjectNotebooks, "1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(levels) != 2 || levels[1].PermissionLevel != PermissionCanManage {
+		t.Fatalf("Unexpected levels: %+v", levels)
+	}
+	if (*requests)[0].path != "/api/2.0/permissions/notebooks/1234/permissionLevels" ||
+		(*endpoints)[0] != "2.0/permissions/notebooks/permissionLevels" {
+		t.Fatalf("Unexpected request %+v reported as %s", (*requests)[0], (*endpoints)[0])
+	}
+}
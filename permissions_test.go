@@ -0,0 +1,50 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_ObjectPermissions_RoundTrip(t *testing.T) {
+	t.Parallel()
+	var permissions ObjectPermissions
+	expectRoundTrip(t, readPayload(t, "permissions_get.json"), &permissions)
+
+	inherited := permissions.AccessControlList[1].AllPermissions[1]
+	if !inherited.Inherited || inherited.PermissionLevel != PermissionCanAttachTo ||
+		len(inherited.InheritedFromObject) != 1 {
+		t.Fatalf("Unexpected inherited permission: %+v", inherited)
+	}
+
+	expected := []AccessControlRequest{
+		{UserName: "jane@example.com", PermissionLevel: PermissionCanManage},
+		{GroupName: "data-eng", PermissionLevel: PermissionCanRestart},
+	}
+	if direct := permissions.Direct(); !reflect.DeepEqual(direct, expected) {
+		t.Fatalf("Expected direct permissions %+v, got %+v", expected, direct)
+	}
+}
+
+func Test_PermissionObjectType_Levels(t *testing.T) {
+	t.Parallel()
+	levels := PermissionObjectClusters.Levels()
+	expected := []PermissionLevel{
+		PermissionCanAttachTo, PermissionCanRestart, PermissionCanManage,
+	}
+	if !reflect.DeepEqual(levels, expected) {
+		t.Fatalf("Expected %v, got %v", expected, levels)
+	}
+	levels[0] = PermissionIsOwner
+	if PermissionObjectClusters.Levels()[0] != PermissionCanAttachTo {
+		t.Fatalf("Expected Levels to return a copy")
+	}
+	if PermissionObjectType("pipelines").Levels() != nil {
+		t.Fatalf("Expected no levels for an unknown object type")
+	}
+
+	// The levels of unknown object types are not checked.
+	ac := AccessControlRequest{UserName: "a@example.com", PermissionLevel: "CAN_RUN"}
+	if err := ac.validate(PermissionObjectType("pipelines")); err != nil {
+		t.Fatal(err)
+	}
+}
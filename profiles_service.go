@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 )
 
@@ -32,23 +31,21 @@ func (s *ProfilesService) Add(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"ProfilesService.Add",
 		http.MethodPost,
-		s.client.url+"2.0/instance-profiles/add",
+		"2.0/instance-profiles/add",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 
@@ -59,23 +56,20 @@ func (s *ProfilesService) Add(
 func (s *ProfilesService) List(
 	ctx context.Context,
 ) ([]string, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"ProfilesService.List",
 		http.MethodGet,
-		s.client.url+"2.0/instance-profiles/get",
+		"2.0/instance-profiles/get",
 		nil,
 	)
 	if err != nil {
 		return []string{}, err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return []string{}, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []string{}, fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 
@@ -104,23 +98,21 @@ func (s *ProfilesService) Remove(
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"ProfilesService.Remove",
 		http.MethodPost,
-		s.client.url+"2.0/instance-profiles/remove",
+		"2.0/instance-profiles/remove",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to returns 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -0,0 +1,216 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIFamily is a family of API endpoints that Databricks rate limits
+// together, e.g. all of the 2.0/jobs endpoints.
+type APIFamily string
+
+const (
+	// FamilyClusters is the 2.0/clusters endpoints.
+	FamilyClusters APIFamily = "clusters"
+	// FamilyDBFS is the 2.0/dbfs endpoints.
+	FamilyDBFS APIFamily = "dbfs"
+	// FamilyGroups is the 2.0/groups endpoints.
+	FamilyGroups APIFamily = "groups"
+	// FamilyInstancePools is the 2.0/instance-pools endpoints.
+	FamilyInstancePools APIFamily = "instance-pools"
+	// FamilyInstanceProfiles is the 2.0/instance-profiles endpoints.
+	FamilyInstanceProfiles APIFamily = "instance-profiles"
+	// FamilyJobs is the 2.0/jobs endpoints, including runs.
+	FamilyJobs APIFamily = "jobs"
+	// FamilyLibraries is the 2.0/libraries endpoints.
+	FamilyLibraries APIFamily = "libraries"
+	// FamilyPermissions is the 2.0/permissions endpoints of every object
+	// type.
+	FamilyPermissions APIFamily = "permissions"
+	// FamilyPolicies is the 2.0/policies endpoints, such as the cluster
+	// policies.
+	FamilyPolicies APIFamily = "policies"
+	// FamilySecrets is the 2.0/secrets endpoints, including scopes and
+	// ACLs.
+	FamilySecrets APIFamily = "secrets"
+	// FamilyToken is the 2.0/token endpoints.
+	FamilyToken APIFamily = "token"
+	// FamilyWorkspace is the 2.0/workspace endpoints.
+	FamilyWorkspace APIFamily = "workspace"
+)
+
+// apiFamily returns the APIFamily of a request path such as
+// /api/2.0/jobs/runs/get.
+func apiFamily(path string) APIFamily {
+	if i := strings.Index(path, "/api/"); i >= 0 {
+		path = path[i+len("/api/"):]
+	}
+	parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)
+	if len(parts) < 2 {
+		return ""
+	}
+	return APIFamily(parts[1])
+}
+
+// RateLimit is a token bucket limit. Tokens are added at RequestsPerSecond
+// up to Burst, and every request consumes one token. A zero RateLimit is
+// unlimited.
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// RateLimits are the client side rate limits of a Client. A request must
+// satisfy both the Global limit and the limit of its APIFamily.
+type RateLimits struct {
+	Global   RateLimit
+	Families map[APIFamily]RateLimit
+}
+
+// ClientRateLimit configures client side rate limiting. Calls block until
+// the limits allow them, or fail early when the context deadline would be
+// exceeded while waiting.
+func ClientRateLimit(limits RateLimits) ClientOpt {
+	return func(c *Client) error {
+		limiter, err := newRateLimiter(limits)
+		if err != nil {
+			return err
+		}
+		c.limiter = limiter
+		return nil
+	}
+}
+
+// rateLimiter holds the token buckets of a Client. It is shared between the
+// services created from a Client.
+type rateLimiter struct {
+	global   *tokenBucket
+	families map[APIFamily]*tokenBucket
+}
+
+func newRateLimiter(limits RateLimits) (*rateLimiter, error) {
+	global, err := newTokenBucket(limits.Global)
+	if err != nil {
+		return nil, err
+	}
+	r := &rateLimiter{
+		global:   global,
+		families: map[APIFamily]*tokenBucket{},
+	}
+	for family, limit := range limits.Families {
+		bucket, err := newTokenBucket(limit)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid rate limit for %s: %s", family, err)
+		}
+		r.families[family] = bucket
+	}
+	return r, nil
+}
+
+// wait blocks until a request of the family is allowed. The token of the
+// family is returned if the wait on the global bucket fails.
+func (r *rateLimiter) wait(ctx context.Context, family APIFamily) error {
+	if r == nil {
+		return nil
+	}
+	bucket := r.families[family]
+	if err := bucket.wait(ctx); err != nil {
+		return err
+	}
+	if err := r.global.wait(ctx); err != nil {
+		if bucket != nil {
+			bucket.cancel()
+		}
+		return err
+	}
+	return nil
+}
+
+// tokenBucket is a token bucket rate limiter. A nil tokenBucket never
+// blocks.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newTokenBucket(limit RateLimit) (*tokenBucket, error) {
+	if limit.RequestsPerSecond == 0 && limit.Burst == 0 {
+		return nil, nil
+	}
+	if limit.RequestsPerSecond <= 0 {
+		return nil, fmt.Errorf("RequestsPerSecond must be positive")
+	}
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   limit.RequestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		now:    time.Now,
+	}, nil
+}
+
+// reserve takes a token and returns how long the caller must wait before
+// using it.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a token that was reserved but not used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	wait := b.reserve()
+	if wait == 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && b.now().Add(wait).After(deadline) {
+		b.cancel()
+		return fmt.Errorf(
+			"Rate limit wait of %s would exceed the context deadline: %w",
+			wait, context.DeadlineExceeded,
+		)
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
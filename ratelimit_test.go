@@ -0,0 +1,125 @@
+package databricks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_apiFamily(t *testing.T) {
+	t.Parallel()
+	tests := map[string]APIFamily{
+		"/api/2.0/jobs/runs/get":         FamilyJobs,
+		"/api/2.0/dbfs/add-block":        FamilyDBFS,
+		"/api/2.0/clusters/list":         FamilyClusters,
+		"/api/2.0/instance-profiles/add": FamilyInstanceProfiles,
+		"/api/2.0/policies/clusters/get": FamilyPolicies,
+		"/":                              "",
+	}
+	for path, expected := range tests {
+		if family := apiFamily(path); family != expected {
+			t.Errorf("apiFamily(%q) = %q, want %q", path, family, expected)
+		}
+	}
+}
+
+func Test_tokenBucket(t *testing.T) {
+	t.Parallel()
+	bucket, err := newTokenBucket(RateLimit{RequestsPerSecond: 2, Burst: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	bucket.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if wait := bucket.reserve(); wait != 0 {
+			t.Fatalf("Expected burst to not wait, got %v", wait)
+		}
+	}
+	if wait := bucket.reserve(); wait != 500*time.Millisecond {
+		t.Fatalf("Expected to wait 500ms, got %v", wait)
+	}
+	now = now.Add(time.Second)
+	if wait := bucket.reserve(); wait != 0 {
+		t.Fatalf("Expected tokens to be refilled, got %v", wait)
+	}
+
+	if _, err := newTokenBucket(RateLimit{Burst: 1}); err == nil {
+		t.Fatalf("Expected an error for a limit without a rate")
+	}
+	if bucket, err := newTokenBucket(RateLimit{}); err != nil || bucket != nil {
+		t.Fatalf("Expected a zero limit to be unlimited")
+	}
+}
+
+func Test_rateLimiter(t *testing.T) {
+	t.Parallel()
+	limiter, err := newRateLimiter(RateLimits{
+		Global: RateLimit{RequestsPerSecond: 0.001, Burst: 1},
+		Families: map[APIFamily]RateLimit{
+			FamilyJobs: {RequestsPerSecond: 0.001, Burst: 1},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := limiter.wait(ctx, FamilyClusters); err != nil {
+		t.Fatal(err)
+	}
+	// The global bucket is empty, so the jobs token must be returned.
+	err = limiter.wait(ctx, FamilyJobs)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if wait := limiter.families[FamilyJobs].reserve(); wait != 0 {
+		t.Fatalf("Expected the jobs token to be returned, got a wait of %v", wait)
+	}
+}
+
+func Test_ClientRateLimit(t *testing.T) {
+	t.Parallel()
+	tripper := &sequenceTripper{codes: []int{200}}
+	client, err := NewClient(
+		"test-account",
+		ClientHTTPClient(&http.Client{Transport: tripper}),
+		ClientRateLimit(RateLimits{
+			Families: map[APIFamily]RateLimit{
+				FamilyJobs: {RequestsPerSecond: 0.001, Burst: 1},
+			},
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := client.Jobs().RunsGet(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	// The jobs bucket is empty and refills far after the deadline.
+	_, err = client.Jobs().RunsGet(ctx, 1)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	// Other families aren't limited.
+	if _, err := client.Cluster().List(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if tripper.attempts() != 2 {
+		t.Fatalf("Expected 2 requests, got %d", tripper.attempts())
+	}
+
+	_, err = NewClient(
+		"test-account",
+		ClientRateLimit(RateLimits{Global: RateLimit{RequestsPerSecond: -1}}),
+	)
+	if err == nil {
+		t.Fatalf("Expected an error for a negative rate")
+	}
+}
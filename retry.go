@@ -0,0 +1,200 @@
+package databricks
+
+import (
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy configures how a Client retries requests that failed with a
+// transient error. Requests are retried on 429 responses, 5XX responses and
+// transport errors.
+//
+// Reads and naturally idempotent POSTs (e.g. clusters/start or jobs/reset)
+// are retried for all of these failures. Creates such as clusters/create or
+// jobs/create may have been applied by the server before the failure, so they
+// are not retried unless RetryNonIdempotent is set.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first
+	// one. A value of 1 or less disables retries.
+	MaxAttempts int
+	// MinBackoff is the backoff before the first retry. Each following retry
+	// doubles the backoff up to MaxBackoff. Jitter is applied to every
+	// backoff.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum backoff between two attempts. It does not
+	// limit waits requested by a Retry-After header.
+	MaxBackoff time.Duration
+	// RetryNonIdempotent opts in to retrying creates on any transient
+	// failure.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is a reasonable RetryPolicy for automation.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+}
+
+// ClientRetryPolicy configures the Client's RetryPolicy. By default requests
+// are not retried.
+func ClientRetryPolicy(policy RetryPolicy) ClientOpt {
+	return func(c *Client) error {
+		c.retry = policy
+		return nil
+	}
+}
+
+// nonIdempotentEndpoints are endpoints that must not be sent twice, either
+// because they create a new resource or because they append data.
+var nonIdempotentEndpoints = map[string]bool{
+	"2.0/clusters/create":          true,
+	"2.0/dbfs/add-block":           true,
+	"2.0/dbfs/create":              true,
+	"2.0/groups/create":            true,
+	"2.0/instance-pools/create":    true,
+	"2.0/jobs/create":              true,
+	"2.0/jobs/run-now":             true,
+	"2.0/jobs/runs/submit":         true,
+	"2.0/policies/clusters/create": true,
+	"2.0/secrets/scopes/create":    true,
+	"2.0/token/create":             true,
+	"2.0/workspace/import":         true,
+}
+
+// isIdempotent returns if a request can be safely sent more than once.
+func isIdempotent(req *http.Request) bool {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return true
+	}
+	path := req.URL.Path
+	if i := strings.Index(path, "/api/"); i >= 0 {
+		path = path[i+len("/api/"):]
+	}
+	return !nonIdempotentEndpoints[path]
+}
+
+// send sends the request of a call, retrying it according to the Client's
+// RetryPolicy. Every attempt waits for the Client's rate limits.
+// Request bodies are replayed with req.GetBody, which http.NewRequest sets
+// for the bytes.Buffer bodies used by the services.
+func (c *Client) send(call *Call) (*http.Response, error) {
+	req := call.Request
+	ctx := req.Context()
+	idempotent := isIdempotent(req)
+	family := apiFamily(req.URL.Path)
+
+	for attempt := 1; ; attempt++ {
+		call.Attempts = attempt
+		attemptReq := req
+		if attempt > 1 {
+			attemptReq = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		if err := c.limiter.wait(ctx, family); err != nil {
+			return nil, err
+		}
+		res, err := c.client.Do(attemptReq)
+		if err == nil && res.StatusCode == http.StatusTooManyRequests {
+			call.Throttles++
+		}
+		if attempt >= c.retry.MaxAttempts || ctx.Err() != nil {
+			return res, err
+		}
+		if req.Body != nil && req.GetBody == nil {
+			return res, err
+		}
+		if !c.retry.shouldRetry(res, err, idempotent) {
+			return res, err
+		}
+
+		wait := c.retry.backoff(attempt)
+		if res != nil {
+			if retryAfter, ok := parseRetryAfter(
+				res.Header.Get("Retry-After"), time.Now(),
+			); ok && retryAfter > wait {
+				wait = retryAfter
+			}
+			// Drain the body so the connection can be reused.
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry returns if a request should be retried given the outcome of
+// its last attempt.
+func (p RetryPolicy) shouldRetry(
+	res *http.Response,
+	err error,
+	idempotent bool,
+) bool {
+	if !idempotent && !p.RetryNonIdempotent {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return res.StatusCode == http.StatusTooManyRequests ||
+		res.StatusCode >= 500 && res.StatusCode != http.StatusNotImplemented
+}
+
+// backoff returns the jittered exponential backoff before the given retry.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	backoff := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || backoff < p.MaxBackoff); i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	// Equal jitter: wait at least half of the backoff.
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1))
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
@@ -0,0 +1,209 @@
+package databricks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// sequenceTripper returns the configured responses in order and records the
+// request bodies it received.
+type sequenceTripper struct {
+	mu     sync.Mutex
+	codes  []int
+	header http.Header
+	bodies []string
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *sequenceTripper) RoundTrip(
+	req *http.Request,
+) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		t.bodies = append(t.bodies, string(body))
+	} else {
+		t.bodies = append(t.bodies, "")
+	}
+	code := t.codes[0]
+	if len(t.codes) > 1 {
+		t.codes = t.codes[1:]
+	}
+	return &http.Response{
+		StatusCode: code,
+		Header:     t.header,
+		Body: nopCloser{
+			bytes.NewBufferString(`{"cluster_id":"abc","job_id":1}`),
+		},
+	}, nil
+}
+
+func (t *sequenceTripper) attempts() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.bodies)
+}
+
+func retryClientHelper(
+	t *testing.T,
+	tripper *sequenceTripper,
+	policy RetryPolicy,
+) *Client {
+	client, err := NewClient(
+		"test-account",
+		ClientHTTPClient(&http.Client{Transport: tripper}),
+		ClientRetryPolicy(policy),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  time.Millisecond,
+	MaxBackoff:  2 * time.Millisecond,
+}
+
+func Test_Retry_Idempotent(t *testing.T) {
+	t.Parallel()
+	tripper := &sequenceTripper{codes: []int{503, 429, 200}}
+	client := retryClientHelper(t, tripper, testRetryPolicy)
+
+	err := client.Cluster().Start(context.Background(), "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tripper.attempts() != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", tripper.attempts())
+	}
+	for _, body := range tripper.bodies {
+		if body != `{"cluster_id":"abc"}` {
+			t.Fatalf("Request body was not replayed: %q", body)
+		}
+	}
+
+	// Attempts are bounded.
+	tripper = &sequenceTripper{codes: []int{503}}
+	client = retryClientHelper(t, tripper, testRetryPolicy)
+	_, err = client.Cluster().List(context.Background())
+	if !errors.Is(err, ErrTemporarilyUnavailable) {
+		t.Fatalf("Expected ErrTemporarilyUnavailable, got %v", err)
+	}
+	if tripper.attempts() != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", tripper.attempts())
+	}
+
+	// Client errors are not retried.
+	tripper = &sequenceTripper{codes: []int{400, 200}}
+	client = retryClientHelper(t, tripper, testRetryPolicy)
+	_, err = client.Cluster().List(context.Background())
+	if err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+	if tripper.attempts() != 1 {
+		t.Fatalf("Expected 1 attempt, got %d", tripper.attempts())
+	}
+}
+
+func Test_Retry_NonIdempotent(t *testing.T) {
+	t.Parallel()
+	tripper := &sequenceTripper{codes: []int{503, 200}}
+	client := retryClientHelper(t, tripper, testRetryPolicy)
+
+	_, err := client.Jobs().Create(context.Background(), &JobCreateRequest{})
+	if err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+	if tripper.attempts() != 1 {
+		t.Fatalf("Expected 1 attempt, got %d", tripper.attempts())
+	}
+
+	tripper = &sequenceTripper{codes: []int{429, 200}}
+	client = retryClientHelper(t, tripper, testRetryPolicy)
+	_, err = client.Jobs().Create(context.Background(), &JobCreateRequest{})
+	if err == nil {
+		t.Fatalf("Expected error to not be nil")
+	}
+	if tripper.attempts() != 1 {
+		t.Fatalf("Expected 1 attempt, got %d", tripper.attempts())
+	}
+
+	policy := testRetryPolicy
+	policy.RetryNonIdempotent = true
+	tripper = &sequenceTripper{codes: []int{503, 429, 200}}
+	client = retryClientHelper(t, tripper, policy)
+	jobID, err := client.Jobs().Create(context.Background(), &JobCreateRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobID != 1 || tripper.attempts() != 3 {
+		t.Fatalf("Unexpected result: %d after %d attempts", jobID, tripper.attempts())
+	}
+}
+
+func Test_Retry_Context(t *testing.T) {
+	t.Parallel()
+	tripper := &sequenceTripper{
+		codes:  []int{429},
+		header: http.Header{"Retry-After": []string{"3600"}},
+	}
+	client := retryClientHelper(t, tripper, testRetryPolicy)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.Cluster().List(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"-1", 0, false},
+		{"Tue, 01 Jan 2019 00:00:10 GMT", 10 * time.Second, true},
+		{"Mon, 31 Dec 2018 00:00:10 GMT", 0, true},
+		{"soon", 0, false},
+	}
+	for _, test := range tests {
+		wait, ok := parseRetryAfter(test.value, now)
+		if wait != test.expected || ok != test.ok {
+			t.Errorf(
+				"parseRetryAfter(%q) = %v, %v, want %v, %v",
+				test.value, wait, ok, test.expected, test.ok,
+			)
+		}
+	}
+}
+
+func Test_RetryPolicy_backoff(t *testing.T) {
+	t.Parallel()
+	policy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 4 * time.Second}
+	for attempt, max := range []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second,
+	} {
+		backoff := policy.backoff(attempt + 1)
+		if backoff < max/2 || backoff > max {
+			t.Errorf("backoff(%d) = %v, want in [%v, %v]", attempt+1, backoff, max/2, max)
+		}
+	}
+}
@@ -1,23 +1,49 @@
 package databricks
 
+// ACLPermission is the permission of a principal on a secret scope.
+// Permissions are ordered, each one including the ones before it.
+type ACLPermission string
+
+const (
+	// ACLPermissionRead allows reading the secret scope and listing its
+	// secrets.
+	ACLPermissionRead ACLPermission = "READ"
+	// ACLPermissionWrite allows reading and writing the secret scope.
+	ACLPermissionWrite ACLPermission = "WRITE"
+	// ACLPermissionManage allows changing the ACLs of the secret scope, and
+	// reading and writing it.
+	ACLPermissionManage ACLPermission = "MANAGE"
+)
+
 // ACLItem is an item representing an ACL rule applied to the given principal
 // (user or group) on the associated scope point.
 type ACLItem struct {
-	Principal  string `json:"principal"`
-	Permission string `json:"permission"` // TODO(daniel): should this be a type?
+	Principal  string        `json:"principal"`
+	Permission ACLPermission `json:"permission"`
 }
 
 // SecretMetadata is the metadata about a secret. Returned when listing
 // secrets. Does not contain the actual secret value.
 type SecretMetadata struct {
-	Key                  string `json:"key"`
-	LastUpdatedTimestamp int64  `json:"last_updated_timestamp"`
+	Key                  string      `json:"key"`
+	LastUpdatedTimestamp EpochMillis `json:"last_updated_timestamp"`
 }
 
 // SecretScope is an organizational resource for storing secrets. Secret scopes
 // can be different types, and ACLs can be applied to control permissions for
 // all secrets within a scope.
 type SecretScope struct {
-	Name        string
-	BackendType string // TODO(daniel)
+	Name        string           `json:"name"`
+	BackendType ScopeBackendType `json:"backend_type"`
 }
+
+// ScopeBackendType is the backend that stores the secrets of a scope.
+type ScopeBackendType string
+
+const (
+	// ScopeBackendDatabricks is a scope stored in an encrypted database
+	// owned and managed by Databricks.
+	ScopeBackendDatabricks ScopeBackendType = "DATABRICKS"
+	// ScopeBackendAzureKeyVault is a scope backed by an Azure Key Vault.
+	ScopeBackendAzureKeyVault ScopeBackendType = "AZURE_KEYVAULT"
+)
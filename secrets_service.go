@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 )
 
@@ -24,8 +23,8 @@ func (s *SecretsService) CreateSecretScope(
 	scope, initialManagePrincipal string,
 ) error {
 	raw, err := json.Marshal(struct {
-		Scope                  string
-		InitialManagePrincipal string
+		Scope                  string `json:"scope"`
+		InitialManagePrincipal string `json:"initial_manage_principal,omitempty"`
 	}{
 		scope,
 		initialManagePrincipal,
@@ -34,23 +33,21 @@ func (s *SecretsService) CreateSecretScope(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"SecretsService.CreateSecretScope",
 		http.MethodPost,
-		s.client.url+"2.0/secrets/scopes/create",
+		"2.0/secrets/scopes/create",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -61,7 +58,7 @@ func (s *SecretsService) DeleteSecretScope(
 	scope string,
 ) error {
 	raw, err := json.Marshal(struct {
-		Scope string
+		Scope string `json:"scope"`
 	}{
 		scope,
 	})
@@ -69,23 +66,21 @@ func (s *SecretsService) DeleteSecretScope(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"SecretsService.DeleteSecretScope",
 		http.MethodPost,
-		s.client.url+"2.0/secrets/scopes/delete",
+		"2.0/secrets/scopes/delete",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -94,28 +89,25 @@ func (s *SecretsService) DeleteSecretScope(
 func (s *SecretsService) ListSecretScopes(
 	ctx context.Context,
 ) ([]SecretScope, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"SecretsService.ListSecretScopes",
 		http.MethodGet,
-		s.client.url+"2.0/secrets/scopes/list",
+		"2.0/secrets/scopes/list",
 		nil,
 	)
 	if err != nil {
 		return []SecretScope{}, err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return []SecretScope{}, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []SecretScope{}, fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
 
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 	listRes := struct {
-		Scopes []SecretScope
+		Scopes []SecretScope `json:"scopes"`
 	}{[]SecretScope{}}
 	err = decoder.Decode(&listRes)
 
@@ -137,9 +129,9 @@ func (s *SecretsService) PutSecret(
 	scope, key, value string,
 ) error {
 	raw, err := json.Marshal(struct {
-		Scope       string
-		Key         string
-		StringValue string
+		Scope       string `json:"scope"`
+		Key         string `json:"key"`
+		StringValue string `json:"string_value"`
 	}{
 		scope,
 		key,
@@ -149,23 +141,21 @@ func (s *SecretsService) PutSecret(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"SecretsService.PutSecret",
 		http.MethodPost,
-		s.client.url+"2.0/secrets/put",
+		"2.0/secrets/put",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -176,8 +166,8 @@ func (s *SecretsService) DeleteSecret(
 	scope, key string,
 ) error {
 	raw, err := json.Marshal(struct {
-		Scope string
-		Key   string
+		Scope string `json:"scope"`
+		Key   string `json:"key"`
 	}{
 		scope,
 		key,
@@ -186,23 +176,21 @@ func (s *SecretsService) DeleteSecret(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"SecretsService.DeleteSecret",
 		http.MethodPost,
-		s.client.url+"2.0/secrets/delete",
+		"2.0/secrets/delete",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -213,28 +201,25 @@ func (s *SecretsService) DeleteSecret(
 func (s *SecretsService) ListSecrets(
 	ctx context.Context,
 ) ([]SecretMetadata, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"SecretsService.ListSecrets",
 		http.MethodGet,
-		s.client.url+"2.0/secrets/list",
+		"2.0/secrets/list",
 		nil,
 	)
 	if err != nil {
 		return []SecretMetadata{}, err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return []SecretMetadata{}, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []SecretMetadata{}, fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
 
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 	listRes := struct {
-		Secrets []SecretMetadata
+		Secrets []SecretMetadata `json:"secrets"`
 	}{[]SecretMetadata{}}
 	err = decoder.Decode(&listRes)
 
@@ -259,12 +244,12 @@ func (s *SecretsService) ListSecrets(
 func (s *SecretsService) PutSecretACL(
 	ctx context.Context,
 	scope, principal string,
-	permission string,
+	permission ACLPermission,
 ) error {
 	raw, err := json.Marshal(struct {
-		Scope      string
-		Principal  string
-		Permission string
+		Scope      string        `json:"scope"`
+		Principal  string        `json:"principal"`
+		Permission ACLPermission `json:"permission"`
 	}{
 		scope,
 		principal,
@@ -274,23 +259,21 @@ func (s *SecretsService) PutSecretACL(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"SecretsService.PutSecretACL",
 		http.MethodPost,
-		s.client.url+"2.0/secrets/acls/put",
+		"2.0/secrets/acls/put",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -301,8 +284,8 @@ func (s *SecretsService) DeleteSecretACL(
 	scope, principal string,
 ) error {
 	raw, err := json.Marshal(struct {
-		Scope     string
-		Principal string
+		Scope     string `json:"scope"`
+		Principal string `json:"principal"`
 	}{
 		scope,
 		principal,
@@ -311,23 +294,21 @@ func (s *SecretsService) DeleteSecretACL(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"SecretsService.DeleteSecretACL",
 		http.MethodPost,
-		s.client.url+"2.0/secrets/acls/delete",
+		"2.0/secrets/acls/delete",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 
 	return nil
 }
@@ -339,34 +320,31 @@ func (s *SecretsService) DeleteSecretACL(
 func (s *SecretsService) GetSecretACL(
 	ctx context.Context,
 	scope, principal string,
-) (string, error) {
-	req, err := http.NewRequest(
+) (ACLPermission, error) {
+	req, err := s.client.newRequest(
+		ctx,
+		"SecretsService.GetSecretACL",
 		http.MethodGet,
-		s.client.url+"2.0/secrets/acls/get",
+		"2.0/secrets/acls/get",
 		nil,
 	)
 	if err != nil {
 		return "", err
 	}
-	req = req.WithContext(ctx)
 	q := req.URL.Query()
 	q.Add("scope", scope)
 	q.Add("principal", principal)
 	req.URL.RawQuery = q.Encode()
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return "", err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return "", fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
 
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 	getRes := struct {
-		Principal  string
-		Permission string
+		Principal  string        `json:"principal"`
+		Permission ACLPermission `json:"permission"`
 	}{}
 	err = decoder.Decode(&getRes)
 
@@ -380,31 +358,28 @@ func (s *SecretsService) ListSecretACLs(
 	ctx context.Context,
 	scope string,
 ) ([]ACLItem, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"SecretsService.ListSecretACLs",
 		http.MethodGet,
-		s.client.url+"2.0/secrets/acls/list",
+		"2.0/secrets/acls/list",
 		nil,
 	)
 	if err != nil {
 		return []ACLItem{}, err
 	}
-	req = req.WithContext(ctx)
 	q := req.URL.Query()
 	q.Add("scope", scope)
 	req.URL.RawQuery = q.Encode()
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return []ACLItem{}, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []ACLItem{}, fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
 
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 	listRes := struct {
-		Items []ACLItem
+		Items []ACLItem `json:"items"`
 	}{[]ACLItem{}}
 	err = decoder.Decode(&listRes)
 
@@ -3,8 +3,14 @@ package databricks
 // PublicTokenInfo is a data structure that describes the public metadata of an
 // access token.
 type PublicTokenInfo struct {
-	TokenID      string `json:"token_id"`
-	CreationTime int64  `json:"creation_time"`
-	ExpiryTime   int64  `json:"expiry_time"`
-	Comment      string `json:"comment"`
+	TokenID      string      `json:"token_id"`
+	CreationTime EpochMillis `json:"creation_time"`
+	// ExpiryTime is -1 for tokens that do not expire, see NeverExpires.
+	ExpiryTime EpochMillis `json:"expiry_time"`
+	Comment    string      `json:"comment"`
+}
+
+// NeverExpires returns whether the token does not expire.
+func (t PublicTokenInfo) NeverExpires() bool {
+	return t.ExpiryTime < 0
 }
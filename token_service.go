@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 )
 
@@ -23,8 +22,8 @@ func (s *TokenService) Create(
 	lifetimeSec uint,
 ) (string, *PublicTokenInfo, error) {
 	raw, err := json.Marshal(struct {
-		LifetimeSeconds uint
-		Comment         string
+		LifetimeSeconds uint   `json:"lifetime_seconds,omitempty"`
+		Comment         string `json:"comment,omitempty"`
 	}{
 		lifetimeSec,
 		"",
@@ -33,32 +32,29 @@ func (s *TokenService) Create(
 		return "", nil, err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"TokenService.Create",
 		http.MethodPost,
-		s.client.url+"2.0/token/create",
+		"2.0/token/create",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return "", nil, err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return "", nil, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return "", nil, fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 	createRes := struct {
-		TokenValue string
-		TokenInfo  *PublicTokenInfo
+		TokenValue string           `json:"token_value"`
+		TokenInfo  *PublicTokenInfo `json:"token_info"`
 	}{}
 	err = decoder.Decode(&createRes)
 
-	return createRes.TokenValue, createRes.TokenInfo, nil
+	return createRes.TokenValue, createRes.TokenInfo, err
 }
 
 // List all the valid tokens for a user-workspace pair.
@@ -67,23 +63,20 @@ func (s *TokenService) Create(
 func (s *TokenService) List(
 	ctx context.Context,
 ) ([]PublicTokenInfo, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"TokenService.List",
 		http.MethodGet,
-		s.client.url+"2.0/token/list",
+		"2.0/token/list",
 		nil,
 	)
 	if err != nil {
 		return []PublicTokenInfo{}, err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return []PublicTokenInfo{}, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []PublicTokenInfo{}, fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 	listRes := struct {
@@ -94,39 +87,48 @@ func (s *TokenService) List(
 	return listRes.TokenInfos, err
 }
 
-// Revoke revokes an access token. This call returns the error
-// RESOURCE_DOES_NOT_EXIST if a token with the given ID is not valid.
+// Revoke revokes access tokens, one at a time. This call returns the error
+// RESOURCE_DOES_NOT_EXIST if a token with the given ID is not valid. It stops
+// at the first token that fails, so the tokens before it are revoked and the
+// tokens after it are not.
 //
 // This API is available to all users.
 func (s *TokenService) Revoke(
 	ctx context.Context,
 	tokens []PublicTokenInfo,
 ) error {
+	for _, token := range tokens {
+		if err := s.revoke(ctx, token.TokenID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *TokenService) revoke(ctx context.Context, tokenID string) error {
 	raw, err := json.Marshal(struct {
-		TokenInfos []PublicTokenInfo
+		TokenID string `json:"token_id"`
 	}{
-		tokens,
+		tokenID,
 	})
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"TokenService.Revoke",
 		http.MethodPost,
-		s.client.url+"2.0/token/delete",
+		"2.0/token/delete",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 	return nil
 }
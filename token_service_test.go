@@ -77,8 +77,8 @@ func successTokenHelper(
 func Test_TokenService_Create(t *testing.T) {
 	t.Parallel()
 	res, err := json.Marshal(struct {
-		TokenValue string
-		TokenInfo  *PublicTokenInfo
+		TokenValue string           `json:"token_value"`
+		TokenInfo  *PublicTokenInfo `json:"token_info"`
 	}{
 		"token-123",
 		&PublicTokenInfo{},
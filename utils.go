@@ -11,42 +11,20 @@ import (
 	"github.com/mitchellh/go-homedir"
 )
 
-type netrcRoundTripper struct{}
-
-// RoundTrip implements the http.RoundTripper interface.
-func (r netrcRoundTripper) RoundTrip(
-	req *http.Request,
-) (*http.Response, error) {
-	if err := addAuthFromNetrc(req.URL); err != nil {
-		return nil, err
-	}
-	return http.DefaultClient.Do(req)
-}
-
-// NetrcHTTPClient adds auth from NETRC.
-var NetrcHTTPClient = &http.Client{
-	Transport: netrcRoundTripper{},
-}
+// NetrcHTTPClient adds auth from NETRC. Requests to a host without a netrc
+// entry fail with ErrNoCredentials.
+var NetrcHTTPClient = newCredentialsHTTPClient(NetrcCredentials())
 
 // NewBearerHTTPClient uses a token as an authorization bearer.
 // See:
 // https://docs.databricks.com/api/latest/authentication.html#pass-token-to-bearer-authentication
 func NewBearerHTTPClient(token string) *http.Client {
-	client := *http.DefaultClient
-	client.Transport = bearerRoundTripper{token: token}
-	return &client
-}
-
-type bearerRoundTripper struct {
-	token string
+	return newCredentialsHTTPClient(BearerCredentials(token))
 }
 
-// RoundTrip implements the http.RoundTripper interface.
-func (r bearerRoundTripper) RoundTrip(
-	req *http.Request,
-) (*http.Response, error) {
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", r.token))
-	return http.DefaultClient.Do(req)
+// NewBasicHTTPClient uses a username and password for basic authentication.
+func NewBasicHTTPClient(username, password string) *http.Client {
+	return newCredentialsHTTPClient(BasicCredentials(username, password))
 }
 
 // addAuthFromNetrc adds auth information to the URL from the user's
@@ -0,0 +1,405 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Defaults of the waiters.
+const (
+	// DefaultWaitPollInterval is the interval before the first poll of a
+	// waiter.
+	DefaultWaitPollInterval = 5 * time.Second
+	// DefaultWaitMaxPollInterval is the maximum interval between two polls.
+	DefaultWaitMaxPollInterval = time.Minute
+	// DefaultWaitBackoff is the factor that the poll interval grows by after
+	// every poll.
+	DefaultWaitBackoff = 1.5
+)
+
+// WaitProgress is passed to the progress callback of a waiter after every
+// poll.
+type WaitProgress struct {
+	// Target describes what is waited for, e.g. "cluster 0101-120000-abc to
+	// be RUNNING".
+	Target string
+	// State is the state of the resource at the last poll.
+	State string
+	// Message is the state message of the resource at the last poll.
+	Message string
+	// Polls is the number of polls so far.
+	Polls int
+	// Elapsed is the time since the waiter started.
+	Elapsed time.Duration
+}
+
+// WaitOpt is used for configuring a waiter.
+type WaitOpt func(*waitConfig)
+
+type waitConfig struct {
+	interval    time.Duration
+	maxInterval time.Duration
+	backoff     float64
+	timeout     time.Duration
+	progress    func(WaitProgress)
+}
+
+// WaitPollInterval configures the interval before the first poll. It
+// defaults to DefaultWaitPollInterval.
+func WaitPollInterval(interval time.Duration) WaitOpt {
+	return func(c *waitConfig) {
+		c.interval = interval
+	}
+}
+
+// WaitBackoff configures the factor that the poll interval grows by after
+// every poll, and the maximum interval. A factor of 1 polls at a constant
+// interval. They default to DefaultWaitBackoff and
+// DefaultWaitMaxPollInterval.
+func WaitBackoff(factor float64, maxInterval time.Duration) WaitOpt {
+	return func(c *waitConfig) {
+		c.backoff = factor
+		c.maxInterval = maxInterval
+	}
+}
+
+// WaitTimeout configures how long a waiter waits before it fails. By default
+// a waiter waits until its context is done.
+func WaitTimeout(timeout time.Duration) WaitOpt {
+	return func(c *waitConfig) {
+		c.timeout = timeout
+	}
+}
+
+// WaitProgressFunc configures a callback that is called after every poll,
+// e.g. to log the state of the resource.
+func WaitProgressFunc(progress func(WaitProgress)) WaitOpt {
+	return func(c *waitConfig) {
+		c.progress = progress
+	}
+}
+
+// WaitError is returned by a waiter when the resource reached a state from
+// which the target state can not be reached, e.g. a cluster that terminated
+// while waiting for it to run, or a run that failed.
+type WaitError struct {
+	// Target describes what was waited for.
+	Target string
+	// State is the final state of the resource.
+	State string
+	// Message is the final state message of the resource.
+	Message string
+	// TerminationReason is the reason a cluster terminated, if it did.
+	TerminationReason *TerminationReason
+	// ResultState is the result of a run.
+	ResultState ResultState
+}
+
+// Error implements the error interface.
+func (e *WaitError) Error() string {
+	state := e.State
+	if e.ResultState != "" {
+		state += " " + string(e.ResultState)
+	}
+	msg := fmt.Sprintf("Failed waiting for %s: reached %s", e.Target, state)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.TerminationReason != nil && e.TerminationReason.Code != "" {
+		msg += fmt.Sprintf(" (%s)", e.TerminationReason.Code)
+	}
+	return msg
+}
+
+// waitStatus is the status of a resource at a poll.
+type waitStatus struct {
+	state   string
+	message string
+	done    bool
+}
+
+// waitFor polls until poll reports that it is done or returns an error. An
+// unreachable target is reported by poll returning a *WaitError.
+func waitFor[T any](
+	ctx context.Context,
+	target string,
+	opts []WaitOpt,
+	poll func(ctx context.Context) (T, waitStatus, error),
+) (T, error) {
+	cfg := waitConfig{
+		interval:    DefaultWaitPollInterval,
+		maxInterval: DefaultWaitMaxPollInterval,
+		backoff:     DefaultWaitBackoff,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.timeout)
+		defer cancel()
+	}
+
+	// last and lastStatus are of the last successful poll, which is
+	// reported if the context is done while a poll is in flight.
+	var last T
+	var lastStatus waitStatus
+	stopped := func() (T, error) {
+		if lastStatus.state == "" {
+			return last, fmt.Errorf(
+				"Stopped waiting for %s: %w", target, ctx.Err())
+		}
+		return last, fmt.Errorf(
+			"Stopped waiting for %s, last state %s: %w",
+			target, lastStatus.state, ctx.Err())
+	}
+
+	start := time.Now()
+	interval := cfg.interval
+	for polls := 1; ; polls++ {
+		res, status, err := poll(ctx)
+		if cfg.progress != nil && status.state != "" {
+			cfg.progress(WaitProgress{
+				Target:  target,
+				State:   status.state,
+				Message: status.message,
+				Polls:   polls,
+				Elapsed: time.Since(start),
+			})
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return stopped()
+			}
+			return res, err
+		}
+		if status.done {
+			return res, nil
+		}
+		last, lastStatus = res, status
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return stopped()
+		case <-timer.C:
+		}
+		if cfg.backoff > 1 {
+			interval = time.Duration(float64(interval) * cfg.backoff)
+		}
+		if cfg.maxInterval > 0 && interval > cfg.maxInterval {
+			interval = cfg.maxInterval
+		}
+	}
+}
+
+// clusterTransitions are the state transitions of a cluster, as documented
+// on ClusterState.
+var clusterTransitions = map[ClusterState][]ClusterState{
+	Pending:     {Running, Terminating},
+	Running:     {Resizing, Restarting, Terminating},
+	Restarting:  {Running, Terminating},
+	Resizing:    {Running, Terminating},
+	Terminating: {Terminated},
+	// A terminated cluster only runs again when it is started, which a
+	// waiter does not do.
+	Terminated: {},
+}
+
+// clusterCanReach returns whether a cluster in the from state can reach the
+// to state on its own. Unknown states are assumed to be able to reach any
+// state.
+func clusterCanReach(from, to ClusterState) bool {
+	if from == to {
+		return true
+	}
+	if _, ok := clusterTransitions[from]; !ok {
+		return from != ClusterError
+	}
+	seen := map[ClusterState]bool{from: true}
+	queue := []ClusterState{from}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for _, next := range clusterTransitions[state] {
+			if next == to {
+				return true
+			}
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return false
+}
+
+// WaitForClusterState polls a cluster until it is in the target state. It
+// fails with a *WaitError if the cluster reaches a state from which the
+// target can not be reached, e.g. TERMINATED while waiting for RUNNING.
+func (s *ClusterService) WaitForClusterState(
+	ctx context.Context,
+	clusterID string,
+	target ClusterState,
+	opts ...WaitOpt,
+) (*ClusterGetResponse, error) {
+	desc := fmt.Sprintf("cluster %s to be %s", clusterID, target)
+	return waitFor(ctx, desc, opts, func(
+		ctx context.Context,
+	) (*ClusterGetResponse, waitStatus, error) {
+		cluster, err := s.Get(ctx, clusterID)
+		if err != nil {
+			return nil, waitStatus{}, err
+		}
+		status := waitStatus{
+			state:   string(cluster.State),
+			message: cluster.StateMessage,
+			done:    cluster.State == target,
+		}
+		if !clusterCanReach(cluster.State, target) {
+			return cluster, status, &WaitError{
+				Target:            desc,
+				State:             string(cluster.State),
+				Message:           cluster.StateMessage,
+				TerminationReason: cluster.TerminationReason,
+			}
+		}
+		return cluster, status, nil
+	})
+}
+
+// StartAndWait starts a terminated cluster and waits for it to be RUNNING.
+func (s *ClusterService) StartAndWait(
+	ctx context.Context,
+	clusterID string,
+	opts ...WaitOpt,
+) (*ClusterGetResponse, error) {
+	if err := s.Start(ctx, clusterID); err != nil {
+		return nil, err
+	}
+	return s.WaitForClusterState(ctx, clusterID, Running, opts...)
+}
+
+// CreateAndWait creates a cluster and waits for it to be RUNNING. The
+// cluster ID is returned even if waiting fails.
+func (s *ClusterService) CreateAndWait(
+	ctx context.Context,
+	createReq *ClusterCreateRequest,
+	opts ...WaitOpt,
+) (string, *ClusterGetResponse, error) {
+	clusterID, err := s.Create(ctx, createReq)
+	if err != nil {
+		return "", nil, err
+	}
+	cluster, err := s.WaitForClusterState(ctx, clusterID, Running, opts...)
+	return clusterID, cluster, err
+}
+
+// TerminateAndWait terminates a cluster and waits for it to be TERMINATED.
+func (s *ClusterService) TerminateAndWait(
+	ctx context.Context,
+	clusterID string,
+	opts ...WaitOpt,
+) (*ClusterGetResponse, error) {
+	if err := s.Terminate(ctx, clusterID); err != nil {
+		return nil, err
+	}
+	return s.WaitForClusterState(ctx, clusterID, Terminated, opts...)
+}
+
+// WaitForRun polls a run until it finishes. It fails with a *WaitError if
+// the run did not succeed, e.g. because it failed, was canceled or was
+// skipped. The run is returned in every case.
+func (s *JobsService) WaitForRun(
+	ctx context.Context,
+	runID int64,
+	opts ...WaitOpt,
+) (*JobRunGetResponse, error) {
+	desc := fmt.Sprintf("run %d to finish", runID)
+	return waitFor(ctx, desc, opts, func(
+		ctx context.Context,
+	) (*JobRunGetResponse, waitStatus, error) {
+		run, err := s.RunsGet(ctx, runID)
+		if err != nil {
+			return nil, waitStatus{}, err
+		}
+		state := run.State
+		status := waitStatus{
+			state:   string(state.LifeCycleState),
+			message: state.StateMessage,
+			done:    state.LifeCycleState.IsTerminal(),
+		}
+		if status.done && !state.ResultState.IsSuccess() {
+			return run, status, &WaitError{
+				Target:      desc,
+				State:       string(state.LifeCycleState),
+				Message:     state.StateMessage,
+				ResultState: state.ResultState,
+			}
+		}
+		return run, status, nil
+	})
+}
+
+// WaitForInstall polls the libraries of a cluster until none of them is
+// being installed or uninstalled. It fails with a *WaitError listing the
+// libraries that failed to install. Libraries of a terminated cluster are
+// only installed once it is started.
+func (s *LibrariesService) WaitForInstall(
+	ctx context.Context,
+	clusterID string,
+	opts ...WaitOpt,
+) ([]LibraryFullStatus, error) {
+	desc := fmt.Sprintf("libraries of cluster %s to be installed", clusterID)
+	return waitFor(ctx, desc, opts, func(
+		ctx context.Context,
+	) ([]LibraryFullStatus, waitStatus, error) {
+		statuses, err := s.ClusterStatus(ctx, clusterID)
+		if err != nil {
+			return nil, waitStatus{}, err
+		}
+		counts := map[LibraryStatus]int{}
+		var failures []string
+		done := true
+		for _, status := range statuses {
+			counts[status.Status]++
+			done = done && status.Status.IsTerminal()
+			if status.Status.IsFailed() {
+				failures = append(failures, strings.Join(status.Messages, " "))
+			}
+		}
+		status := waitStatus{state: libraryCounts(counts), done: done}
+		if done && len(failures) > 0 {
+			return statuses, status, &WaitError{
+				Target:  desc,
+				State:   string(LibraryStatusFailed),
+				Message: strings.Join(failures, "; "),
+			}
+		}
+		return statuses, status, nil
+	})
+}
+
+// libraryCounts describes the number of libraries in each status, e.g.
+// "INSTALLED=2 PENDING=1".
+func libraryCounts(counts map[LibraryStatus]int) string {
+	var parts []string
+	for _, status := range []LibraryStatus{
+		LibraryStatusPending,
+		LibraryStatusResolving,
+		LibraryStatusInstalling,
+		LibraryStatusRestored,
+		LibraryStatusInstalled,
+		LibraryStatusSkipped,
+		LibraryStatusFailed,
+		LibraryStatusUninstallOnRestart,
+	} {
+		if counts[status] > 0 {
+			parts = append(parts, fmt.Sprintf("%s=%d", status, counts[status]))
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,272 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// sequenceServer serves the given responses of an endpoint in order,
+// repeating the last one.
+func sequenceServer(
+	t *testing.T,
+	endpoint string,
+	responses ...interface{},
+) (*Client, *int) {
+	t.Helper()
+	var mu sync.Mutex
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/"+endpoint {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			mu.Lock()
+			res := responses[polls]
+			if polls < len(responses)-1 {
+				polls++
+			}
+			mu.Unlock()
+			json.NewEncoder(w).Encode(res)
+		},
+	))
+	t.Cleanup(server.Close)
+	client, err := NewClient("", ClientHost(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, &polls
+}
+
+// fastWait polls without waiting.
+var fastWait = []WaitOpt{
+	WaitPollInterval(time.Millisecond),
+	WaitTimeout(5 * time.Second),
+}
+
+func Test_ClusterService_WaitForClusterState(t *testing.T) {
+	t.Parallel()
+	client, _ := sequenceServer(t, "2.0/clusters/get",
+		map[string]string{"cluster_id": "a", "state": "PENDING"},
+		map[string]string{"cluster_id": "a", "state": "PENDING"},
+		map[string]string{"cluster_id": "a", "state": "RUNNING"},
+	)
+	var progress []WaitProgress
+	opts := append(fastWait, WaitProgressFunc(func(p WaitProgress) {
+		progress = append(progress, p)
+	}))
+	cluster, err := client.Cluster().WaitForClusterState(
+		context.Background(), "a", Running, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cluster.State != Running {
+		t.Fatalf("Unexpected state: %s", cluster.State)
+	}
+	if len(progress) != 3 || progress[0].State != "PENDING" || progress[2].Polls != 3 {
+		t.Fatalf("Unexpected progress: %+v", progress)
+	}
+	if progress[0].Target != "cluster a to be RUNNING" {
+		t.Fatalf("Unexpected target: %s", progress[0].Target)
+	}
+}
+
+func Test_ClusterService_WaitForClusterState_Unreachable(t *testing.T) {
+	t.Parallel()
+	client, _ := sequenceServer(t, "2.0/clusters/get",
+		map[string]string{"cluster_id": "a", "state": "PENDING"},
+		map[string]interface{}{
+			"cluster_id":    "a",
+			"state":         "TERMINATING",
+			"state_message": "Instance launch failed",
+			"termination_reason": map[string]interface{}{
+				"code": "CLOUD_PROVIDER_LAUNCH_FAILURE",
+			},
+		},
+	)
+	cluster, err := client.Cluster().WaitForClusterState(
+		context.Background(), "a", Running, fastWait...)
+	var waitErr *WaitError
+	if !errors.As(err, &waitErr) {
+		t.Fatalf("Expected a WaitError, got %v", err)
+	}
+	if waitErr.State != "TERMINATING" ||
+		waitErr.TerminationReason == nil ||
+		waitErr.TerminationReason.Code != TerminationCloudProviderLaunchFailure {
+		t.Fatalf("Unexpected error: %+v", waitErr)
+	}
+	if !strings.Contains(err.Error(), "Instance launch failed (CLOUD_PROVIDER_LAUNCH_FAILURE)") {
+		t.Fatalf("Unexpected error message: %s", err)
+	}
+	if cluster == nil || cluster.State != Terminating {
+		t.Fatalf("Expected the last cluster, got %+v", cluster)
+	}
+}
+
+func Test_ClusterService_WaitForClusterState_Timeout(t *testing.T) {
+	t.Parallel()
+	client, _ := sequenceServer(t, "2.0/clusters/get",
+		map[string]string{"cluster_id": "a", "state": "PENDING"},
+	)
+	cluster, err := client.Cluster().WaitForClusterState(
+		context.Background(), "a", Running,
+		WaitPollInterval(time.Millisecond),
+		WaitTimeout(20*time.Millisecond),
+	)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a deadline error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "last state PENDING") {
+		t.Fatalf("Unexpected error message: %s", err)
+	}
+	if cluster == nil || cluster.State != Pending {
+		t.Fatalf("Expected the last cluster, got %+v", cluster)
+	}
+}
+
+func Test_waitFor_InFlight(t *testing.T) {
+	t.Parallel()
+	// The deadline expires during the second poll.
+	polls := 0
+	res, err := waitFor(context.Background(), "RUNNING",
+		[]WaitOpt{WaitPollInterval(time.Millisecond), WaitTimeout(20 * time.Millisecond)},
+		func(ctx context.Context) (*ClusterInfo, waitStatus, error) {
+			polls++
+			if polls == 1 {
+				return &ClusterInfo{State: Pending}, waitStatus{state: "PENDING"}, nil
+			}
+			<-ctx.Done()
+			return nil, waitStatus{}, ctx.Err()
+		},
+	)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a deadline error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "last state PENDING") {
+		t.Fatalf("Unexpected error message: %s", err)
+	}
+	if res == nil || res.State != Pending {
+		t.Fatalf("Expected the last cluster, got %+v", res)
+	}
+}
+
+func Test_clusterCanReach(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		from, to  ClusterState
+		reachable bool
+	}{
+		{Pending, Running, true},
+		{Restarting, Running, true},
+		{Running, Terminated, true},
+		{Terminating, Running, false},
+		{Terminated, Running, false},
+		{ClusterError, Running, false},
+		{ClusterUnknown, Running, true},
+		{Terminated, Terminated, true},
+	}
+	for _, test := range tests {
+		if clusterCanReach(test.from, test.to) != test.reachable {
+			t.Fatalf("Expected %s to reach %s: %t",
+				test.from, test.to, test.reachable)
+		}
+	}
+}
+
+func Test_JobsService_WaitForRun(t *testing.T) {
+	t.Parallel()
+	running := map[string]interface{}{
+		"run_id": 1,
+		"state":  map[string]string{"life_cycle_state": "RUNNING"},
+	}
+	client, _ := sequenceServer(t, "2.0/jobs/runs/get", running, map[string]interface{}{
+		"run_id": 1,
+		"state": map[string]string{
+			"life_cycle_state": "TERMINATED",
+			"result_state":     "SUCCESS",
+		},
+	})
+	run, err := client.Jobs().WaitForRun(context.Background(), 1, fastWait...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.State.ResultState != ResultStateSuccess {
+		t.Fatalf("Unexpected state: %+v", run.State)
+	}
+
+	client, _ = sequenceServer(t, "2.0/jobs/runs/get", running, map[string]interface{}{
+		"run_id": 1,
+		"state": map[string]string{
+			"life_cycle_state": "TERMINATED",
+			"result_state":     "FAILED",
+			"state_message":    "Notebook raised an exception",
+		},
+	})
+	run, err = client.Jobs().WaitForRun(context.Background(), 1, fastWait...)
+	var waitErr *WaitError
+	if !errors.As(err, &waitErr) {
+		t.Fatalf("Expected a WaitError, got %v", err)
+	}
+	if waitErr.ResultState != ResultStateFailed || waitErr.Message != "Notebook raised an exception" {
+		t.Fatalf("Unexpected error: %+v", waitErr)
+	}
+	if err.Error() != "Failed waiting for run 1 to finish: reached TERMINATED FAILED: Notebook raised an exception" {
+		t.Fatalf("Unexpected error message: %s", err)
+	}
+	if run == nil || run.RunID != 1 {
+		t.Fatalf("Expected the run, got %+v", run)
+	}
+}
+
+func Test_LibrariesService_WaitForInstall(t *testing.T) {
+	t.Parallel()
+	jar := "dbfs:/a.jar"
+	statuses := func(statuses ...string) map[string]interface{} {
+		libraries := []map[string]interface{}{}
+		for _, status := range statuses {
+			libraries = append(libraries, map[string]interface{}{
+				"library":  Library{Jar: &jar},
+				"status":   status,
+				"messages": []string{"Library " + strings.ToLower(status)},
+			})
+		}
+		return map[string]interface{}{
+			"cluster_id":       "a",
+			"library_statuses": libraries,
+		}
+	}
+	client, polls := sequenceServer(t, "2.0/libraries/cluster-status",
+		statuses("PENDING", "INSTALLING"),
+		statuses("INSTALLED", "INSTALLING"),
+		statuses("INSTALLED", "INSTALLED"),
+	)
+	libraries, err := client.Libraries().WaitForInstall(
+		context.Background(), "a", fastWait...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(libraries) != 2 || *polls != 2 {
+		t.Fatalf("Unexpected libraries after %d polls: %+v", *polls, libraries)
+	}
+
+	client, _ = sequenceServer(t, "2.0/libraries/cluster-status",
+		statuses("INSTALLING", "INSTALLED"),
+		statuses("FAILED", "INSTALLED"),
+	)
+	_, err = client.Libraries().WaitForInstall(
+		context.Background(), "a", fastWait...)
+	var waitErr *WaitError
+	if !errors.As(err, &waitErr) {
+		t.Fatalf("Expected a WaitError, got %v", err)
+	}
+	if waitErr.Message != "Library failed" {
+		t.Fatalf("Unexpected error: %+v", waitErr)
+	}
+}
@@ -5,9 +5,9 @@ type Language string
 
 const (
 	Scala  Language = "SCALA"
-	Python          = "PYTHON"
-	SQL             = "SQL"
-	R               = "R"
+	Python Language = "PYTHON"
+	SQL    Language = "SQL"
+	R      Language = "R"
 )
 
 // ObjectType is the type of the object in workspace.
@@ -15,8 +15,8 @@ type ObjectType string
 
 const (
 	Notebook      ObjectType = "NOTEBOOK"
-	Directory                = "DIRECTORY"
-	LibraryObject            = "LIBRARY"
+	Directory     ObjectType = "DIRECTORY"
+	LibraryObject ObjectType = "LIBRARY"
 )
 
 // ObjectInfo is the information of the object in workspace. It will be
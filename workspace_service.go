@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"net/http"
 )
@@ -28,8 +27,8 @@ func (s *WorkspaceService) Delete(
 	recursive bool,
 ) error {
 	raw, err := json.Marshal(struct {
-		Path      string
-		Recursive bool
+		Path      string `json:"path"`
+		Recursive bool   `json:"recursive"`
 	}{
 		path,
 		recursive,
@@ -38,23 +37,21 @@ func (s *WorkspaceService) Delete(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"WorkspaceService.Delete",
 		http.MethodPost,
-		s.client.url+"2.0/workspace/delete",
+		"2.0/workspace/delete",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 	return nil
 }
 
@@ -67,28 +64,25 @@ func (s *WorkspaceService) Export(
 	ctx context.Context,
 	path string,
 ) ([]byte, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"WorkspaceService.Export",
 		http.MethodGet,
-		s.client.url+"2.0/workspace/export",
+		"2.0/workspace/export",
 		nil,
 	)
 	if err != nil {
 		return []byte{}, err
 	}
-	req = req.WithContext(ctx)
 	q := req.URL.Query()
 	q.Add("path", path)
 	q.Add("direct_download", "true")
 	req.URL.RawQuery = q.Encode()
 
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return []byte{}, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []byte{}, fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	return ioutil.ReadAll(res.Body)
 }
@@ -99,34 +93,31 @@ func (s *WorkspaceService) Export(
 func (s *WorkspaceService) GetStatus(
 	ctx context.Context,
 	path string,
-) (string, string, error) {
-	req, err := http.NewRequest(
+) (Language, ObjectType, error) {
+	req, err := s.client.newRequest(
+		ctx,
+		"WorkspaceService.GetStatus",
 		http.MethodGet,
-		s.client.url+"2.0/workspace/get-status",
+		"2.0/workspace/get-status",
 		nil,
 	)
 	if err != nil {
 		return "", "", err
 	}
-	req = req.WithContext(ctx)
 	q := req.URL.Query()
 	q.Add("path", path)
 	req.URL.RawQuery = q.Encode()
 
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return "", "", err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return "", "", fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 	statusRes := struct {
-		Path       string
-		Language   string
-		ObjectType string
+		Path       string     `json:"path"`
+		Language   Language   `json:"language"`
+		ObjectType ObjectType `json:"object_type"`
 	}{}
 	err = decoder.Decode(&statusRes)
 
@@ -141,16 +132,16 @@ func (s *WorkspaceService) Import(
 	ctx context.Context,
 	path string,
 	content []byte,
-	language string,
+	language Language,
 	overwrite bool,
 	format string,
 ) error {
 	raw, err := json.Marshal(struct {
-		Path      string
-		Content   string
-		Format    string
-		Language  string
-		Overwrite bool
+		Path      string   `json:"path"`
+		Content   string   `json:"content"`
+		Format    string   `json:"format,omitempty"`
+		Language  Language `json:"language,omitempty"`
+		Overwrite bool     `json:"overwrite"`
 	}{
 		path,
 		base64.StdEncoding.EncodeToString(content),
@@ -162,23 +153,21 @@ func (s *WorkspaceService) Import(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"WorkspaceService.Import",
 		http.MethodPost,
-		s.client.url+"2.0/workspace/import",
+		"2.0/workspace/import",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 	return nil
 }
 
@@ -189,31 +178,28 @@ func (s *WorkspaceService) List(
 	ctx context.Context,
 	path string,
 ) ([]ObjectInfo, error) {
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"WorkspaceService.List",
 		http.MethodGet,
-		s.client.url+"2.0/workspace/list",
+		"2.0/workspace/list",
 		nil,
 	)
 	if err != nil {
 		return []ObjectInfo{}, err
 	}
-	req = req.WithContext(ctx)
 	q := req.URL.Query()
 	q.Add("path", path)
 	req.URL.RawQuery = q.Encode()
 
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return []ObjectInfo{}, err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return []ObjectInfo{}, fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
 	defer res.Body.Close()
 	decoder := json.NewDecoder(res.Body)
 	listRes := struct {
-		Objects []ObjectInfo
+		Objects []ObjectInfo `json:"objects"`
 	}{[]ObjectInfo{}}
 	err = decoder.Decode(&listRes)
 
@@ -230,7 +216,7 @@ func (s *WorkspaceService) Mkdirs(
 	path string,
 ) error {
 	raw, err := json.Marshal(struct {
-		Path string
+		Path string `json:"path"`
 	}{
 		path,
 	})
@@ -238,22 +224,20 @@ func (s *WorkspaceService) Mkdirs(
 		return err
 	}
 
-	req, err := http.NewRequest(
+	req, err := s.client.newRequest(
+		ctx,
+		"WorkspaceService.Mkdirs",
 		http.MethodPost,
-		s.client.url+"2.0/workspace/mkdirs",
+		"2.0/workspace/mkdirs",
 		bytes.NewBuffer(raw),
 	)
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(ctx)
-	res, err := s.client.client.Do(req)
+	res, err := s.client.do(req)
 	if err != nil {
 		return err
 	}
-	if res.StatusCode >= 300 || res.StatusCode <= 199 {
-		return fmt.Errorf(
-			"Failed to return a 2XX response: %d", res.StatusCode)
-	}
+	defer res.Body.Close()
 	return nil
 }
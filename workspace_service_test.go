@@ -135,9 +135,9 @@ func Test_WorkspaceService_Export(t *testing.T) {
 func Test_WorkspaceService_GetStatus(t *testing.T) {
 	t.Parallel()
 	res, err := json.Marshal(struct {
-		Path       string
-		Language   string
-		ObjectType string
+		Path       string `json:"path"`
+		Language   string `json:"language"`
+		ObjectType string `json:"object_type"`
 	}{
 		"/foo",
 		"bar",